@@ -0,0 +1,552 @@
+package beacon
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// maxPendingActions is the maximum number of each kind of slashing, deposit and exit kept in
+// the mempool.
+const maxPendingActions = 1024
+
+var errActionPoolFull = errors.New("action pool is full")
+
+type proposerSlashingMempool struct {
+	slashings map[chainhash.Hash]primitives.ProposerSlashing
+
+	// byProposer maps proposers to their pending slashing. Only one slashing is kept for
+	// each proposer since any of them slashes the proposer.
+	byProposer map[uint32]chainhash.Hash
+
+	maxSlashings int
+	lock         *sync.RWMutex
+}
+
+func newProposerSlashingMempool() *proposerSlashingMempool {
+	return &proposerSlashingMempool{
+		slashings:    make(map[chainhash.Hash]primitives.ProposerSlashing),
+		byProposer:   make(map[uint32]chainhash.Hash),
+		maxSlashings: maxPendingActions,
+		lock:         new(sync.RWMutex),
+	}
+}
+
+// Size gets the size of the mempool.
+func (pm *proposerSlashingMempool) Size() int {
+	pm.lock.RLock()
+	defer pm.lock.RUnlock()
+	return len(pm.slashings)
+}
+
+// add adds a slashing to the pool. The lock must be held.
+func (pm *proposerSlashingMempool) add(slashingHash chainhash.Hash, slashing primitives.ProposerSlashing) error {
+	if _, found := pm.byProposer[slashing.ProposerIndex]; found {
+		return errors.New("proposer already has a pending slashing")
+	}
+
+	if len(pm.slashings) >= pm.maxSlashings {
+		return errActionPoolFull
+	}
+
+	pm.slashings[slashingHash] = slashing.Copy()
+	pm.byProposer[slashing.ProposerIndex] = slashingHash
+	return nil
+}
+
+// remove removes a slashing from the pool. The lock must be held.
+func (pm *proposerSlashingMempool) remove(slashingHash chainhash.Hash) {
+	slashing, found := pm.slashings[slashingHash]
+	if !found {
+		return
+	}
+
+	delete(pm.slashings, slashingHash)
+	delete(pm.byProposer, slashing.ProposerIndex)
+}
+
+type casperSlashingMempool struct {
+	slashings map[chainhash.Hash]primitives.CasperSlashing
+
+	// pendingSlashings counts the pending slashings that slash each validator. A slashing is
+	// only added if it slashes a validator that isn't slashed by another pending slashing.
+	pendingSlashings map[uint32]int
+
+	maxSlashings int
+	lock         *sync.RWMutex
+}
+
+func newCasperSlashingMempool() *casperSlashingMempool {
+	return &casperSlashingMempool{
+		slashings:        make(map[chainhash.Hash]primitives.CasperSlashing),
+		pendingSlashings: make(map[uint32]int),
+		maxSlashings:     maxPendingActions,
+		lock:             new(sync.RWMutex),
+	}
+}
+
+// Size gets the size of the mempool.
+func (cm *casperSlashingMempool) Size() int {
+	cm.lock.RLock()
+	defer cm.lock.RUnlock()
+	return len(cm.slashings)
+}
+
+// slashedValidators gets the validators that voted in both votes of a casper slashing.
+func slashedValidators(slashing primitives.CasperSlashing) []uint32 {
+	voted := make(map[uint32]struct{})
+	for _, i := range slashing.Votes1.AggregateSignaturePoC0Indices {
+		voted[i] = struct{}{}
+	}
+	for _, i := range slashing.Votes1.AggregateSignaturePoC1Indices {
+		voted[i] = struct{}{}
+	}
+
+	var slashed []uint32
+	for _, indices := range [][]uint32{slashing.Votes2.AggregateSignaturePoC0Indices, slashing.Votes2.AggregateSignaturePoC1Indices} {
+		for _, i := range indices {
+			if _, found := voted[i]; found {
+				slashed = append(slashed, i)
+				delete(voted, i)
+			}
+		}
+	}
+
+	return slashed
+}
+
+// add adds a slashing to the pool. The lock must be held.
+func (cm *casperSlashingMempool) add(slashingHash chainhash.Hash, slashing primitives.CasperSlashing) error {
+	slashed := slashedValidators(slashing)
+
+	slashesNewValidator := false
+	for _, i := range slashed {
+		if cm.pendingSlashings[i] == 0 {
+			slashesNewValidator = true
+			break
+		}
+	}
+
+	if !slashesNewValidator {
+		return errors.New("every validator in the slashing already has a pending slashing")
+	}
+
+	if len(cm.slashings) >= cm.maxSlashings {
+		return errActionPoolFull
+	}
+
+	cm.slashings[slashingHash] = slashing.Copy()
+	for _, i := range slashed {
+		cm.pendingSlashings[i]++
+	}
+	return nil
+}
+
+// remove removes a slashing from the pool. The lock must be held.
+func (cm *casperSlashingMempool) remove(slashingHash chainhash.Hash) {
+	slashing, found := cm.slashings[slashingHash]
+	if !found {
+		return
+	}
+
+	delete(cm.slashings, slashingHash)
+	for _, i := range slashedValidators(slashing) {
+		cm.pendingSlashings[i]--
+		if cm.pendingSlashings[i] == 0 {
+			delete(cm.pendingSlashings, i)
+		}
+	}
+}
+
+type depositMempool struct {
+	deposits map[chainhash.Hash]primitives.Deposit
+
+	// byIndex maps deposit indices to the pending deposit with that index.
+	byIndex map[uint64]chainhash.Hash
+
+	// depositRoot is the latest deposit tree root blocks should vote for, or the zero hash if
+	// it isn't known.
+	depositRoot chainhash.Hash
+
+	maxDeposits int
+	lock        *sync.RWMutex
+}
+
+func newDepositMempool() *depositMempool {
+	return &depositMempool{
+		deposits:    make(map[chainhash.Hash]primitives.Deposit),
+		byIndex:     make(map[uint64]chainhash.Hash),
+		maxDeposits: maxPendingActions,
+		lock:        new(sync.RWMutex),
+	}
+}
+
+// Size gets the size of the mempool.
+func (dm *depositMempool) Size() int {
+	dm.lock.RLock()
+	defer dm.lock.RUnlock()
+	return len(dm.deposits)
+}
+
+// add adds a deposit to the pool. The lock must be held.
+func (dm *depositMempool) add(depositHash chainhash.Hash, deposit primitives.Deposit) error {
+	if _, found := dm.byIndex[deposit.Index]; found {
+		return errors.New("already have a pending deposit with the same index")
+	}
+
+	if len(dm.deposits) >= dm.maxDeposits {
+		return errActionPoolFull
+	}
+
+	dm.deposits[depositHash] = deposit.Copy()
+	dm.byIndex[deposit.Index] = depositHash
+	return nil
+}
+
+// remove removes a deposit from the pool. The lock must be held.
+func (dm *depositMempool) remove(depositHash chainhash.Hash) {
+	deposit, found := dm.deposits[depositHash]
+	if !found {
+		return
+	}
+
+	delete(dm.deposits, depositHash)
+	delete(dm.byIndex, deposit.Index)
+}
+
+type exitMempool struct {
+	exits map[chainhash.Hash]primitives.Exit
+
+	// byValidator maps validators to their pending exit.
+	byValidator map[uint64]chainhash.Hash
+
+	maxExits int
+	lock     *sync.RWMutex
+}
+
+func newExitMempool() *exitMempool {
+	return &exitMempool{
+		exits:       make(map[chainhash.Hash]primitives.Exit),
+		byValidator: make(map[uint64]chainhash.Hash),
+		maxExits:    maxPendingActions,
+		lock:        new(sync.RWMutex),
+	}
+}
+
+// Size gets the size of the mempool.
+func (em *exitMempool) Size() int {
+	em.lock.RLock()
+	defer em.lock.RUnlock()
+	return len(em.exits)
+}
+
+// add adds an exit to the pool. The lock must be held.
+func (em *exitMempool) add(exitHash chainhash.Hash, exit primitives.Exit) error {
+	if _, found := em.byValidator[exit.ValidatorIndex]; found {
+		return errors.New("validator already has a pending exit")
+	}
+
+	if len(em.exits) >= em.maxExits {
+		return errActionPoolFull
+	}
+
+	em.exits[exitHash] = exit.Copy()
+	em.byValidator[exit.ValidatorIndex] = exitHash
+	return nil
+}
+
+// remove removes an exit from the pool. The lock must be held.
+func (em *exitMempool) remove(exitHash chainhash.Hash) {
+	exit, found := em.exits[exitHash]
+	if !found {
+		return
+	}
+
+	delete(em.exits, exitHash)
+	delete(em.byValidator, exit.ValidatorIndex)
+}
+
+// ProcessNewProposerSlashing validates a proposer slashing against the head state and
+// adds it to the mempool.
+func (m *Mempool) ProcessNewProposerSlashing(slashing primitives.ProposerSlashing) error {
+	slashingHash, err := ssz.HashTreeRoot(slashing)
+	if err != nil {
+		return err
+	}
+
+	pm := m.ProposerSlashingMempool
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+
+	if _, found := pm.slashings[slashingHash]; found {
+		return nil
+	}
+
+	state := m.blockchain.GetState()
+	stateCopy := state.Copy()
+	err = stateCopy.ApplyProposerSlashing(slashing, m.blockchain.config)
+	if err != nil {
+		return err
+	}
+
+	return pm.add(slashingHash, slashing)
+}
+
+// ProcessNewCasperSlashing validates a casper slashing against the head state and
+// adds it to the mempool.
+func (m *Mempool) ProcessNewCasperSlashing(slashing primitives.CasperSlashing) error {
+	slashingHash, err := ssz.HashTreeRoot(slashing)
+	if err != nil {
+		return err
+	}
+
+	cm := m.CasperSlashingMempool
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+
+	if _, found := cm.slashings[slashingHash]; found {
+		return nil
+	}
+
+	state := m.blockchain.GetState()
+	stateCopy := state.Copy()
+	err = stateCopy.ApplyCasperSlashing(slashing, m.blockchain.config)
+	if err != nil {
+		return err
+	}
+
+	return cm.add(slashingHash, slashing)
+}
+
+// ProcessNewDeposit validates a deposit and adds it to the mempool.
+func (m *Mempool) ProcessNewDeposit(deposit primitives.Deposit) error {
+	depositHash, err := ssz.HashTreeRoot(deposit)
+	if err != nil {
+		return err
+	}
+
+	dm := m.DepositMempool
+	dm.lock.Lock()
+	defer dm.lock.Unlock()
+
+	if _, found := dm.deposits[depositHash]; found {
+		return nil
+	}
+
+	state := m.blockchain.GetState()
+	if deposit.Index < state.DepositIndex {
+		return errors.New("deposit was already processed")
+	}
+
+	err = state.ValidateDeposit(deposit, m.blockchain.config)
+	if err != nil {
+		return err
+	}
+
+	return dm.add(depositHash, deposit)
+}
+
+// SetDepositRoot sets the latest root of the deposit tree. Blocks built from the mempool vote
+// for this root so that deposits in the tree can be included once enough blocks vote for it.
+func (m *Mempool) SetDepositRoot(root chainhash.Hash) {
+	m.DepositMempool.lock.Lock()
+	defer m.DepositMempool.lock.Unlock()
+	m.DepositMempool.depositRoot = root
+}
+
+// ProcessNewExit validates an exit against the head state and adds it to the mempool.
+func (m *Mempool) ProcessNewExit(exit primitives.Exit) error {
+	exitHash, err := ssz.HashTreeRoot(exit)
+	if err != nil {
+		return err
+	}
+
+	em := m.ExitMempool
+	em.lock.Lock()
+	defer em.lock.Unlock()
+
+	if _, found := em.exits[exitHash]; found {
+		return nil
+	}
+
+	state := m.blockchain.GetState()
+	stateCopy := state.Copy()
+	err = stateCopy.ApplyExit(exit, m.blockchain.config)
+	if err != nil {
+		return err
+	}
+
+	return em.add(exitHash, exit)
+}
+
+// GetActionsToInclude gets the slashings, deposits and exits to include in a block. Each
+// action is applied to a copy of the state in block order so that conflicting actions
+// aren't included together.
+func (m *Mempool) GetActionsToInclude(slot uint64, lastBlockHash chainhash.Hash, c *config.Config) (*primitives.BlockBody, error) {
+	state, found := m.blockchain.stateManager.GetStateForHash(lastBlockHash)
+	if !found {
+		return nil, errors.New("don't have state for block hash")
+	}
+
+	blockView, err := m.blockchain.GetSubView(lastBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	stateCopy := state.Copy()
+
+	_, err = stateCopy.ProcessSlots(slot, &blockView, c)
+	if err != nil {
+		return nil, err
+	}
+
+	c = c.ForEpoch(stateCopy.EpochIndex)
+
+	body := &primitives.BlockBody{
+		Attestations:      make([]primitives.Attestation, 0),
+		ProposerSlashings: make([]primitives.ProposerSlashing, 0),
+		CasperSlashings:   make([]primitives.CasperSlashing, 0),
+		Deposits:          make([]primitives.Deposit, 0),
+		Exits:             make([]primitives.Exit, 0),
+	}
+
+	pm := m.ProposerSlashingMempool
+	pm.lock.RLock()
+	for _, ps := range pm.slashings {
+		if len(body.ProposerSlashings) >= c.MaxProposerSlashings {
+			break
+		}
+		if err := stateCopy.ApplyProposerSlashing(ps, c); err != nil {
+			continue
+		}
+		body.ProposerSlashings = append(body.ProposerSlashings, ps.Copy())
+	}
+	pm.lock.RUnlock()
+
+	cm := m.CasperSlashingMempool
+	cm.lock.RLock()
+	for _, cs := range cm.slashings {
+		if len(body.CasperSlashings) >= c.MaxCasperSlashings {
+			break
+		}
+		if err := stateCopy.ApplyCasperSlashing(cs, c); err != nil {
+			continue
+		}
+		body.CasperSlashings = append(body.CasperSlashings, cs.Copy())
+	}
+	cm.lock.RUnlock()
+
+	// deposits have to be included in the order of the deposit tree
+	dm := m.DepositMempool
+	dm.lock.RLock()
+	body.DepositRoot = dm.depositRoot
+	depositsByIndex := make(map[uint64]primitives.Deposit)
+	for _, d := range dm.deposits {
+		depositsByIndex[d.Index] = d
+	}
+	dm.lock.RUnlock()
+
+	for len(body.Deposits) < c.MaxDeposits {
+		d, found := depositsByIndex[stateCopy.DepositIndex]
+		if !found {
+			break
+		}
+		if err := stateCopy.ApplyDeposit(d, c); err != nil {
+			break
+		}
+		body.Deposits = append(body.Deposits, d.Copy())
+	}
+
+	em := m.ExitMempool
+	em.lock.RLock()
+	for _, e := range em.exits {
+		if len(body.Exits) >= c.MaxExits {
+			break
+		}
+		if err := stateCopy.ApplyExit(e, c); err != nil {
+			continue
+		}
+		body.Exits = append(body.Exits, e.Copy())
+	}
+	em.lock.RUnlock()
+
+	return body, nil
+}
+
+// removeIncludedActions removes any slashings, deposits and exits included in a block and any
+// that can no longer be included on top of the head state.
+func (m *Mempool) removeIncludedActions(b *primitives.Block) {
+	state := m.blockchain.GetState()
+
+	pm := m.ProposerSlashingMempool
+	pm.lock.Lock()
+	for _, ps := range b.BlockBody.ProposerSlashings {
+		if h, err := ssz.HashTreeRoot(ps); err == nil {
+			pm.remove(h)
+		}
+	}
+	for h, ps := range pm.slashings {
+		if !canBeSlashed(&state, ps.ProposerIndex) {
+			pm.remove(h)
+		}
+	}
+	pm.lock.Unlock()
+
+	cm := m.CasperSlashingMempool
+	cm.lock.Lock()
+	for _, cs := range b.BlockBody.CasperSlashings {
+		if h, err := ssz.HashTreeRoot(cs); err == nil {
+			cm.remove(h)
+		}
+	}
+	for h, cs := range cm.slashings {
+		slashesValidator := false
+		for _, i := range slashedValidators(cs) {
+			if canBeSlashed(&state, i) {
+				slashesValidator = true
+				break
+			}
+		}
+		if !slashesValidator {
+			cm.remove(h)
+		}
+	}
+	cm.lock.Unlock()
+
+	dm := m.DepositMempool
+	dm.lock.Lock()
+	for _, d := range b.BlockBody.Deposits {
+		if h, err := ssz.HashTreeRoot(d); err == nil {
+			dm.remove(h)
+		}
+	}
+	for h, d := range dm.deposits {
+		if d.Index < state.DepositIndex {
+			dm.remove(h)
+		}
+	}
+	dm.lock.Unlock()
+
+	em := m.ExitMempool
+	em.lock.Lock()
+	for _, e := range b.BlockBody.Exits {
+		if h, err := ssz.HashTreeRoot(e); err == nil {
+			em.remove(h)
+		}
+	}
+	for h, e := range em.exits {
+		if e.ValidatorIndex >= uint64(len(state.ValidatorRegistry)) || state.ValidatorRegistry[e.ValidatorIndex].Status != primitives.Active {
+			em.remove(h)
+		}
+	}
+	em.lock.Unlock()
+}
+
+// canBeSlashed checks if a validator can still be slashed in a state.
+func canBeSlashed(state *primitives.State, index uint32) bool {
+	return index < uint32(len(state.ValidatorRegistry)) && state.ValidatorRegistry[index].Status != primitives.ExitedWithPenalty
+}
@@ -0,0 +1,67 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+)
+
+func TestExitMempoolLimit(t *testing.T) {
+	em := newExitMempool()
+	em.maxExits = 2
+
+	for i := uint64(0); i < 2; i++ {
+		err := em.add(chainhash.HashH([]byte{byte(i)}), primitives.Exit{ValidatorIndex: i})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := em.add(chainhash.HashH([]byte{2}), primitives.Exit{ValidatorIndex: 2}); err != errActionPoolFull {
+		t.Fatalf("expected exit to be rejected when the pool is full, got %v", err)
+	}
+
+	em.remove(chainhash.HashH([]byte{0}))
+
+	if err := em.add(chainhash.HashH([]byte{3}), primitives.Exit{ValidatorIndex: 1, Slot: 1}); err == nil {
+		t.Fatal("expected a second exit for the same validator to be rejected")
+	}
+
+	if err := em.add(chainhash.HashH([]byte{2}), primitives.Exit{ValidatorIndex: 2}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCasperSlashingMempoolLimit(t *testing.T) {
+	cm := newCasperSlashingMempool()
+
+	slashing := func(indices ...uint32) primitives.CasperSlashing {
+		return primitives.CasperSlashing{
+			Votes1: primitives.SlashableVoteData{AggregateSignaturePoC0Indices: indices},
+			Votes2: primitives.SlashableVoteData{AggregateSignaturePoC1Indices: indices},
+		}
+	}
+
+	if err := cm.add(chainhash.HashH([]byte{0}), slashing(1, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cm.add(chainhash.HashH([]byte{1}), slashing(2)); err == nil {
+		t.Fatal("expected slashing of validators with pending slashings to be rejected")
+	}
+
+	if err := cm.add(chainhash.HashH([]byte{1}), slashing(2, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	cm.remove(chainhash.HashH([]byte{0}))
+
+	if err := cm.add(chainhash.HashH([]byte{2}), slashing(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cm.add(chainhash.HashH([]byte{3}), slashing(2)); err == nil {
+		t.Fatal("expected slashing of validator 2 to be rejected while another slashing is pending")
+	}
+}
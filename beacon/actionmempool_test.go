@@ -0,0 +1,281 @@
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func signExit(t *testing.T, keys validator.Keystore, validatorIndex uint64) primitives.Exit {
+	var zeroHash chainhash.Hash
+	sig, err := bls.Sign(keys.GetKeyForValidator(uint32(validatorIndex)), zeroHash[:], bls.DomainExit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return primitives.Exit{
+		Slot:           0,
+		ValidatorIndex: validatorIndex,
+		Signature:      sig.Serialize(),
+	}
+}
+
+func signProposal(t *testing.T, keys validator.Keystore, proposerIndex uint32, proposal primitives.ProposalSignedData) [48]byte {
+	proposalHash, err := ssz.HashTreeRoot(proposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := bls.Sign(keys.GetKeyForValidator(proposerIndex), proposalHash[:], bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig.Serialize()
+}
+
+func TestMempoolIncludesSlashingsAndExits(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := &config.RegtestConfig
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+1, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := beacon.NewMempool(b)
+
+	// exiting a validator looks up the proposer of the previous slot, so move past genesis
+	s := b.GetState()
+	proposerIndex, err := s.GetBeaconProposerIndex(0, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposal1 := primitives.ProposalSignedData{Slot: 1, Shard: c.BeaconShardNumber, BlockHash: chainhash.HashH([]byte("proposal 1"))}
+	proposal2 := primitives.ProposalSignedData{Slot: 1, Shard: c.BeaconShardNumber, BlockHash: chainhash.HashH([]byte("proposal 2"))}
+
+	slashing := primitives.ProposerSlashing{
+		ProposerIndex:      1,
+		ProposalData1:      proposal1,
+		ProposalSignature1: signProposal(t, keys, 1, proposal1),
+		ProposalData2:      proposal2,
+		ProposalSignature2: signProposal(t, keys, 1, proposal2),
+	}
+
+	err = m.ProcessNewProposerSlashing(slashing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidSlashing := slashing.Copy()
+	invalidSlashing.ProposalData2 = proposal1
+	if err := m.ProcessNewProposerSlashing(invalidSlashing); err == nil {
+		t.Fatal("expected slashing of the same proposal to be rejected")
+	}
+
+	// validator 1 can't exit after being slashed in the same block
+	for _, validatorIndex := range []uint64{1, 2} {
+		err = m.ProcessNewExit(signExit(t, keys, validatorIndex))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := m.ProcessNewProposerSlashing(slashing); err != nil {
+		t.Fatalf("expected resubmitting a pending slashing to be ignored, got %s", err)
+	}
+
+	otherProposal := primitives.ProposalSignedData{Slot: 1, Shard: c.BeaconShardNumber, BlockHash: chainhash.HashH([]byte("proposal 3"))}
+	otherSlashing := slashing.Copy()
+	otherSlashing.ProposalData2 = otherProposal
+	otherSlashing.ProposalSignature2 = signProposal(t, keys, 1, otherProposal)
+	if err := m.ProcessNewProposerSlashing(otherSlashing); err == nil {
+		t.Fatal("expected a second slashing of the same proposer to be rejected")
+	}
+
+	laterExit := signExit(t, keys, 2)
+	laterExit.Slot = 1
+	if err := m.ProcessNewExit(laterExit); err == nil {
+		t.Fatal("expected a second exit for the same validator to be rejected")
+	}
+
+	badExit := signExit(t, keys, 3)
+	badExit.ValidatorIndex = 4
+	if err := m.ProcessNewExit(badExit); err == nil {
+		t.Fatal("expected exit with invalid signature to be rejected")
+	}
+
+	tip := b.View.Chain.Tip()
+	body, err := m.GetActionsToInclude(tip.Slot+1, tip.Hash, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(body.ProposerSlashings) != 1 {
+		t.Fatalf("expected 1 proposer slashing to be included, got %d", len(body.ProposerSlashings))
+	}
+
+	if len(body.Exits) != 1 || body.Exits[0].ValidatorIndex != 2 {
+		t.Fatalf("expected only the exit for validator 2 to be included, got %v", body.Exits)
+	}
+
+	s = b.GetState()
+	proposerIndex, err = s.GetBeaconProposerIndex(tip.Slot, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = util.MineBlockWithSpecialsAndAttestations(b, nil, body.ProposerSlashings, nil, nil, body.Exits, keys, proposerIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// notifees are called asynchronously, so notify the mempool directly to avoid racing
+	m.ConnectBlock(&primitives.Block{BlockBody: *body})
+
+	if m.ProposerSlashingMempool.Size() != 0 {
+		t.Fatal("expected included proposer slashing to be removed from the mempool")
+	}
+
+	// the exit of the slashed validator can't be included anymore
+	if m.ExitMempool.Size() != 0 {
+		t.Fatalf("expected the exit of the slashed validator to be removed from the mempool, got %d", m.ExitMempool.Size())
+	}
+}
+
+func TestDepositRootVoting(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := &config.RegtestConfig
+
+	numValidators := c.ShardCount*c.TargetCommitteeSize*2 + 1
+
+	b, keys, err := util.SetupBlockchain(numValidators, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := beacon.NewMempool(b)
+
+	emptyRoot := primitives.EmptyDepositTreeRoot(c.DepositTreeDepth)
+	if s := b.GetState(); !s.LatestDepositRoot.IsEqual(&emptyRoot) {
+		t.Fatalf("expected genesis deposit root to be the root of an empty tree, got %s", s.LatestDepositRoot)
+	}
+
+	key := keys.GetKeyForValidator(uint32(numValidators))
+	pub := key.DerivePublicKey()
+	hashPub, err := ssz.HashTreeRoot(pub.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofOfPossession, err := bls.Sign(key, hashPub[:], bls.DomainDeposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deposit := primitives.Deposit{
+		Parameters: primitives.DepositParameters{
+			PubKey:            pub.Serialize(),
+			ProofOfPossession: proofOfPossession.Serialize(),
+		},
+		Amount: c.MaxDeposit,
+		Index:  0,
+	}
+
+	// the deposit is the first leaf of the tree, so the rest of the tree is empty
+	root, err := deposit.Leaf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var emptySubtree chainhash.Hash
+	for i := uint64(0); i < c.DepositTreeDepth; i++ {
+		deposit.Proof = append(deposit.Proof, emptySubtree)
+		root = chainhash.HashH(append(root[:], emptySubtree[:]...))
+		emptySubtree = chainhash.HashH(append(emptySubtree[:], emptySubtree[:]...))
+	}
+
+	if err := m.ProcessNewDeposit(deposit); err == nil {
+		t.Fatal("expected deposit to be rejected before its deposit root is voted in")
+	}
+
+	m.SetDepositRoot(root)
+
+	mineBlock := func() *primitives.Block {
+		tip := b.View.Chain.Tip()
+		state, err := b.GetUpdatedState(tip.Slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := state.GetBeaconProposerIndex(tip.Slot, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, err := m.GetActionsToInclude(tip.Slot+1, tip.Hash, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		block, err := util.MineBlockWithBody(b, *body, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// notifees are called asynchronously, so notify the mempool directly to avoid racing
+		m.ConnectBlock(block)
+
+		return block
+	}
+
+	// blocks vote for the root until the end of the voting period
+	for i := uint64(0); i < 2*c.EpochsPerDepositRootVotingPeriod*c.EpochLength; i++ {
+		s := b.GetState()
+		if s.LatestDepositRoot.IsEqual(&root) {
+			break
+		}
+
+		block := mineBlock()
+		if !block.BlockBody.DepositRoot.IsEqual(&root) {
+			t.Fatal("expected block to vote for the deposit root of the mempool")
+		}
+	}
+
+	if s := b.GetState(); !s.LatestDepositRoot.IsEqual(&root) {
+		t.Fatal("expected deposit root to be updated after a voting period")
+	}
+
+	err = m.ProcessNewDeposit(deposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := mineBlock()
+	if len(block.BlockBody.Deposits) != 1 {
+		t.Fatalf("expected deposit to be included, got %d deposits", len(block.BlockBody.Deposits))
+	}
+
+	s := b.GetState()
+	if len(s.ValidatorRegistry) != numValidators+1 {
+		t.Fatalf("expected deposit to add a validator (expected: %d, got: %d)", numValidators+1, len(s.ValidatorRegistry))
+	}
+
+	if s.DepositIndex != 1 {
+		t.Fatalf("expected deposit index to be 1, got %d", s.DepositIndex)
+	}
+
+	if m.DepositMempool.Size() != 0 {
+		t.Fatal("expected included deposit to be removed from the mempool")
+	}
+}
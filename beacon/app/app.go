@@ -2,6 +2,7 @@ package app
 
 import (
 	"crypto/rand"
+	"net"
 	"os"
 	"os/signal"
 	"sync"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/phoreproject/synapse/primitives"
 
+	"github.com/libp2p/go-libp2p"
 	crypto "github.com/libp2p/go-libp2p-crypto"
 	homedir "github.com/mitchellh/go-homedir"
 	ma "github.com/multiformats/go-multiaddr"
@@ -20,6 +22,7 @@ import (
 	"github.com/phoreproject/synapse/p2p"
 	"github.com/phoreproject/synapse/utils"
 	logger "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 )
 
 // Config is the config of an BeaconApp
@@ -36,6 +39,16 @@ type Config struct {
 	HeartBeatInterval      time.Duration
 	TimeOutInterval        time.Duration
 	MaxPeers               int
+	CheckpointFile         string
+	StateRetention         uint64
+	Clock                  utils.Clock
+
+	// RPCListener is used to serve RPC instead of listening on RPCAddress if it's set.
+	RPCListener net.Listener
+
+	// HostOptions are extra options for the libp2p host, like a transport to use instead of
+	// TCP.
+	HostOptions []libp2p.Option
 
 	// These options are filled in through the chain file.
 	GenesisTime          uint64
@@ -45,11 +58,14 @@ type Config struct {
 
 // NewConfig creates a default Config
 func NewConfig() Config {
+	clock := utils.NewRealClock()
+
 	return Config{
 		RPCProto:               "tcp",
 		ListeningAddress:       "/ip4/127.0.0.1/tcp/20000",
 		RPCAddress:             "127.0.0.1:20002",
-		GenesisTime:            uint64(utils.Now().Unix()),
+		GenesisTime:            uint64(clock.Now().Unix()),
+		Clock:                  clock,
 		InitialValidatorList:   []primitives.InitialValidatorEntry{},
 		NetworkConfig:          &config.MainNetConfig,
 		IsIntegrationTest:      false,
@@ -79,6 +95,8 @@ type BeaconApp struct {
 	// P2P
 	hostNode    *p2p.HostNode
 	syncManager beacon.SyncManager
+
+	rpcServer *grpc.Server
 }
 
 // NewBeaconApp creates a new instance of BeaconApp
@@ -99,6 +117,27 @@ func NewBeaconApp(config Config) *BeaconApp {
 
 // Run runs the main loop of BeaconApp
 func (app *BeaconApp) Run() error {
+	if !app.config.IsIntegrationTest {
+		signalHandler := make(chan os.Signal, 1)
+		signal.Notify(signalHandler, os.Interrupt, syscall.SIGTERM)
+
+		go app.listenForInterrupt(signalHandler)
+	}
+
+	err := app.Start()
+	if err != nil {
+		return err
+	}
+
+	// the main loop for this thread is waiting for the exit and cleaning up
+	app.waitForExit()
+
+	return nil
+}
+
+// Start loads the blockchain and starts syncing and serving RPC without waiting for the app to
+// exit. An app started this way must be stopped with Stop.
+func (app *BeaconApp) Start() error {
 	err := app.loadConfig()
 	if err != nil {
 		return err
@@ -108,13 +147,6 @@ func (app *BeaconApp) Run() error {
 		return err
 	}
 
-	signalHandler := make(chan os.Signal, 1)
-	signal.Notify(signalHandler, os.Interrupt, syscall.SIGTERM)
-
-	if !app.config.IsIntegrationTest {
-		go app.listenForInterrupt(signalHandler)
-	}
-
 	err = app.loadBlockchain()
 	if err != nil {
 		return err
@@ -133,7 +165,9 @@ func (app *BeaconApp) Run() error {
 
 	app.syncManager.Start()
 
-	return app.runMainLoop()
+	app.runMainLoop()
+
+	return nil
 }
 
 func (app *BeaconApp) getHostKey() (crypto.PrivKey, crypto.PubKey, error) {
@@ -172,7 +206,7 @@ func (app *BeaconApp) loadP2P() error {
 		panic(err)
 	}
 
-	hostNode, err := p2p.NewHostNode(addr, pub, priv, app.config.DiscoveryOptions, app.config.TimeOutInterval, app.config.MaxPeers, app.config.HeartBeatInterval, app.blockchain)
+	hostNode, err := p2p.NewHostNode(addr, pub, priv, app.config.DiscoveryOptions, app.config.TimeOutInterval, app.config.MaxPeers, app.config.HeartBeatInterval, app.blockchain, app.config.HostOptions...)
 	if err != nil {
 		panic(err)
 	}
@@ -194,6 +228,21 @@ func (app *BeaconApp) GetHostNode() *p2p.HostNode {
 	return app.hostNode
 }
 
+// GetBlockchain gets the blockchain
+func (app *BeaconApp) GetBlockchain() *beacon.Blockchain {
+	return app.blockchain
+}
+
+// GetMempool gets the mempool
+func (app *BeaconApp) GetMempool() *beacon.Mempool {
+	return app.mempool
+}
+
+// GetSyncManager gets the sync manager
+func (app *BeaconApp) GetSyncManager() beacon.SyncManager {
+	return app.syncManager
+}
+
 // Load user config from configure file
 func (app *BeaconApp) loadConfig() error {
 	return nil
@@ -265,11 +314,32 @@ func (app *BeaconApp) loadBlockchain() error {
 		genesisTime = app.config.GenesisTime
 	}
 
-	blockchain, err := beacon.NewBlockchainWithInitialValidators(app.database, app.config.NetworkConfig, app.config.InitialValidatorList, true, genesisTime)
+	var checkpoint *beacon.Checkpoint
+	if app.config.CheckpointFile != "" {
+		f, err := os.Open(app.config.CheckpointFile)
+		if err != nil {
+			return err
+		}
+
+		checkpoint, err = beacon.ReadCheckpoint(f)
+		if err != nil {
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	blockchain, err := beacon.NewBlockchainWithCheckpoint(app.database, app.config.NetworkConfig, app.config.InitialValidatorList, true, genesisTime, checkpoint)
 	if err != nil {
 		panic(err)
 	}
 
+	blockchain.SetStateCheckpointRetention(app.config.StateRetention)
+	blockchain.SetClock(app.config.Clock)
+
 	app.blockchain = blockchain
 
 	app.mempool = beacon.NewMempool(blockchain)
@@ -277,10 +347,21 @@ func (app *BeaconApp) loadBlockchain() error {
 }
 
 func (app *BeaconApp) createRPCServer() error {
+	lis := app.config.RPCListener
+	if lis == nil {
+		l, err := net.Listen(app.config.RPCProto, app.config.RPCAddress)
+		if err != nil {
+			return err
+		}
+		lis = l
+	}
+
+	app.rpcServer = rpc.NewServer(app.blockchain, app.hostNode, app.mempool)
+
 	go func() {
-		err := rpc.Serve(app.config.RPCProto, app.config.RPCAddress, app.blockchain, app.hostNode, app.mempool)
+		err := app.rpcServer.Serve(lis)
 		if err != nil {
-			panic(err)
+			logger.Errorf("error serving RPC: %s", err)
 		}
 	}()
 
@@ -297,7 +378,7 @@ func (app *BeaconApp) WaitForConnections(numConnections int) {
 	}
 }
 
-func (app *BeaconApp) runMainLoop() error {
+func (app *BeaconApp) runMainLoop() {
 	go func() {
 		app.WaitForConnections(app.config.MinPeerCountToWait)
 
@@ -316,12 +397,14 @@ func (app *BeaconApp) runMainLoop() error {
 				logger.Errorf("error listening for attestations: %s", err)
 			}
 		}()
-	}()
 
-	// the main loop for this thread is waiting for the exit and cleaning up
-	app.waitForExit()
-
-	return nil
+		go func() {
+			err := app.syncManager.ListenForActions()
+			if err != nil {
+				logger.Errorf("error listening for slashings, deposits and exits: %s", err)
+			}
+		}()
+	}()
 }
 
 func (app BeaconApp) listenForInterrupt(signalHandler chan os.Signal) {
@@ -333,24 +416,26 @@ func (app BeaconApp) listenForInterrupt(signalHandler chan os.Signal) {
 func (app BeaconApp) waitForExit() {
 	<-app.exitChan
 
-	app.exit()
+	app.Stop()
 
 	logger.Info("exiting")
 }
 
-func (app BeaconApp) exit() {
-	err := app.database.Close()
-	if err != nil {
-		panic(err)
-	}
+// Stop stops serving RPC, disconnects from peers and closes the database.
+func (app *BeaconApp) Stop() {
+	app.rpcServer.Stop()
 
 	logger.Info("Disconnect peers when exiting")
 
-	for _, p := range app.hostNode.GetPeerList() {
-		p.Disconnect()
+	err := app.hostNode.Close()
+	if err != nil {
+		logger.Errorf("error closing host node: %s", err)
 	}
 
-	os.Exit(0)
+	err = app.database.Close()
+	if err != nil {
+		panic(err)
+	}
 
 	app.exited.Unlock()
 }
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/primitives"
+)
+
+// GenerateChainConfig creates a chain file from lists of initial validators like the ones written
+// by synapsekey. Every proof of possession is checked and validators with duplicate IDs or public
+// keys are rejected. The ID of a validator is its index in the validator registry, so IDs must be
+// consecutive starting at 0.
+func GenerateChainConfig(validatorLists []InitialValidatorList, genesisTime uint64, bootstrapPeers []string, networkID string, networkConfig json.RawMessage) (*ChainConfig, error) {
+	// a genesis time of 0 is replaced by the time the node starts, so the genesis would change
+	if genesisTime == 0 {
+		return nil, errors.New("genesis time must be set")
+	}
+
+	var validators []InitialValidatorInformation
+	validatorIDs := make(map[uint32]struct{})
+	validatorPubKeys := make(map[[96]byte]uint32)
+
+	for _, list := range validatorLists {
+		for _, v := range list.Validators {
+			if _, found := validatorIDs[v.ID]; found {
+				return nil, fmt.Errorf("duplicate validator ID %d", v.ID)
+			}
+
+			entry, err := v.GetEntry()
+			if err != nil {
+				return nil, err
+			}
+
+			if otherID, found := validatorPubKeys[entry.PubKey]; found {
+				return nil, fmt.Errorf("validators %d and %d have the same public key", otherID, v.ID)
+			}
+
+			err = verifyProofOfPossession(entry)
+			if err != nil {
+				return nil, fmt.Errorf("validator %d has invalid proof of possession: %s", v.ID, err)
+			}
+
+			validatorIDs[v.ID] = struct{}{}
+			validatorPubKeys[entry.PubKey] = v.ID
+			validators = append(validators, v)
+		}
+	}
+
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].ID < validators[j].ID
+	})
+
+	for i, v := range validators {
+		if v.ID != uint32(i) {
+			return nil, fmt.Errorf("validator IDs must be consecutive starting at 0, but validator %d is missing", i)
+		}
+	}
+
+	if bootstrapPeers == nil {
+		bootstrapPeers = []string{}
+	}
+
+	chainConfig := &ChainConfig{
+		GenesisTime:    genesisTime,
+		BootstrapPeers: bootstrapPeers,
+		NetworkID:      networkID,
+		InitialValidators: InitialValidatorList{
+			NumValidators: len(validators),
+			Validators:    validators,
+		},
+		NetworkConfig: networkConfig,
+	}
+
+	// make sure nodes will be able to load the chain file
+	_, err := GenerateConfigFromChainConfig(*chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainConfig, nil
+}
+
+// verifyProofOfPossession checks the proof of possession of an initial validator.
+func verifyProofOfPossession(entry *primitives.InitialValidatorEntry) error {
+	pub, err := bls.DeserializePublicKey(entry.PubKey)
+	if err != nil {
+		return err
+	}
+
+	sig, err := bls.DeserializeSignature(entry.ProofOfPossession)
+	if err != nil {
+		return err
+	}
+
+	valid, err := primitives.VerifyProofOfPossession(pub, sig)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return errors.New("signature is not valid")
+	}
+
+	return nil
+}
+
+// GetGenesisBlock gets the genesis block of the chain. The genesis block includes the root of
+// the genesis state.
+func (c ChainConfig) GetGenesisBlock() (*primitives.Block, error) {
+	appConfig, err := GenerateConfigFromChainConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	_, block, err := beacon.GenerateGenesis(appConfig.NetworkConfig, appConfig.InitialValidatorList, true, c.GenesisTime)
+	return block, err
+}
@@ -0,0 +1,173 @@
+package app_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/app"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+const testGenesisTime = 1560000000
+
+var testNetworkConfig = json.RawMessage(`{"EpochLength": 8, "ShardCount": 8, "MaxAttestations": 16}`)
+
+func validatorInformation(id uint32, entry primitives.InitialValidatorEntry) app.InitialValidatorInformation {
+	return app.InitialValidatorInformation{
+		PubKey:                fmt.Sprintf("%x", entry.PubKey),
+		ProofOfPossession:     fmt.Sprintf("%x", entry.ProofOfPossession),
+		WithdrawalShard:       entry.WithdrawalShard,
+		WithdrawalCredentials: fmt.Sprintf("%x", entry.WithdrawalCredentials[:]),
+		DepositSize:           entry.DepositSize,
+		ID:                    id,
+	}
+}
+
+func testConfig(t *testing.T) *config.Config {
+	chainConfig := app.ChainConfig{
+		NetworkID:         "regtest",
+		InitialValidators: validatorList(40),
+		NetworkConfig:     testNetworkConfig,
+	}
+
+	c, err := chainConfig.GetNetworkConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// generateValidatorLists splits initial validators into two lists, with the higher IDs first.
+func generateValidatorLists(t *testing.T, c *config.Config) ([]primitives.InitialValidatorEntry, []app.InitialValidatorList) {
+	keystore := validator.NewFakeKeyStore()
+
+	entries, err := util.InitialValidators(39, &keystore, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists := make([]app.InitialValidatorList, 2)
+	for i, entry := range entries {
+		list := &lists[1-i/20]
+		list.Validators = append(list.Validators, validatorInformation(uint32(i), entry))
+		list.NumValidators++
+	}
+
+	return entries, lists
+}
+
+func TestGenerateChainConfig(t *testing.T) {
+	c := testConfig(t)
+
+	entries, lists := generateValidatorLists(t, c)
+
+	chainConfig, err := app.GenerateChainConfig(lists, testGenesisTime, nil, "regtest", testNetworkConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if chainConfig.InitialValidators.NumValidators != len(entries) {
+		t.Fatalf("expected %d validators in chain file, got %d", len(entries), chainConfig.InitialValidators.NumValidators)
+	}
+
+	for i, v := range chainConfig.InitialValidators.Validators {
+		if v.ID != uint32(i) {
+			t.Fatalf("expected validators to be sorted by ID, got validator %d at index %d", v.ID, i)
+		}
+	}
+
+	genesisBlock, err := chainConfig.GetGenesisBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisHash, err := ssz.HashTreeRoot(*genesisBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), c, entries, false, testGenesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.GenesisHash() != genesisHash {
+		t.Fatalf("expected genesis hash %x to match the genesis hash of a node, %s", genesisHash, b.GenesisHash())
+	}
+}
+
+func TestGenerateChainConfigRejectsInvalidValidators(t *testing.T) {
+	c := testConfig(t)
+
+	tests := []struct {
+		name   string
+		modify func(lists []app.InitialValidatorList)
+	}{
+		{"duplicate ID", func(lists []app.InitialValidatorList) {
+			lists[0].Validators[0].ID = lists[1].Validators[0].ID
+		}},
+		{"duplicate public key", func(lists []app.InitialValidatorList) {
+			id := lists[0].Validators[0].ID
+			lists[0].Validators[0] = lists[1].Validators[0]
+			lists[0].Validators[0].ID = id
+		}},
+		{"invalid proof of possession", func(lists []app.InitialValidatorList) {
+			lists[0].Validators[0].ProofOfPossession = lists[0].Validators[1].ProofOfPossession
+		}},
+		{"malformed public key", func(lists []app.InitialValidatorList) {
+			lists[0].Validators[0].PubKey = lists[0].Validators[0].PubKey[2:]
+		}},
+		{"missing ID", func(lists []app.InitialValidatorList) {
+			lists[1].Validators = lists[1].Validators[1:]
+		}},
+	}
+
+	for _, test := range tests {
+		_, lists := generateValidatorLists(t, c)
+		test.modify(lists)
+
+		_, err := app.GenerateChainConfig(lists, testGenesisTime, nil, "regtest", testNetworkConfig)
+		if err == nil {
+			t.Fatalf("expected validators with %s to be rejected", test.name)
+		}
+	}
+
+	_, lists := generateValidatorLists(t, c)
+
+	_, err := app.GenerateChainConfig(lists, 0, nil, "regtest", testNetworkConfig)
+	if err == nil {
+		t.Fatal("expected chain file without a genesis time to be rejected")
+	}
+
+	_, err = app.GenerateChainConfig(lists, testGenesisTime, []string{"/ip4/127.0.0.1/tcp/20000"}, "regtest", testNetworkConfig)
+	if err == nil {
+		t.Fatal("expected bootstrap peer without a peer ID to be rejected")
+	}
+}
+
+func TestRegtestChainFileHasValidProofsOfPossession(t *testing.T) {
+	f, err := os.Open("../../regtest.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	chainConfig, err := app.ReadChainFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.GenerateChainConfig([]app.InitialValidatorList{chainConfig.InitialValidators}, testGenesisTime, chainConfig.BootstrapPeers, chainConfig.NetworkID, chainConfig.NetworkConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
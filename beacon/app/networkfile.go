@@ -1,8 +1,10 @@
 package app
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -24,6 +26,47 @@ type InitialValidatorInformation struct {
 	ID                    uint32
 }
 
+// GetEntry decodes the initial validator entry.
+func (v InitialValidatorInformation) GetEntry() (*primitives.InitialValidatorEntry, error) {
+	entry := &primitives.InitialValidatorEntry{
+		WithdrawalShard: v.WithdrawalShard,
+		DepositSize:     v.DepositSize,
+	}
+
+	err := decodeHexField(entry.PubKey[:], v.PubKey, "public key", v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeHexField(entry.ProofOfPossession[:], v.ProofOfPossession, "proof of possession", v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decodeHexField(entry.WithdrawalCredentials[:], v.WithdrawalCredentials, "withdrawal credentials", v.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// decodeHexField decodes a hex encoded field of an initial validator that must fill out.
+func decodeHexField(out []byte, field string, name string, id uint32) error {
+	b, err := hex.DecodeString(field)
+	if err != nil {
+		return fmt.Errorf("validator %d has invalid %s: %s", id, name, err)
+	}
+
+	if len(b) != len(out) {
+		return fmt.Errorf("validator %d has %s of %d bytes, expected %d bytes", id, name, len(b), len(out))
+	}
+
+	copy(out, b)
+
+	return nil
+}
+
 // InitialValidatorList is a list of initial validators and the number of validators
 type InitialValidatorList struct {
 	NumValidators int
@@ -36,45 +79,64 @@ type ChainConfig struct {
 	BootstrapPeers    []string
 	NetworkID         string
 	InitialValidators InitialValidatorList
+
+	// NetworkConfig overrides fields of the config of the network ID. If there's no network
+	// ID, this must be a full config.
+	NetworkConfig json.RawMessage `json:",omitempty"`
 }
 
-// GenerateConfigFromChainConfig generates a new config from the passed in network config
-// which should be loaded from a JSON file.
-func GenerateConfigFromChainConfig(chainConfig ChainConfig) (*Config, error) {
-	c := NewConfig()
+// GetNetworkConfig gets the config of the network. The config of the network ID is used as a
+// base with any fields set in NetworkConfig overriding it. The config is checked to be
+// consistent with the initial validators.
+func (c ChainConfig) GetNetworkConfig() (*config.Config, error) {
+	numValidators := len(c.InitialValidators.Validators)
+	if c.InitialValidators.NumValidators != numValidators {
+		return nil, fmt.Errorf("chain file has %d initial validators, but NumValidators is %d", numValidators, c.InitialValidators.NumValidators)
+	}
 
-	c.InitialValidatorList = make([]primitives.InitialValidatorEntry, chainConfig.InitialValidators.NumValidators)
-	for i := range c.InitialValidatorList {
-		validator := chainConfig.InitialValidators.Validators[i]
+	var networkConfig config.Config
 
-		pubKeyBytes, err := hex.DecodeString(validator.PubKey)
-		if err != nil {
-			return nil, err
+	if c.NetworkID != "" {
+		base, found := config.NetworkIDs[c.NetworkID]
+		if !found {
+			return nil, fmt.Errorf("error getting network config for ID: %s", c.NetworkID)
 		}
-		var pubKey [96]byte
-		copy(pubKey[:], pubKeyBytes)
+		networkConfig = base
+	} else if len(c.NetworkConfig) == 0 {
+		return nil, errors.New("chain file must have a network ID or a network config")
+	}
+
+	if len(c.NetworkConfig) > 0 {
+		d := json.NewDecoder(bytes.NewReader(c.NetworkConfig))
+		d.DisallowUnknownFields()
 
-		sigBytes, err := hex.DecodeString(validator.ProofOfPossession)
+		err := d.Decode(&networkConfig)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid network config: %s", err)
 		}
-		var signature [48]byte
-		copy(signature[:], sigBytes)
+	}
+
+	err := networkConfig.Validate(numValidators)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network config: %s", err)
+	}
+
+	return &networkConfig, nil
+}
+
+// GenerateConfigFromChainConfig generates a new config from the passed in network config
+// which should be loaded from a JSON file.
+func GenerateConfigFromChainConfig(chainConfig ChainConfig) (*Config, error) {
+	c := NewConfig()
 
-		withdrawalCredentialsBytes, err := hex.DecodeString(validator.WithdrawalCredentials)
+	c.InitialValidatorList = make([]primitives.InitialValidatorEntry, len(chainConfig.InitialValidators.Validators))
+	for i := range c.InitialValidatorList {
+		entry, err := chainConfig.InitialValidators.Validators[i].GetEntry()
 		if err != nil {
 			return nil, err
 		}
-		var withdrawalCredentials [32]byte
-		copy(withdrawalCredentials[:], withdrawalCredentialsBytes)
-
-		c.InitialValidatorList[i] = primitives.InitialValidatorEntry{
-			PubKey:                pubKey,
-			ProofOfPossession:     signature,
-			WithdrawalCredentials: withdrawalCredentials,
-			WithdrawalShard:       validator.WithdrawalShard,
-			DepositSize:           validator.DepositSize,
-		}
+
+		c.InitialValidatorList[i] = *entry
 	}
 
 	c.GenesisTime = chainConfig.GenesisTime
@@ -83,11 +145,11 @@ func GenerateConfigFromChainConfig(chainConfig ChainConfig) (*Config, error) {
 
 	c.DiscoveryOptions.PeerAddresses = make([]peerstore.PeerInfo, len(chainConfig.BootstrapPeers))
 
-	networkConfig, found := config.NetworkIDs[chainConfig.NetworkID]
-	if !found {
-		return nil, fmt.Errorf("error getting network config for ID: %s", chainConfig.NetworkID)
+	networkConfig, err := chainConfig.GetNetworkConfig()
+	if err != nil {
+		return nil, err
 	}
-	c.NetworkConfig = &networkConfig
+	c.NetworkConfig = networkConfig
 
 	for i := range c.DiscoveryOptions.PeerAddresses {
 		a, err := multiaddr.NewMultiaddr(chainConfig.BootstrapPeers[i])
@@ -104,15 +166,25 @@ func GenerateConfigFromChainConfig(chainConfig ChainConfig) (*Config, error) {
 	return &c, nil
 }
 
-// ReadChainFileToConfig reads a network config from the reader.
-func ReadChainFileToConfig(r io.Reader) (*Config, error) {
-	var networkConfig ChainConfig
+// ReadChainFile reads a chain file from the reader.
+func ReadChainFile(r io.Reader) (*ChainConfig, error) {
+	var chainConfig ChainConfig
 
 	d := json.NewDecoder(r)
-	err := d.Decode(&networkConfig)
+	err := d.Decode(&chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainConfig, nil
+}
+
+// ReadChainFileToConfig reads a network config from the reader.
+func ReadChainFileToConfig(r io.Reader) (*Config, error) {
+	chainConfig, err := ReadChainFile(r)
 	if err != nil {
 		return nil, err
 	}
 
-	return GenerateConfigFromChainConfig(networkConfig)
+	return GenerateConfigFromChainConfig(*chainConfig)
 }
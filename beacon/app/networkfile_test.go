@@ -0,0 +1,94 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/app"
+	"github.com/phoreproject/synapse/beacon/config"
+)
+
+// validatorList creates a list of initial validators of a certain size. The validators
+// themselves aren't checked when getting the network config.
+func validatorList(n int) app.InitialValidatorList {
+	return app.InitialValidatorList{
+		NumValidators: n,
+		Validators:    make([]app.InitialValidatorInformation, n),
+	}
+}
+
+func TestGetNetworkConfigOverrides(t *testing.T) {
+	chainConfig := app.ChainConfig{
+		NetworkID:         "regtest",
+		InitialValidators: validatorList(64),
+		NetworkConfig:     json.RawMessage(`{"SlotDuration": 1, "EpochLength": 8, "ShardCount": 16}`),
+	}
+
+	c, err := chainConfig.GetNetworkConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.SlotDuration != 1 || c.EpochLength != 8 || c.ShardCount != 16 {
+		t.Fatal("expected fields in the chain file to override the network config")
+	}
+
+	if c.TargetCommitteeSize != config.RegtestConfig.TargetCommitteeSize || c.MaxDeposit != config.RegtestConfig.MaxDeposit {
+		t.Fatal("expected fields not in the chain file to come from the network ID")
+	}
+
+	if config.RegtestConfig.EpochLength == 8 {
+		t.Fatal("expected overrides to not modify the built-in network config")
+	}
+}
+
+func TestGetNetworkConfigRejectsInvalidOverrides(t *testing.T) {
+	tests := map[string]app.ChainConfig{
+		"unknown network ID": {
+			NetworkID: "devnet",
+		},
+		"no network ID or config": {},
+		"unknown field": {
+			NetworkID:     "regtest",
+			NetworkConfig: json.RawMessage(`{"SlotLength": 1}`),
+		},
+		"too few validators for committees": {
+			NetworkID:         "regtest",
+			InitialValidators: validatorList(16),
+			NetworkConfig:     json.RawMessage(`{"EpochLength": 8, "ShardCount": 16}`),
+		},
+		"fewer shards than slots": {
+			NetworkID:         "regtest",
+			InitialValidators: validatorList(256),
+			NetworkConfig:     json.RawMessage(`{"ShardCount": 8}`),
+		},
+		"more validators than listed": {
+			NetworkID: "regtest",
+			InitialValidators: app.InitialValidatorList{
+				NumValidators: 256,
+				Validators:    make([]app.InitialValidatorInformation, 16),
+			},
+		},
+	}
+
+	for name, chainConfig := range tests {
+		_, err := chainConfig.GetNetworkConfig()
+		if err == nil {
+			t.Fatalf("expected chain config with %s to be rejected", name)
+		}
+	}
+}
+
+func TestGenerateConfigRejectsWrongValidatorCount(t *testing.T) {
+	chainConfig := app.ChainConfig{
+		NetworkID: "regtest",
+		InitialValidators: app.InitialValidatorList{
+			NumValidators: 256,
+		},
+	}
+
+	_, err := app.GenerateConfigFromChainConfig(chainConfig)
+	if err == nil {
+		t.Fatal("expected chain config with fewer validators than NumValidators to be rejected")
+	}
+}
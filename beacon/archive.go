@@ -0,0 +1,182 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+// maxArchiveBlockSize is the maximum size of a single block in a block archive.
+const maxArchiveBlockSize = 1 << 24
+
+// archiveImportBatchSize is the number of blocks to verify signatures for at once when
+// importing a block archive.
+const archiveImportBatchSize = 64
+
+// WriteArchiveBlock writes a length-prefixed block to a block archive.
+func WriteArchiveBlock(w io.Writer, block *primitives.Block) error {
+	blockBytes, err := proto.Marshal(block.ToProto())
+	if err != nil {
+		return err
+	}
+
+	var lengthBytes [4]byte
+	binary.BigEndian.PutUint32(lengthBytes[:], uint32(len(blockBytes)))
+
+	if _, err := w.Write(lengthBytes[:]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(blockBytes)
+	return err
+}
+
+// ReadArchiveBlock reads a length-prefixed block from a block archive. It returns io.EOF
+// when there are no more blocks.
+func ReadArchiveBlock(r io.Reader) (*primitives.Block, error) {
+	var lengthBytes [4]byte
+	if _, err := io.ReadFull(r, lengthBytes[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(lengthBytes[:])
+	if length > maxArchiveBlockSize {
+		return nil, fmt.Errorf("block in archive is too large (%d bytes)", length)
+	}
+
+	blockBytes := make([]byte, length)
+	if _, err := io.ReadFull(r, blockBytes); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	blockProto := new(pb.Block)
+	err := proto.Unmarshal(blockBytes, blockProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return primitives.BlockFromProto(blockProto)
+}
+
+// ExportBlockArchive writes every block in the main chain of a database after the first block
+// of the block index to a block archive and returns the number of blocks written.
+func ExportBlockArchive(database db.Database, w io.Writer) (int, error) {
+	headHash, err := database.GetHeadBlock()
+	if err != nil {
+		return 0, err
+	}
+
+	// walk back from the head until we reach the first block in the block index
+	var hashes []chainhash.Hash
+	node, err := database.GetBlockNode(*headHash)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		parent, err := database.GetBlockNode(node.Parent)
+		if err != nil {
+			break
+		}
+		hashes = append(hashes, node.Hash)
+		node = parent
+	}
+
+	for i := len(hashes) - 1; i >= 0; i-- {
+		block, err := database.GetBlockForHash(hashes[i])
+		if err != nil {
+			return 0, err
+		}
+
+		err = WriteArchiveBlock(w, block)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(hashes), nil
+}
+
+// ImportBlockArchive processes every block in a block archive that isn't already in the
+// block index and returns the number of blocks imported. Signatures are verified in batches
+// before the blocks are processed.
+func (b *Blockchain) ImportBlockArchive(r io.Reader) (int, error) {
+	imported := 0
+	batch := make([]primitives.Block, 0, archiveImportBatchSize)
+
+	for {
+		block, err := ReadArchiveBlock(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		blockHash, err := ssz.HashTreeRoot(block)
+		if err != nil {
+			return imported, err
+		}
+
+		if b.View.Index.Has(blockHash) {
+			continue
+		}
+
+		batch = append(batch, *block)
+		if len(batch) < archiveImportBatchSize {
+			continue
+		}
+
+		err = b.importBlockBatch(batch)
+		if err != nil {
+			return imported, err
+		}
+		imported += len(batch)
+
+		// processed blocks may still be referenced by notifees, so don't reuse the batch
+		batch = make([]primitives.Block, 0, archiveImportBatchSize)
+	}
+
+	err := b.importBlockBatch(batch)
+	if err != nil {
+		return imported, err
+	}
+	imported += len(batch)
+
+	return imported, nil
+}
+
+func (b *Blockchain) importBlockBatch(blocks []primitives.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	err := b.VerifyBlockSignatures(blocks)
+	if err != nil {
+		return err
+	}
+
+	for i := range blocks {
+		_, _, err := b.ProcessBlock(&blocks[i], false, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"blocks": len(blocks),
+		"slot":   blocks[len(blocks)-1].BlockHeader.SlotNumber,
+	}).Info("imported blocks from archive")
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package beacon_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func TestBlockArchiveExportImport(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisTime := b.GetState().GenesisTime
+
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+
+	archive := new(bytes.Buffer)
+	for i := range blocks {
+		err := beacon.WriteArchiveBlock(archive, &blocks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	archiveBytes := archive.Bytes()
+
+	dir, err := ioutil.TempDir("", "synapse-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	database := db.NewBadgerDB(dir)
+	defer database.Close()
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(database, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := b2.ImportBlockArchive(bytes.NewReader(archiveBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imported != len(blocks) {
+		t.Fatalf("expected to import %d blocks, imported %d", len(blocks), imported)
+	}
+
+	if b2.View.Chain.Tip().Hash != b.View.Chain.Tip().Hash {
+		t.Fatal("expected imported chain to have the same tip")
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	finalizedNode2, _ := b2.View.GetFinalizedHead()
+	if finalizedNode2.Hash != finalizedNode.Hash {
+		t.Fatal("expected imported chain to have the same finalized head")
+	}
+
+	// importing again shouldn't process any blocks
+	imported, err = b2.ImportBlockArchive(bytes.NewReader(archiveBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported != 0 {
+		t.Fatalf("expected blocks that were already imported to be skipped, imported %d", imported)
+	}
+
+	exported := new(bytes.Buffer)
+	exportedCount, err := beacon.ExportBlockArchive(database, exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exportedCount != len(blocks) {
+		t.Fatalf("expected to export %d blocks, exported %d", len(blocks), exportedCount)
+	}
+
+	if !bytes.Equal(exported.Bytes(), archiveBytes) {
+		t.Fatal("expected exported archive to match the imported archive")
+	}
+}
+
+func TestBlockArchiveInvalidSignature(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+
+	// sign the last block with the wrong signature
+	invalidBlock := &blocks[len(blocks)-1]
+	invalidBlock.BlockHeader.Signature = invalidBlock.BlockHeader.RandaoReveal
+
+	invalidHash, err := ssz.HashTreeRoot(invalidBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := new(bytes.Buffer)
+	for i := range blocks {
+		err := beacon.WriteArchiveBlock(archive, &blocks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &c, validators, true, b.GetState().GenesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b2.ImportBlockArchive(archive)
+	if err == nil || !strings.Contains(err.Error(), "invalid signature") {
+		t.Fatalf("expected archive with an invalid signature to fail to import (got error: %v)", err)
+	}
+
+	if b2.View.Index.Has(invalidHash) {
+		t.Fatal("expected block with an invalid signature to not be imported")
+	}
+}
@@ -2,6 +2,8 @@ package beacon
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -33,38 +35,75 @@ type Blockchain struct {
 	DB           db.Database
 	config       *config.Config
 	stateManager *StateManager
+	forkChoice   *ForkChoice
+	genesisHash  chainhash.Hash
+	clock        utils.Clock
 
-	Notifees []BlockchainNotifee
+	// pruneLock protects the fields below
+	pruneLock    *sync.Mutex
+	lastPruned   *BlockNode
+	prunedBlocks uint64
+
+	// attestationStateLock protects the fields below
+	attestationStateLock *sync.Mutex
+	attestationState     *primitives.State
+	attestationStateKey  attestationStateKey
+
+	notifees []*notifeeQueue
 }
 
-func (b *Blockchain) getLatestAttestationTarget(validator uint32) (*BlockNode, error) {
-	att, err := b.DB.GetLatestAttestation(validator)
-	if err != nil {
-		return nil, err
-	}
-	bl, err := b.DB.GetBlockForHash(att.Data.BeaconBlockHash)
+// GenerateGenesis creates the genesis state and genesis block of a chain with the specified
+// initial validators.
+func GenerateGenesis(config *config.Config, validators []primitives.InitialValidatorEntry, skipValidation bool, genesisTime uint64) (*primitives.State, *primitives.Block, error) {
+	initialState, err := primitives.InitializeState(config, validators, genesisTime, skipValidation)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	h, err := ssz.HashTreeRoot(bl)
+
+	stateRoot, err := initialState.HashTreeRoot()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	node := b.View.Index.GetBlockNodeByHash(h)
-	if node == nil {
-		return nil, errors.New("couldn't find block attested to by validator in index")
+	block0 := &primitives.Block{
+		BlockHeader: primitives.BlockHeader{
+			SlotNumber:   0,
+			StateRoot:    stateRoot,
+			ParentRoot:   zeroHash,
+			RandaoReveal: bls.EmptySignature.Serialize(),
+			Signature:    bls.EmptySignature.Serialize(),
+		},
+		BlockBody: primitives.BlockBody{
+			ProposerSlashings: []primitives.ProposerSlashing{},
+			CasperSlashings:   []primitives.CasperSlashing{},
+			Attestations:      []primitives.Attestation{},
+			Deposits:          []primitives.Deposit{},
+			Exits:             []primitives.Exit{},
+		},
 	}
-	return node, nil
+
+	return initialState, block0, nil
 }
 
 // NewBlockchainWithInitialValidators creates a new blockchain with the specified
 // initial validators.
 func NewBlockchainWithInitialValidators(db db.Database, config *config.Config, validators []primitives.InitialValidatorEntry, skipValidation bool, genesisTime uint64) (*Blockchain, error) {
+	return NewBlockchainWithCheckpoint(db, config, validators, skipValidation, genesisTime, nil)
+}
+
+// NewBlockchainWithCheckpoint creates a new blockchain with the specified initial validators.
+// If the database is empty and a checkpoint is given, the blockchain starts from the
+// checkpoint instead of the genesis block.
+func NewBlockchainWithCheckpoint(db db.Database, config *config.Config, validators []primitives.InitialValidatorEntry, skipValidation bool, genesisTime uint64, checkpoint *Checkpoint) (*Blockchain, error) {
 	b := &Blockchain{
-		DB:     db,
-		config: config,
-		View:   NewBlockchainView(),
+		DB:         db,
+		config:     config,
+		View:       NewBlockchainView(),
+		forkChoice: NewForkChoice(),
+		pruneLock:  new(sync.Mutex),
+
+		attestationStateLock: new(sync.Mutex),
+		clock:      utils.NewRealClock(),
 	}
 
 	sm, err := NewStateManager(config, genesisTime, b, db)
@@ -74,54 +113,58 @@ func NewBlockchainWithInitialValidators(db db.Database, config *config.Config, v
 
 	b.stateManager = sm
 
-	initialState, err := primitives.InitializeState(config, validators, genesisTime, skipValidation)
+	initialState, block0, err := GenerateGenesis(config, validators, skipValidation, genesisTime)
 	if err != nil {
 		return nil, err
 	}
 
-	stateRoot, err := ssz.HashTreeRoot(initialState)
+	stateRoot := block0.BlockHeader.StateRoot
+
+	blockHash, err := ssz.HashTreeRoot(*block0)
 	if err != nil {
 		return nil, err
 	}
 
-	block0 := primitives.Block{
-		BlockHeader: primitives.BlockHeader{
-			SlotNumber:   0,
-			StateRoot:    stateRoot,
-			ParentRoot:   zeroHash,
-			RandaoReveal: bls.EmptySignature.Serialize(),
-			Signature:    bls.EmptySignature.Serialize(),
-		},
-		BlockBody: primitives.BlockBody{
-			ProposerSlashings: []primitives.ProposerSlashing{},
-			CasperSlashings:   []primitives.CasperSlashing{},
-			Attestations:      []primitives.Attestation{},
-			Deposits:          []primitives.Deposit{},
-			Exits:             []primitives.Exit{},
-		},
+	b.genesisHash = blockHash
+
+	// if the chain was started from a checkpoint, the block index starts at the checkpoint
+	if rootHash, err := b.DB.GetBlockIndexRoot(); err == nil {
+		logrus.WithField("checkpointHash", rootHash).Info("loading blockchain started from checkpoint")
+
+		if err := b.loadBlockchainFromDisk(*rootHash); err != nil {
+			return nil, err
+		}
+
+		return b, nil
 	}
 
-	blockHash, err := ssz.HashTreeRoot(block0)
-	if err != nil {
-		return nil, err
+	// check if the block index exists in the database
+	_, err = b.DB.GetBlockNode(blockHash)
+	if err != nil && checkpoint != nil {
+		// if it doesn't and we have a checkpoint, start from the checkpoint
+		if err := b.initializeDatabaseFromCheckpoint(checkpoint); err != nil {
+			return nil, err
+		}
+
+		return b, nil
 	}
+	blockIndexExists := err == nil
 
 	logrus.WithField("genesisHash", chainhash.Hash(blockHash)).Info("initializing blockchain with genesis block")
 
-	err = b.DB.SetBlock(block0)
+	err = b.DB.SetBlock(*block0)
 	if err != nil {
 		return nil, err
 	}
 
 	// this is a new database, so let's populate it with default values
-	node, err := b.View.Index.AddBlockNodeToIndex(&block0, blockHash, stateRoot)
+	node, err := b.View.Index.AddBlockNodeToIndex(block0, blockHash, stateRoot)
 	if err != nil {
 		return nil, err
 	}
+	b.forkChoice.AddBlock(node)
 
-	// check if the block index exists in the database
-	_, err = b.DB.GetBlockNode(blockHash)
-	if err != nil {
+	if !blockIndexExists {
 		// if it doesn't, initialize the database
 		if err := b.initializeDatabase(node, *initialState); err != nil {
 			return nil, err
@@ -152,6 +195,111 @@ func (b *Blockchain) GetUpdatedState(upTo uint64) (*primitives.State, error) {
 	return &tipStateCopy, nil
 }
 
+// GetStateForBlock gets the state after processing a certain block, regenerating it
+// from the closest checkpoint if it has already been discarded.
+func (b *Blockchain) GetStateForBlock(blockHash chainhash.Hash) (*primitives.State, error) {
+	return b.stateManager.RegenerateStateForHash(blockHash)
+}
+
+// SetStateCheckpointRetention sets the number of epochs before the finalized epoch to keep
+// state checkpoints for. If it is 0, all state checkpoints are kept.
+func (b *Blockchain) SetStateCheckpointRetention(epochs uint64) {
+	b.stateManager.SetCheckpointRetention(epochs)
+}
+
+// GetReplayedBlockCount gets the number of blocks replayed to regenerate states since the
+// blockchain was loaded.
+func (b *Blockchain) GetReplayedBlockCount() uint64 {
+	return b.stateManager.GetReplayedBlockCount()
+}
+
+// GetStateAtSlot gets the state of the main chain at a certain slot. Slots more than one slot past
+// both the current slot and the tip are rejected because every slot up to them would have to be
+// processed.
+func (b *Blockchain) GetStateAtSlot(slot uint64) (*primitives.State, error) {
+	tip := b.View.Chain.Tip()
+
+	maxSlot := b.GetCurrentSlot()
+	if tip.Slot > maxSlot {
+		maxSlot = tip.Slot
+	}
+
+	if slot > maxSlot+1 {
+		return nil, fmt.Errorf("can't get state at slot %d past the current slot %d", slot, maxSlot)
+	}
+
+	if slot >= tip.Slot {
+		return b.GetUpdatedState(slot)
+	}
+
+	node, err := b.View.Chain.GetBlockBySlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := b.GetStateForBlock(node.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	view := NewChainView(node)
+
+	_, err = state.ProcessSlots(slot, &view, b.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// attestationStateKey is the head and epoch a state used to verify attestations was processed
+// from and up to.
+type attestationStateKey struct {
+	head  chainhash.Hash
+	epoch uint64
+}
+
+// GetStateForAttestation gets a state to verify the signature of an attestation at a certain
+// slot. The committees of the head state don't include the next epoch until a block in that
+// epoch is processed, so the state is processed up to the slot of the attestation if it's after
+// the head. Processed states are cached for each head and epoch, so many attestations don't
+// cause the same slots to be processed again. The state must not be modified.
+func (b *Blockchain) GetStateForAttestation(slot uint64) (*primitives.State, error) {
+	tip := b.View.Chain.Tip()
+
+	state, found := b.stateManager.GetStateForHash(tip.Hash)
+	if !found {
+		return nil, errors.New("don't have state for tip")
+	}
+
+	if slot <= state.Slot {
+		return state, nil
+	}
+
+	// processing slots up to the attestation runs every epoch transition before its slot
+	key := attestationStateKey{
+		head:  tip.Hash,
+		epoch: (slot - 1) / b.config.EpochLength,
+	}
+
+	b.attestationStateLock.Lock()
+	defer b.attestationStateLock.Unlock()
+
+	if b.attestationState != nil && b.attestationStateKey == key {
+		return b.attestationState, nil
+	}
+
+	updatedState, err := b.GetStateAtSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	b.attestationState = updatedState
+	b.attestationStateKey = key
+
+	return updatedState, nil
+}
+
 // GetNextSlotTime returns the timestamp of the next slot.
 func (b *Blockchain) GetNextSlotTime() time.Time {
 	return time.Unix(int64((b.View.Chain.Tip().Slot+1)*uint64(b.config.SlotDuration)+b.stateManager.GetGenesisTime()), 0)
@@ -166,6 +314,11 @@ func (b *Blockchain) Height() uint64 {
 	return uint64(h)
 }
 
+// TipSlot gets the slot of the tip of the main chain.
+func (b *Blockchain) TipSlot() uint64 {
+	return b.View.Chain.Tip().Slot
+}
+
 // GetBlockByHash gets a block by Hash.
 func (b *Blockchain) GetBlockByHash(h chainhash.Hash) (*primitives.Block, error) {
 	block, err := b.DB.GetBlockForHash(h)
@@ -181,9 +334,23 @@ func (b *Blockchain) GetConfig() *config.Config {
 	return b.config
 }
 
+// SetClock sets the clock used to tell the current slot. This should be called before the
+// blockchain is used.
+func (b *Blockchain) SetClock(clock utils.Clock) {
+	b.clock = clock
+}
+
+// GetClock gets the clock used to tell the current slot.
+func (b *Blockchain) GetClock() utils.Clock {
+	return b.clock
+}
+
 // GetCurrentSlot gets the current slot according to the time.
 func (b *Blockchain) GetCurrentSlot() uint64 {
-	currentTime := uint64(utils.Now().Unix())
+	currentTime := uint64(b.clock.Now().Unix())
+	if currentTime < b.stateManager.GetGenesisTime() {
+		return 0
+	}
 
 	timeSinceGenesis := currentTime - b.stateManager.GetGenesisTime()
 
@@ -192,5 +359,16 @@ func (b *Blockchain) GetCurrentSlot() uint64 {
 
 // GenesisHash gets the genesis hash for the chain.
 func (b *Blockchain) GenesisHash() chainhash.Hash {
-	return b.View.Chain.GetBlockByHeight(0).Hash
+	return b.genesisHash
+}
+
+// ForkVersion gets the fork version of the current slot according to the fork schedule. Each
+// epoch starts after the first slot of the epoch, which is when its fork activates.
+func (b *Blockchain) ForkVersion() uint64 {
+	currentSlot := b.GetCurrentSlot()
+	if currentSlot == 0 {
+		return b.config.ForkVersionAtEpoch(0)
+	}
+
+	return b.config.ForkVersionAtEpoch((currentSlot - 1) / b.config.EpochLength)
 }
@@ -0,0 +1,357 @@
+package beacon
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// ParentRequest is a request for the blocks missing before some of the orphans in the pool.
+type ParentRequest struct {
+	// Missing is the hash of the first block missing before the orphans.
+	Missing chainhash.Hash
+
+	// Child is an orphan built on the missing block. Blocks should be requested up to it.
+	Child chainhash.Hash
+
+	// Peer is the peer that most recently sent an orphan waiting for the missing block. It is
+	// empty if the peer isn't known.
+	Peer peer.ID
+}
+
+type missingBlock struct {
+	requested time.Time
+	peer      peer.ID
+}
+
+// OrphanBlockPool keeps blocks whose parent hasn't been processed yet until the parent
+// arrives. When the pool is full, the oldest blocks are evicted. It keeps track of when the
+// first missing ancestor of each chain of orphans was requested so it can be requested again
+// if it doesn't arrive.
+type OrphanBlockPool struct {
+	lock       *sync.Mutex
+	maxBlocks  int
+	retryAfter time.Duration
+
+	blocks   map[chainhash.Hash]*primitives.Block
+	byParent map[chainhash.Hash][]chainhash.Hash
+	missing  map[chainhash.Hash]*missingBlock
+
+	// order is the hashes of the blocks in the order they were added
+	order []chainhash.Hash
+}
+
+// NewOrphanBlockPool creates an orphan block pool that holds up to maxBlocks blocks. Missing
+// blocks are requested again if they haven't arrived retryAfter after they were requested.
+func NewOrphanBlockPool(maxBlocks int, retryAfter time.Duration) *OrphanBlockPool {
+	return &OrphanBlockPool{
+		lock:       new(sync.Mutex),
+		maxBlocks:  maxBlocks,
+		retryAfter: retryAfter,
+		blocks:     make(map[chainhash.Hash]*primitives.Block),
+		byParent:   make(map[chainhash.Hash][]chainhash.Hash),
+		missing:    make(map[chainhash.Hash]*missingBlock),
+	}
+}
+
+// Add adds a block received from a peer to the pool. This returns a request for the first
+// missing ancestor of the block if it hasn't been requested yet or was requested longer than
+// the retry interval ago, including when the parent of the block is an orphan itself.
+func (p *OrphanBlockPool) Add(block *primitives.Block, from peer.ID, now time.Time) (ParentRequest, bool, error) {
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		return ParentRequest{}, false, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, found := p.blocks[blockHash]; found {
+		return ParentRequest{}, false, nil
+	}
+
+	for len(p.order) >= p.maxBlocks {
+		p.evict(p.order[0])
+	}
+
+	parentRoot := block.BlockHeader.ParentRoot
+
+	p.blocks[blockHash] = block
+	p.byParent[parentRoot] = append(p.byParent[parentRoot], blockHash)
+	p.order = append(p.order, blockHash)
+
+	// orphans waiting for this block are now waiting for its first missing ancestor
+	delete(p.missing, blockHash)
+
+	missingHash := p.firstMissingAncestor(parentRoot)
+	missing, found := p.missing[missingHash]
+	if !found {
+		missing = new(missingBlock)
+		p.missing[missingHash] = missing
+	}
+
+	if from != "" {
+		missing.peer = from
+	}
+
+	if found && now.Sub(missing.requested) < p.retryAfter {
+		return ParentRequest{}, false, nil
+	}
+
+	missing.requested = now
+
+	return p.request(missingHash, missing), true, nil
+}
+
+// firstMissingAncestor follows the parents of orphans in the pool starting with a certain
+// block until it finds one that isn't in the pool.
+func (p *OrphanBlockPool) firstMissingAncestor(blockHash chainhash.Hash) chainhash.Hash {
+	for {
+		block, found := p.blocks[blockHash]
+		if !found {
+			return blockHash
+		}
+		blockHash = block.BlockHeader.ParentRoot
+	}
+}
+
+func (p *OrphanBlockPool) request(missingHash chainhash.Hash, missing *missingBlock) ParentRequest {
+	return ParentRequest{
+		Missing: missingHash,
+		Child:   p.byParent[missingHash][0],
+		Peer:    missing.peer,
+	}
+}
+
+// ExpiredRequests gets requests for the missing blocks that were requested longer than the
+// retry interval ago and marks them as requested again.
+func (p *OrphanBlockPool) ExpiredRequests(now time.Time) []ParentRequest {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var requests []ParentRequest
+	for missingHash, missing := range p.missing {
+		if now.Sub(missing.requested) < p.retryAfter {
+			continue
+		}
+
+		missing.requested = now
+		requests = append(requests, p.request(missingHash, missing))
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Missing.String() < requests[j].Missing.String()
+	})
+
+	return requests
+}
+
+// evict removes a block from the pool to make room for another one. Any orphans built on it
+// are now waiting for it, so it is requested the next time expired requests are checked.
+func (p *OrphanBlockPool) evict(blockHash chainhash.Hash) {
+	p.remove(blockHash)
+
+	if _, found := p.missing[blockHash]; !found && len(p.byParent[blockHash]) > 0 {
+		p.missing[blockHash] = new(missingBlock)
+	}
+}
+
+// remove removes a block from the pool.
+func (p *OrphanBlockPool) remove(blockHash chainhash.Hash) {
+	block, found := p.blocks[blockHash]
+	if !found {
+		return
+	}
+
+	delete(p.blocks, blockHash)
+
+	parentRoot := block.BlockHeader.ParentRoot
+	siblings := p.byParent[parentRoot]
+	for i := range siblings {
+		if siblings[i].IsEqual(&blockHash) {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	if len(siblings) == 0 {
+		delete(p.byParent, parentRoot)
+		delete(p.missing, parentRoot)
+	} else {
+		p.byParent[parentRoot] = siblings
+	}
+
+	for i := range p.order {
+		if p.order[i].IsEqual(&blockHash) {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// TakeChildren removes and returns the blocks in the pool waiting for a certain parent.
+func (p *OrphanBlockPool) TakeChildren(parentRoot chainhash.Hash) []*primitives.Block {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// remove modifies the list of children, so copy it first
+	childHashes := append([]chainhash.Hash(nil), p.byParent[parentRoot]...)
+	children := make([]*primitives.Block, len(childHashes))
+	for i, h := range childHashes {
+		children[i] = p.blocks[h]
+		p.remove(h)
+	}
+
+	return children
+}
+
+// Has checks if a block is in the pool.
+func (p *OrphanBlockPool) Has(blockHash chainhash.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, found := p.blocks[blockHash]
+	return found
+}
+
+// Len gets the number of blocks in the pool.
+func (p *OrphanBlockPool) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return len(p.blocks)
+}
+
+// FutureBlock is a block received before its slot started.
+type FutureBlock struct {
+	Block *primitives.Block
+
+	// From is the peer the block was received from. It is empty if the peer isn't known.
+	From peer.ID
+
+	hash chainhash.Hash
+}
+
+// FutureBlockQueue keeps blocks received before their slot started until the slot starts.
+// When the queue is full, the blocks farthest in the future are evicted first.
+type FutureBlockQueue struct {
+	lock          *sync.Mutex
+	maxBlocks     int
+	maxSlotsAhead uint64
+
+	bySlot map[uint64][]FutureBlock
+	hashes map[chainhash.Hash]struct{}
+}
+
+// NewFutureBlockQueue creates a future block queue that holds up to maxBlocks blocks that are
+// at most maxSlotsAhead slots after the current slot.
+func NewFutureBlockQueue(maxBlocks int, maxSlotsAhead uint64) *FutureBlockQueue {
+	return &FutureBlockQueue{
+		lock:          new(sync.Mutex),
+		maxBlocks:     maxBlocks,
+		maxSlotsAhead: maxSlotsAhead,
+		bySlot:        make(map[uint64][]FutureBlock),
+		hashes:        make(map[chainhash.Hash]struct{}),
+	}
+}
+
+// Add queues a block received from a peer to be processed once its slot starts. Blocks too far
+// after the current slot are dropped. If the queue is full, the block farthest in the future
+// is evicted to make room unless the new block is at least as far in the future. This returns
+// true if the block was queued.
+func (q *FutureBlockQueue) Add(block *primitives.Block, from peer.ID, currentSlot uint64) (bool, error) {
+	slot := block.BlockHeader.SlotNumber
+	if slot > currentSlot+q.maxSlotsAhead {
+		return false, nil
+	}
+
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		return false, err
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, found := q.hashes[blockHash]; found {
+		return true, nil
+	}
+
+	if len(q.hashes) >= q.maxBlocks {
+		farthestSlot := uint64(0)
+		for s := range q.bySlot {
+			if s > farthestSlot {
+				farthestSlot = s
+			}
+		}
+
+		if farthestSlot <= slot {
+			return false, nil
+		}
+
+		q.evict(farthestSlot)
+	}
+
+	q.hashes[blockHash] = struct{}{}
+	q.bySlot[slot] = append(q.bySlot[slot], FutureBlock{
+		Block: block,
+		From:  from,
+		hash:  blockHash,
+	})
+
+	return true, nil
+}
+
+// evict removes the most recently queued block at a certain slot.
+func (q *FutureBlockQueue) evict(slot uint64) {
+	blocks := q.bySlot[slot]
+	last := blocks[len(blocks)-1]
+
+	delete(q.hashes, last.hash)
+	if len(blocks) == 1 {
+		delete(q.bySlot, slot)
+	} else {
+		q.bySlot[slot] = blocks[:len(blocks)-1]
+	}
+}
+
+// TakeReady removes and returns the queued blocks with slots up to the current slot in order
+// of slot.
+func (q *FutureBlockQueue) TakeReady(currentSlot uint64) []FutureBlock {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var slots []uint64
+	for slot := range q.bySlot {
+		if slot <= currentSlot {
+			slots = append(slots, slot)
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] < slots[j]
+	})
+
+	var ready []FutureBlock
+	for _, slot := range slots {
+		ready = append(ready, q.bySlot[slot]...)
+		for _, b := range q.bySlot[slot] {
+			delete(q.hashes, b.hash)
+		}
+		delete(q.bySlot, slot)
+	}
+
+	return ready
+}
+
+// Len gets the number of blocks in the queue.
+func (q *FutureBlockQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.hashes)
+}
@@ -0,0 +1,213 @@
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+func TestOrphanBlockPool(t *testing.T) {
+	blocks := fakeChain(t, 4)
+
+	pool := beacon.NewOrphanBlockPool(8, time.Minute)
+	now := time.Now()
+
+	request, requestParent, err := pool.Add(blocks[1], "a", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !requestParent {
+		t.Fatal("expected parent of first orphan to be requested")
+	}
+	if request.Missing != blocks[1].BlockHeader.ParentRoot || request.Peer != "a" {
+		t.Fatal("expected parent to be requested from the peer that sent the orphan")
+	}
+
+	// a sibling waiting for the same parent and a child of an orphan don't need another request
+	sibling := *blocks[1]
+	sibling.BlockHeader.StateRoot = chainhash.HashH([]byte("sibling"))
+
+	for _, b := range []int{2, 3} {
+		_, requestParent, err = pool.Add(blocks[b], "b", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requestParent {
+			t.Fatal("expected parent to not be requested when it is already in the pool")
+		}
+	}
+
+	_, requestParent, err = pool.Add(&sibling, "b", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requestParent {
+		t.Fatal("expected parent to not be requested again for a sibling")
+	}
+
+	if pool.Len() != 4 {
+		t.Fatalf("expected 4 blocks in the pool, got %d", pool.Len())
+	}
+
+	children := pool.TakeChildren(blocks[1].BlockHeader.ParentRoot)
+	if len(children) != 2 {
+		t.Fatalf("expected both children of the parent to be returned, got %d", len(children))
+	}
+
+	if len(pool.TakeChildren(blocks[1].BlockHeader.ParentRoot)) != 0 {
+		t.Fatal("expected children to be removed from the pool")
+	}
+
+	if pool.Len() != 2 {
+		t.Fatalf("expected 2 blocks left in the pool, got %d", pool.Len())
+	}
+}
+
+func TestOrphanBlockPoolRequestsMissingBlocksAgain(t *testing.T) {
+	blocks := fakeChain(t, 4)
+
+	pool := beacon.NewOrphanBlockPool(8, time.Minute)
+	now := time.Now()
+
+	_, _, err := pool.Add(blocks[2], "a", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pool.ExpiredRequests(now.Add(time.Second))) != 0 {
+		t.Fatal("expected request to not expire before the retry interval")
+	}
+
+	// the request was lost, so a new orphan waiting for the same block requests it again
+	request, requestParent, err := pool.Add(blocks[3], "b", now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !requestParent || request.Missing != blocks[2].BlockHeader.ParentRoot || request.Peer != "b" {
+		t.Fatal("expected missing ancestor of a child of an orphan to be requested again")
+	}
+
+	// once the missing block arrives as an orphan itself, its parent is requested
+	request, requestParent, err = pool.Add(blocks[1], "a", now.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !requestParent || request.Missing != blocks[1].BlockHeader.ParentRoot {
+		t.Fatal("expected the new missing ancestor to be requested")
+	}
+
+	requests := pool.ExpiredRequests(now.Add(4 * time.Minute))
+	if len(requests) != 1 || requests[0].Missing != blocks[1].BlockHeader.ParentRoot || requests[0].Peer != "a" {
+		t.Fatal("expected missing ancestor to be requested again after the retry interval")
+	}
+
+	if len(pool.ExpiredRequests(now.Add(4*time.Minute))) != 0 {
+		t.Fatal("expected request to be marked as sent again")
+	}
+}
+
+func TestOrphanBlockPoolEvictsOldest(t *testing.T) {
+	blocks := fakeChain(t, 5)
+
+	pool := beacon.NewOrphanBlockPool(3, time.Minute)
+	now := time.Now()
+
+	var request beacon.ParentRequest
+	var requestParent bool
+	for _, b := range blocks[1:] {
+		var err error
+		request, requestParent, err = pool.Add(b, "a", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if pool.Len() != 3 {
+		t.Fatalf("expected pool to be limited to 3 blocks, got %d", pool.Len())
+	}
+
+	oldestHash, err := ssz.HashTreeRoot(blocks[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pool.Has(oldestHash) {
+		t.Fatal("expected oldest block to be evicted")
+	}
+
+	newestHash, err := ssz.HashTreeRoot(blocks[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pool.Has(newestHash) {
+		t.Fatal("expected newest block to be kept")
+	}
+
+	// the orphans are now waiting for the evicted block, so it should be requested
+	if !requestParent || request.Missing != oldestHash {
+		t.Fatal("expected evicted block to be requested")
+	}
+}
+
+func TestFutureBlockQueue(t *testing.T) {
+	blocks := fakeChain(t, 10)
+
+	queue := beacon.NewFutureBlockQueue(3, 5)
+
+	queued, err := queue.Add(blocks[9], "a", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued {
+		t.Fatal("expected block too far in the future to be dropped")
+	}
+
+	for _, b := range []int{4, 2, 3} {
+		queued, err := queue.Add(blocks[b], "a", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !queued {
+			t.Fatalf("expected block at slot %d to be queued", blocks[b].BlockHeader.SlotNumber)
+		}
+	}
+
+	// the queue is full and the new block is farther in the future than every queued block
+	queued, err = queue.Add(blocks[5], "a", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued {
+		t.Fatal("expected block to be dropped when the queue is full")
+	}
+
+	// a block sooner than the farthest block replaces it
+	queued, err = queue.Add(blocks[1], "b", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !queued {
+		t.Fatal("expected block to replace the farthest block in the queue")
+	}
+
+	if len(queue.TakeReady(1)) != 0 {
+		t.Fatal("expected no blocks to be ready before their slots")
+	}
+
+	ready := queue.TakeReady(5)
+	if len(ready) != 3 || ready[0].Block.BlockHeader.SlotNumber != 2 || ready[1].Block.BlockHeader.SlotNumber != 3 || ready[2].Block.BlockHeader.SlotNumber != 4 {
+		t.Fatal("expected blocks to be ready in order of slot once their slots start")
+	}
+
+	if ready[0].From != "b" {
+		t.Fatal("expected queued blocks to keep the peer they were received from")
+	}
+
+	if queue.Len() != 0 {
+		t.Fatalf("expected no blocks left in the queue, got %d", queue.Len())
+	}
+}
@@ -29,7 +29,7 @@ func (node *BlockNode) GetAncestorAtHeight(height uint64) *BlockNode {
 	current := node
 
 	// go up to the slot after the slot we're searching for
-	for height < current.Height {
+	for current != nil && height < current.Height {
 		current = current.Parent
 	}
 	return current
@@ -43,8 +43,9 @@ func (node *BlockNode) GetAncestorAtSlot(slot uint64) *BlockNode {
 
 	current := node
 
-	// go up to the slot after the slot we're searching for
-	for slot < current.Slot {
+	// go up to the slot after the slot we're searching for, which may be before the
+	// first block in the index if the chain was started from a checkpoint
+	for current != nil && slot < current.Slot {
 		current = current.Parent
 	}
 	return current
@@ -184,12 +185,13 @@ func (bi *BlockIndex) AddBlockNodeToIndex(block *primitives.Block, blockHash cha
 	return node, nil
 }
 
-// LoadBlockNode loads a block node from disk. The parent must have already been added.
+// LoadBlockNode loads a block node from disk. The parent must have already been added unless
+// the node is the first block in the index.
 func (bi *BlockIndex) LoadBlockNode(blockNodeDisk *db.BlockNodeDisk) (*BlockNode, error) {
 	bi.lock.Lock()
 	defer bi.lock.Unlock()
 	parent := bi.getBlockNodeByHash(blockNodeDisk.Parent)
-	if parent == nil && blockNodeDisk.Slot != 0 {
+	if parent == nil && blockNodeDisk.Slot != 0 && len(bi.index) > 0 {
 		return nil, fmt.Errorf("can't load block node to block index without parent block (missing %s)", blockNodeDisk.Parent)
 	}
 
@@ -211,6 +213,42 @@ func (bi *BlockIndex) LoadBlockNode(blockNodeDisk *db.BlockNodeDisk) (*BlockNode
 	return newNode, nil
 }
 
+// PruneOrphans removes every block that does not descend from the finalized node or one of
+// its descendants, walking back from the finalized node until stopAt or the genesis block.
+// It returns the removed block nodes and the ancestors of the finalized node that lost
+// children.
+func (bi *BlockIndex) PruneOrphans(finalizedNode *BlockNode, stopAt *BlockNode) (pruned []*BlockNode, updated []*BlockNode) {
+	bi.lock.Lock()
+	defer bi.lock.Unlock()
+
+	for current := finalizedNode; current.Parent != nil && current != stopAt; current = current.Parent {
+		parent := current.Parent
+		if len(parent.Children) == 1 {
+			continue
+		}
+
+		queue := make([]*BlockNode, 0)
+		for _, c := range parent.Children {
+			if c != current {
+				queue = append(queue, c)
+			}
+		}
+
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = append(queue[1:], node.Children...)
+
+			delete(bi.index, node.Hash)
+			pruned = append(pruned, node)
+		}
+
+		parent.Children = []*BlockNode{current}
+		updated = append(updated, parent)
+	}
+
+	return pruned, updated
+}
+
 func (bi *BlockIndex) has(h chainhash.Hash) bool {
 	_, found := bi.index[h]
 	return found
@@ -257,7 +295,7 @@ func (c *Chain) Height() int64 {
 }
 
 func (c *Chain) contains(node *BlockNode) bool {
-	return c.chain[node.Height] == node
+	return node.Height < uint64(len(c.chain)) && c.chain[node.Height] == node
 }
 
 // Contains checks if the chain contains a BlockNode.
@@ -0,0 +1,195 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+// Checkpoint is a trusted finalized block along with the state after processing it. New
+// nodes can start syncing from a checkpoint instead of processing every block since genesis.
+type Checkpoint struct {
+	Block primitives.Block
+	State primitives.State
+}
+
+// ToProto gets the protobuf representation of the checkpoint.
+func (c *Checkpoint) ToProto() *pb.Checkpoint {
+	return &pb.Checkpoint{
+		Block: c.Block.ToProto(),
+		State: c.State.ToProto(),
+	}
+}
+
+// CheckpointFromProto gets the checkpoint from the protobuf representation.
+func CheckpointFromProto(c *pb.Checkpoint) (*Checkpoint, error) {
+	if c.Block == nil || c.State == nil {
+		return nil, errors.New("checkpoint is missing block or state")
+	}
+
+	block, err := primitives.BlockFromProto(c.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := primitives.StateFromProto(c.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checkpoint{
+		Block: *block,
+		State: *state,
+	}, nil
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint.
+func ReadCheckpoint(r io.Reader) (*Checkpoint, error) {
+	checkpointBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointProto := new(pb.Checkpoint)
+	err = proto.Unmarshal(checkpointBytes, checkpointProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckpointFromProto(checkpointProto)
+}
+
+// WriteCheckpoint writes a checkpoint so that it can be read by ReadCheckpoint.
+func WriteCheckpoint(w io.Writer, c *Checkpoint) error {
+	checkpointBytes, err := proto.Marshal(c.ToProto())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(checkpointBytes)
+	return err
+}
+
+// GetFinalizedCheckpoint gets the finalized block and state so that other nodes can start
+// syncing from it.
+func (b *Blockchain) GetFinalizedCheckpoint() (*Checkpoint, error) {
+	finalizedNode, finalizedState := b.View.GetFinalizedHead()
+
+	block, err := b.DB.GetBlockForHash(finalizedNode.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checkpoint{
+		Block: *block,
+		State: finalizedState.Copy(),
+	}, nil
+}
+
+// forkDataAtEpoch gets the fork data a state on the configured chain has during an epoch.
+func forkDataAtEpoch(c *config.Config, epoch uint64) primitives.ForkData {
+	forkData := primitives.ForkData{
+		PreForkVersion:  c.InitialForkVersion,
+		PostForkVersion: c.InitialForkVersion,
+		ForkSlotNumber:  c.InitialSlotNumber,
+	}
+
+	for _, fork := range c.ForkSchedule {
+		if fork.Epoch > epoch {
+			break
+		}
+
+		forkData = primitives.ForkData{
+			PreForkVersion:  forkData.PostForkVersion,
+			PostForkVersion: fork.Version,
+			ForkSlotNumber:  fork.Epoch*c.EpochLength + 1,
+		}
+	}
+
+	return forkData
+}
+
+// validateCheckpoint checks that a checkpoint is from the configured chain.
+func (b *Blockchain) validateCheckpoint(checkpoint *Checkpoint) error {
+	if checkpoint.State.Slot != checkpoint.Block.BlockHeader.SlotNumber {
+		return fmt.Errorf("checkpoint state is at slot %d, but checkpoint block is at slot %d", checkpoint.State.Slot, checkpoint.Block.BlockHeader.SlotNumber)
+	}
+
+	genesisTime := b.stateManager.GetGenesisTime()
+	if checkpoint.State.GenesisTime != genesisTime {
+		return fmt.Errorf("checkpoint has genesis time %d, but the chain has genesis time %d", checkpoint.State.GenesisTime, genesisTime)
+	}
+
+	expectedForkData := forkDataAtEpoch(b.config, checkpoint.State.EpochIndex)
+	if checkpoint.State.ForkData != expectedForkData {
+		return fmt.Errorf("checkpoint has fork data %+v, but the chain has fork data %+v at epoch %d", checkpoint.State.ForkData, expectedForkData, checkpoint.State.EpochIndex)
+	}
+
+	return nil
+}
+
+// initializeDatabaseFromCheckpoint sets up the database with the checkpoint block as the
+// root of the block index. Blocks before the checkpoint are never downloaded.
+func (b *Blockchain) initializeDatabaseFromCheckpoint(checkpoint *Checkpoint) error {
+	err := b.validateCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	blockHash, err := ssz.HashTreeRoot(checkpoint.Block)
+	if err != nil {
+		return err
+	}
+
+	stateRoot, err := checkpoint.State.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"checkpointHash": chainhash.Hash(blockHash),
+		"slot":           checkpoint.Block.BlockHeader.SlotNumber,
+	}).Info("initializing blockchain with checkpoint")
+
+	err = b.DB.SetBlock(checkpoint.Block)
+	if err != nil {
+		return err
+	}
+
+	// the checkpoint is the first block in the block index, so heights start from it
+	node, err := b.View.Index.LoadBlockNode(&db.BlockNodeDisk{
+		Hash:      blockHash,
+		Height:    0,
+		Slot:      checkpoint.Block.BlockHeader.SlotNumber,
+		Parent:    checkpoint.Block.BlockHeader.ParentRoot,
+		StateRoot: stateRoot,
+	})
+	if err != nil {
+		return err
+	}
+	b.forkChoice.AddBlock(node)
+
+	err = b.DB.TransactionalUpdate(func(transaction interface{}) error {
+		err := b.DB.SetBlockNode(blockNodeToDisk(*node), transaction)
+		if err != nil {
+			return err
+		}
+
+		return b.DB.SetBlockIndexRoot(blockHash, transaction)
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.initializeDatabase(node, checkpoint.State)
+}
@@ -0,0 +1,205 @@
+package beacon_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/sirupsen/logrus"
+)
+
+func setupFinalizedChain(t testing.TB, c *config.Config) (*beacon.Blockchain, validator.Keystore) {
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := uint64(0); i < c.EpochLength*5+1; i++ {
+		s, err := b.GetUpdatedState(i + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := s.GetBeaconProposerIndex(i, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return b, keys
+}
+
+// blocksAfter gets the blocks in the main chain after a certain block.
+func blocksAfter(t testing.TB, b *beacon.Blockchain, node *beacon.BlockNode) []primitives.Block {
+	var blocks []primitives.Block
+	for current := b.View.Chain.Next(node); current != nil; current = b.View.Chain.Next(current) {
+		block, err := b.GetBlockByHash(current.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, *block)
+	}
+	return blocks
+}
+
+func TestSyncFromCheckpoint(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	checkpoint, err := b.GetFinalizedCheckpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if checkpoint.Block.BlockHeader.SlotNumber == 0 {
+		t.Fatal("expected chain to finalize past genesis")
+	}
+
+	buf := new(bytes.Buffer)
+	err = beacon.WriteCheckpoint(buf, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err = beacon.ReadCheckpoint(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisState := b.GetState()
+
+	database := db.NewInMemoryDB()
+
+	otherGenesis := *checkpoint
+	otherGenesis.State = checkpoint.State.Copy()
+	otherGenesis.State.GenesisTime++
+	if _, err := beacon.NewBlockchainWithCheckpoint(database, &c, validators, true, genesisState.GenesisTime, &otherGenesis); err == nil {
+		t.Fatal("expected checkpoint with a different genesis time to be rejected")
+	}
+
+	otherFork := *checkpoint
+	otherFork.State = checkpoint.State.Copy()
+	otherFork.State.ForkData.PostForkVersion++
+	if _, err := beacon.NewBlockchainWithCheckpoint(database, &c, validators, true, genesisState.GenesisTime, &otherFork); err == nil {
+		t.Fatal("expected checkpoint with different fork data to be rejected")
+	}
+
+	// rejected checkpoints aren't written, so the database can still be started from a checkpoint
+	b2, err := beacon.NewBlockchainWithCheckpoint(database, &c, validators, true, genesisState.GenesisTime, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b2.GenesisHash() != b.GenesisHash() {
+		t.Fatal("expected node started from checkpoint to have the same genesis hash")
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	if b2.View.Chain.Tip().Hash != finalizedNode.Hash {
+		t.Fatal("expected node started from checkpoint to start at the checkpoint")
+	}
+
+	for _, block := range blocksAfter(t, b, finalizedNode) {
+		_, _, err := b2.ProcessBlock(&block, false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if b2.View.Chain.Tip().Hash != b.View.Chain.Tip().Hash {
+		t.Fatal("expected node started from checkpoint to sync to the same tip")
+	}
+
+	finalizedNode2, _ := b2.View.GetFinalizedHead()
+	if finalizedNode2.Hash != finalizedNode.Hash {
+		t.Fatal("expected node started from checkpoint to have the same finalized head")
+	}
+}
+
+func TestRestartFromCheckpoint(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	checkpoint, err := b.GetFinalizedCheckpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisTime := b.GetState().GenesisTime
+
+	dir, err := ioutil.TempDir("", "synapse-checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	database := db.NewBadgerDB(dir)
+
+	b2, err := beacon.NewBlockchainWithCheckpoint(database, &c, validators, true, genesisTime, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	for _, block := range blocksAfter(t, b, finalizedNode) {
+		_, _, err := b2.ProcessBlock(&block, false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = database.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database = db.NewBadgerDB(dir)
+	defer database.Close()
+
+	// restarting doesn't need the checkpoint anymore
+	b3, err := beacon.NewBlockchainWithInitialValidators(database, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b3.View.Chain.Tip().Hash != b.View.Chain.Tip().Hash {
+		t.Fatal("expected node restarted from checkpoint to load the same tip")
+	}
+
+	if b3.GenesisHash() != b.GenesisHash() {
+		t.Fatal("expected node restarted from checkpoint to have the same genesis hash")
+	}
+}
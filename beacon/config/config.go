@@ -1,5 +1,10 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Config is the config for the blockchain.
 type Config struct {
 	ShardCount                         int
@@ -30,6 +35,8 @@ type Config struct {
 	MaxVotes                           int
 	MaxDeposit                         uint64
 	MinDeposit                         uint64
+	DepositTreeDepth                   uint64
+	EpochsPerDepositRootVotingPeriod   uint64
 	ProposalCost                       uint64
 	EpochsPerVotingPeriod              uint64
 	QueueThresholdNumerator            uint64
@@ -41,6 +48,85 @@ type Config struct {
 	GracePeriod                        uint64
 	VotingTimeout                      uint64
 	VotingExpiration                   uint64
+	ForkSchedule                       []Fork `json:",omitempty"`
+}
+
+// Fork is a scheduled change of the fork version and chain parameters.
+type Fork struct {
+	// Epoch is the first epoch using the new fork version and parameters.
+	Epoch uint64
+
+	// Version is the fork version starting at the fork epoch. Signature domains include the fork
+	// version, so signatures made before the fork aren't valid after it.
+	Version uint64
+
+	// Parameters are the chain parameters changed by the fork.
+	Parameters ForkParameters
+}
+
+// ForkParameters are the chain parameters that can be changed by a fork. Parameters that aren't
+// set keep their value from before the fork.
+type ForkParameters struct {
+	EjectionBalance              *uint64 `json:",omitempty"`
+	MinAttestationInclusionDelay *uint64 `json:",omitempty"`
+	BaseRewardQuotient           *uint64 `json:",omitempty"`
+	WhistleblowerRewardQuotient  *uint64 `json:",omitempty"`
+	IncluderRewardQuotient       *uint64 `json:",omitempty"`
+	InactivityPenaltyQuotient    *uint64 `json:",omitempty"`
+	MaxProposerSlashings         *int    `json:",omitempty"`
+	MaxCasperSlashings           *int    `json:",omitempty"`
+	MaxAttestations              *int    `json:",omitempty"`
+	MaxDeposits                  *int    `json:",omitempty"`
+	MaxExits                     *int    `json:",omitempty"`
+	MaxVotes                     *int    `json:",omitempty"`
+	MinDeposit                   *uint64 `json:",omitempty"`
+	ProposalCost                 *uint64 `json:",omitempty"`
+}
+
+// apply sets the parameters changed by the fork in the config.
+func (p ForkParameters) apply(c *Config) {
+	if p.EjectionBalance != nil {
+		c.EjectionBalance = *p.EjectionBalance
+	}
+	if p.MinAttestationInclusionDelay != nil {
+		c.MinAttestationInclusionDelay = *p.MinAttestationInclusionDelay
+	}
+	if p.BaseRewardQuotient != nil {
+		c.BaseRewardQuotient = *p.BaseRewardQuotient
+	}
+	if p.WhistleblowerRewardQuotient != nil {
+		c.WhistleblowerRewardQuotient = *p.WhistleblowerRewardQuotient
+	}
+	if p.IncluderRewardQuotient != nil {
+		c.IncluderRewardQuotient = *p.IncluderRewardQuotient
+	}
+	if p.InactivityPenaltyQuotient != nil {
+		c.InactivityPenaltyQuotient = *p.InactivityPenaltyQuotient
+	}
+	if p.MaxProposerSlashings != nil {
+		c.MaxProposerSlashings = *p.MaxProposerSlashings
+	}
+	if p.MaxCasperSlashings != nil {
+		c.MaxCasperSlashings = *p.MaxCasperSlashings
+	}
+	if p.MaxAttestations != nil {
+		c.MaxAttestations = *p.MaxAttestations
+	}
+	if p.MaxDeposits != nil {
+		c.MaxDeposits = *p.MaxDeposits
+	}
+	if p.MaxExits != nil {
+		c.MaxExits = *p.MaxExits
+	}
+	if p.MaxVotes != nil {
+		c.MaxVotes = *p.MaxVotes
+	}
+	if p.MinDeposit != nil {
+		c.MinDeposit = *p.MinDeposit
+	}
+	if p.ProposalCost != nil {
+		c.ProposalCost = *p.ProposalCost
+	}
 }
 
 // UnitInCoin is the number of base units in 1 coin.
@@ -75,6 +161,8 @@ var MainNetConfig = Config{
 	MaxVotes:                           16,
 	MaxDeposit:                         64 * UnitInCoin,
 	MinDeposit:                         2 * UnitInCoin,
+	DepositTreeDepth:                   32,
+	EpochsPerDepositRootVotingPeriod:   3600 * 24 / 7 / 32, // 1 day
 	ProposalCost:                       1 * UnitInCoin,
 	EpochsPerVotingPeriod:              14 * 3600 * 24 / 7 / 32, // 14 days
 	QueueThresholdNumerator:            3,
@@ -117,6 +205,8 @@ var LocalnetConfig = Config{
 	MaxVotes:                           16,
 	MaxDeposit:                         64 * UnitInCoin,
 	MinDeposit:                         2 * UnitInCoin,
+	DepositTreeDepth:                   32,
+	EpochsPerDepositRootVotingPeriod:   1,
 	ProposalCost:                       1 * UnitInCoin,
 	EpochsPerVotingPeriod:              1,
 	QueueThresholdNumerator:            3,
@@ -159,6 +249,8 @@ var RegtestConfig = Config{
 	MaxVotes:                           1,
 	MaxDeposit:                         64 * UnitInCoin,
 	MinDeposit:                         2 * UnitInCoin,
+	DepositTreeDepth:                   32,
+	EpochsPerDepositRootVotingPeriod:   1,
 	ProposalCost:                       1 * UnitInCoin,
 	EpochsPerVotingPeriod:              1,
 	QueueThresholdNumerator:            3,
@@ -178,3 +270,133 @@ var NetworkIDs = map[string]Config{
 	"regtest":  RegtestConfig,
 	"testnet":  MainNetConfig,
 }
+
+// Validate checks that the config is consistent and that committees can be filled with a certain
+// number of initial validators.
+func (c *Config) Validate(initialValidators int) error {
+	if c.SlotDuration == 0 {
+		return errors.New("SlotDuration must be greater than 0")
+	}
+
+	if c.EpochLength == 0 {
+		return errors.New("EpochLength must be greater than 0")
+	}
+
+	if c.TargetCommitteeSize <= 0 {
+		return errors.New("TargetCommitteeSize must be greater than 0")
+	}
+
+	// every slot needs at least one shard to crosslink
+	if c.ShardCount < int(c.EpochLength) {
+		return fmt.Errorf("ShardCount (%d) must be at least EpochLength (%d)", c.ShardCount, c.EpochLength)
+	}
+
+	if c.LatestBlockRootsLength < 2*c.EpochLength {
+		return fmt.Errorf("LatestBlockRootsLength (%d) must be at least two epochs (%d)", c.LatestBlockRootsLength, 2*c.EpochLength)
+	}
+
+	if c.MinAttestationInclusionDelay >= c.EpochLength {
+		return fmt.Errorf("MinAttestationInclusionDelay (%d) must be less than EpochLength (%d)", c.MinAttestationInclusionDelay, c.EpochLength)
+	}
+
+	if c.MinDeposit > c.MaxDeposit {
+		return fmt.Errorf("MinDeposit (%d) must not be greater than MaxDeposit (%d)", c.MinDeposit, c.MaxDeposit)
+	}
+
+	if c.BaseRewardQuotient == 0 || c.WhistleblowerRewardQuotient == 0 || c.IncluderRewardQuotient == 0 || c.InactivityPenaltyQuotient == 0 || c.MaxBalanceChurnQuotient == 0 {
+		return errors.New("reward and penalty quotients must be greater than 0")
+	}
+
+	if c.EpochsPerDepositRootVotingPeriod == 0 {
+		return errors.New("EpochsPerDepositRootVotingPeriod must be greater than 0")
+	}
+
+	if c.EpochsPerVotingPeriod == 0 {
+		return errors.New("EpochsPerVotingPeriod must be greater than 0")
+	}
+
+	if c.QueueThresholdNumerator > c.QueueThresholdDenominator || c.CancelThresholdNumerator > c.CancelThresholdDenominator || c.FailThresholdNumerator > c.FailThresholdDenominator {
+		return errors.New("voting threshold numerators must not be greater than their denominators")
+	}
+
+	if c.MaxAttestations <= 0 {
+		return errors.New("MaxAttestations must be greater than 0")
+	}
+
+	// each slot needs at least one full committee
+	minValidators := int(c.EpochLength) * c.TargetCommitteeSize
+	if initialValidators < minValidators {
+		return fmt.Errorf("%d initial validators can't fill a committee of %d validators in each of the %d slots of an epoch, need at least %d", initialValidators, c.TargetCommitteeSize, c.EpochLength, minValidators)
+	}
+
+	version := c.InitialForkVersion
+	for i, fork := range c.ForkSchedule {
+		if fork.Epoch == 0 {
+			return errors.New("forks can't be scheduled at the genesis epoch")
+		}
+
+		if i > 0 && fork.Epoch <= c.ForkSchedule[i-1].Epoch {
+			return fmt.Errorf("fork at epoch %d must be scheduled after the fork at epoch %d", fork.Epoch, c.ForkSchedule[i-1].Epoch)
+		}
+
+		// reusing a fork version would make signatures from before the fork valid again
+		if fork.Version <= version {
+			return fmt.Errorf("fork at epoch %d must increase the fork version (%d), got %d", fork.Epoch, version, fork.Version)
+		}
+		version = fork.Version
+
+		forkConfig := c.ForEpoch(fork.Epoch)
+		forkConfig.ForkSchedule = nil
+		if err := forkConfig.Validate(initialValidators); err != nil {
+			return fmt.Errorf("fork at epoch %d has invalid parameters: %s", fork.Epoch, err)
+		}
+	}
+
+	return nil
+}
+
+// ForEpoch gets the config with the parameters of every fork activated by a certain epoch. If no
+// fork is active yet, the config itself is returned.
+func (c *Config) ForEpoch(epoch uint64) *Config {
+	var epochConfig *Config
+	for _, fork := range c.ForkSchedule {
+		if fork.Epoch > epoch {
+			break
+		}
+
+		if epochConfig == nil {
+			configCopy := *c
+			epochConfig = &configCopy
+		}
+
+		fork.Parameters.apply(epochConfig)
+	}
+
+	if epochConfig == nil {
+		return c
+	}
+
+	return epochConfig
+}
+
+// ForkAtEpoch gets the fork scheduled to activate at a certain epoch or nil if there isn't one.
+func (c *Config) ForkAtEpoch(epoch uint64) *Fork {
+	for i := range c.ForkSchedule {
+		if c.ForkSchedule[i].Epoch == epoch {
+			return &c.ForkSchedule[i]
+		}
+	}
+	return nil
+}
+
+// ForkVersionAtEpoch gets the fork version used during a certain epoch.
+func (c *Config) ForkVersionAtEpoch(epoch uint64) uint64 {
+	version := c.InitialForkVersion
+	for _, fork := range c.ForkSchedule {
+		if fork.Epoch > epoch {
+			break
+		}
+		version = fork.Version
+	}
+	return version
+}
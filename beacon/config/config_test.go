@@ -0,0 +1,119 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/config"
+)
+
+func TestNetworkConfigsAreValid(t *testing.T) {
+	for id, c := range config.NetworkIDs {
+		err := c.Validate(int(c.EpochLength) * c.TargetCommitteeSize)
+		if err != nil {
+			t.Fatalf("expected %s config to be valid: %s", id, err)
+		}
+	}
+}
+
+func TestValidateRejectsInconsistentConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *config.Config)
+	}{
+		{"zero slot duration", func(c *config.Config) { c.SlotDuration = 0 }},
+		{"zero epoch length", func(c *config.Config) { c.EpochLength = 0 }},
+		{"fewer shards than slots", func(c *config.Config) { c.ShardCount = int(c.EpochLength) - 1 }},
+		{"block roots shorter than two epochs", func(c *config.Config) { c.LatestBlockRootsLength = c.EpochLength }},
+		{"inclusion delay longer than an epoch", func(c *config.Config) { c.MinAttestationInclusionDelay = c.EpochLength }},
+		{"minimum deposit above maximum", func(c *config.Config) { c.MinDeposit = c.MaxDeposit + 1 }},
+		{"zero reward quotient", func(c *config.Config) { c.BaseRewardQuotient = 0 }},
+		{"threshold above 1", func(c *config.Config) { c.FailThresholdNumerator = c.FailThresholdDenominator + 1 }},
+	}
+
+	for _, test := range tests {
+		c := config.RegtestConfig
+		test.modify(&c)
+
+		if c.Validate(1024) == nil {
+			t.Fatalf("expected config with %s to be rejected", test.name)
+		}
+	}
+
+	c := config.RegtestConfig
+	if c.Validate(int(c.EpochLength)*c.TargetCommitteeSize-1) == nil {
+		t.Fatal("expected config to be rejected when there aren't enough validators to fill committees")
+	}
+}
+
+func TestForkSchedule(t *testing.T) {
+	c := config.RegtestConfig
+
+	maxAttestations := c.MaxAttestations * 2
+	baseRewardQuotient := c.BaseRewardQuotient / 2
+	c.ForkSchedule = []config.Fork{
+		{Epoch: 5, Version: 1, Parameters: config.ForkParameters{MaxAttestations: &maxAttestations}},
+		{Epoch: 10, Version: 2, Parameters: config.ForkParameters{BaseRewardQuotient: &baseRewardQuotient}},
+	}
+
+	err := c.Validate(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ForEpoch(4) != &c {
+		t.Fatal("expected config before any fork to be unchanged")
+	}
+
+	epoch5 := c.ForEpoch(5)
+	if epoch5.MaxAttestations != maxAttestations || epoch5.BaseRewardQuotient != c.BaseRewardQuotient {
+		t.Fatal("expected only the parameters of the first fork to be changed at epoch 5")
+	}
+
+	epoch10 := c.ForEpoch(10)
+	if epoch10.MaxAttestations != maxAttestations || epoch10.BaseRewardQuotient != baseRewardQuotient {
+		t.Fatal("expected the parameters of both forks to be changed at epoch 10")
+	}
+
+	if !reflect.DeepEqual(epoch10.ForEpoch(10), epoch10) {
+		t.Fatal("expected applying the fork schedule twice to not change the config")
+	}
+
+	if c.MaxAttestations == maxAttestations {
+		t.Fatal("expected ForEpoch to not modify the config")
+	}
+
+	versions := map[uint64]uint64{0: 0, 4: 0, 5: 1, 9: 1, 10: 2, 100: 2}
+	for epoch, version := range versions {
+		if c.ForkVersionAtEpoch(epoch) != version {
+			t.Fatalf("expected fork version %d at epoch %d, got %d", version, epoch, c.ForkVersionAtEpoch(epoch))
+		}
+	}
+
+	if c.ForkAtEpoch(10) == nil || c.ForkAtEpoch(10).Version != 2 || c.ForkAtEpoch(11) != nil {
+		t.Fatal("expected fork to only be found at the epoch it activates")
+	}
+}
+
+func TestValidateRejectsInvalidForkSchedules(t *testing.T) {
+	zero := 0
+	tests := []struct {
+		name     string
+		schedule []config.Fork
+	}{
+		{"fork at genesis", []config.Fork{{Epoch: 0, Version: 1}}},
+		{"forks out of order", []config.Fork{{Epoch: 10, Version: 1}, {Epoch: 5, Version: 2}}},
+		{"unchanged fork version", []config.Fork{{Epoch: 5, Version: 0}}},
+		{"reused fork version", []config.Fork{{Epoch: 5, Version: 2}, {Epoch: 10, Version: 1}}},
+		{"invalid fork parameters", []config.Fork{{Epoch: 5, Version: 1, Parameters: config.ForkParameters{MaxAttestations: &zero}}}},
+	}
+
+	for _, test := range tests {
+		c := config.RegtestConfig
+		c.ForkSchedule = test.schedule
+
+		if c.Validate(1024) == nil {
+			t.Fatalf("expected config with %s to be rejected", test.name)
+		}
+	}
+}
@@ -48,6 +48,9 @@ func (b *Blockchain) loadBlockchainFromDisk(genesisHash chainhash.Hash) error {
 		return err
 	}
 
+	logrus.Info("loading latest votes...")
+	b.populateLatestVotes()
+
 	logrus.Info("populating state map...")
 	err = b.populateStateMap()
 	if err != nil {
@@ -80,6 +83,10 @@ func (b *Blockchain) initializeDatabase(node *BlockNode, initialState primitives
 	if err != nil {
 		return err
 	}
+	err = b.DB.SetStateCheckpoint(node.Hash, initialState)
+	if err != nil {
+		return err
+	}
 	b.View.Chain.SetTip(node)
 
 	err = b.stateManager.SetBlockState(node.Hash, &initialState)
@@ -127,6 +134,18 @@ func (b *Blockchain) populateJustifiedAndFinalizedNodes() error {
 	return nil
 }
 
+// populateLatestVotes loads the latest attestation of each validator into the fork choice.
+func (b *Blockchain) populateLatestVotes() {
+	_, justifiedState := b.View.GetJustifiedHead()
+	for i := range justifiedState.ValidatorRegistry {
+		att, err := b.DB.GetLatestAttestation(uint32(i))
+		if err != nil {
+			continue
+		}
+		b.forkChoice.ProcessAttestation([]uint32{uint32(i)}, att.Data.BeaconBlockHash, att.Data.Slot)
+	}
+}
+
 func (b *Blockchain) populateBlockIndexFromDatabase(genesisHash chainhash.Hash) error {
 	justfiedHead, err := b.DB.GetJustifiedHead()
 	if err != nil {
@@ -146,10 +165,11 @@ func (b *Blockchain) populateBlockIndexFromDatabase(genesisHash chainhash.Hash)
 
 		fmt.Println(nodeDisk.Hash, justfiedHead)
 
-		_, err = b.View.Index.LoadBlockNode(nodeDisk)
+		node, err := b.View.Index.LoadBlockNode(nodeDisk)
 		if err != nil {
 			return err
 		}
+		b.forkChoice.AddBlock(node)
 
 		if nodeToFind.IsEqual(justfiedHead) {
 			// don't process any children of the finalized node (that happens when we load state)
@@ -188,6 +208,8 @@ func (b *Blockchain) populateStateMap() error {
 
 	b.View.Chain.SetTip(finalizedNode)
 
+	// load the rest of the block index without processing any blocks
+	var leaves []*BlockNode
 	for len(loadQueue) > 0 {
 		itemToLoad := loadQueue[0]
 
@@ -196,33 +218,28 @@ func (b *Blockchain) populateStateMap() error {
 			return err
 		}
 
-		//logrus.WithField("loading", node.Hash).WithField("slot", node.Slot).Debug("loading block from database")
-
 		loadQueue = loadQueue[1:]
 
-		bl, err := b.DB.GetBlockForHash(node.Hash)
+		loadedNode, err := b.View.Index.LoadBlockNode(node)
 		if err != nil {
 			return err
 		}
+		b.forkChoice.AddBlock(loadedNode)
 
-		_, _, err = b.AddBlockToStateMap(bl, false)
-		if err != nil {
-			logrus.Debug(err)
-			return err
+		if len(node.Children) == 0 {
+			leaves = append(leaves, loadedNode)
 		}
 
-		_, err = b.View.Index.LoadBlockNode(node)
-		if err != nil {
-			return err
-		}
+		loadQueue = append(loadQueue, node.Children...)
+	}
 
-		// now, update the chain head
-		err = b.UpdateChainHead()
-		if err != nil {
-			return err
+	// only the states of the tips of each fork are needed to continue processing blocks. Those
+	// are regenerated from the closest state checkpoint, and any other states are regenerated
+	// when they are needed.
+	for _, leaf := range leaves {
+		if _, found := b.stateManager.GetStateForHash(leaf.Hash); !found {
+			return fmt.Errorf("could not regenerate state for block %s", leaf.Hash)
 		}
-
-		loadQueue = append(loadQueue, node.Children...)
 	}
 
 	justifiedHead, err := b.DB.GetJustifiedHead()
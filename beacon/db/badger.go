@@ -28,6 +28,10 @@ var _ Database = (*BadgerDB)(nil)
 type BadgerDB struct {
 	db               *badger.DB
 	attestationCache map[uint32]uint64 // maps validator ID to latest attestation slot
+
+	// stopGC stops garbage collection when the database is closed.
+	stopGC    chan struct{}
+	gcStopped chan struct{}
 }
 
 // NewBadgerDB initializes the badger database with the supplied directories.
@@ -50,9 +54,16 @@ func NewBadgerDB(databaseDir string) *BadgerDB {
 		log.Fatal(err)
 	}
 
+	err = migrateSchema(db, migrations)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	b := &BadgerDB{
 		db:               db,
 		attestationCache: make(map[uint32]uint64),
+		stopGC:           make(chan struct{}),
+		gcStopped:        make(chan struct{}),
 	}
 
 	go b.GarbageCollect()
@@ -64,7 +75,14 @@ var blockPrefix = []byte("block")
 
 // Flush flushes all block data.
 func (b *BadgerDB) Flush() error {
-	return b.db.DropAll()
+	err := b.db.DropAll()
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(txn *badger.Txn) error {
+		return setSchemaVersion(txn, uint32(len(migrations)))
+	})
 }
 
 // GetBlockForHash gets a block for a certain block hash.
@@ -112,6 +130,14 @@ func (b *BadgerDB) SetBlock(block primitives.Block, transaction ...interface{})
 	}, transaction...)
 }
 
+// DeleteBlock deletes the block with a certain block hash.
+func (b *BadgerDB) DeleteBlock(h chainhash.Hash, transaction ...interface{}) error {
+	key := append(blockPrefix, h[:]...)
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	}, transaction...)
+}
+
 var attestationPrefix = []byte("att")
 
 // GetLatestAttestation gets the latest attestation from a validator.
@@ -162,7 +188,11 @@ func (b *BadgerDB) SetLatestAttestationsIfNeeded(validators []uint32, attestatio
 			item, err := txn.Get(key[:])
 			// if there's no attestation yet, set this as the latest
 			if err == badger.ErrKeyNotFound {
-				return txn.Set(key, attSer)
+				err := txn.Set(key, attSer)
+				if err != nil {
+					return err
+				}
+				continue
 			}
 			if err != nil {
 				return err
@@ -200,6 +230,7 @@ func (b *BadgerDB) SetLatestAttestationsIfNeeded(validators []uint32, attestatio
 var headBlockKey = []byte("head_block")
 var justifiedHeadKey = []byte("justified_head")
 var finalizedHeadKey = []byte("finalized_head")
+var blockIndexRootKey = []byte("block_index_root")
 
 // SetHeadBlock sets the head block for the chain.
 func (b *BadgerDB) SetHeadBlock(h chainhash.Hash, transaction ...interface{}) error {
@@ -279,6 +310,34 @@ func (b *BadgerDB) GetFinalizedHead(transaction ...interface{}) (*chainhash.Hash
 	return chainhash.NewHash(blockBytesCopy)
 }
 
+// SetBlockIndexRoot sets the hash of the first block in the block index if the
+// chain was started from a checkpoint instead of the genesis block.
+func (b *BadgerDB) SetBlockIndexRoot(h chainhash.Hash, transaction ...interface{}) error {
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Set(blockIndexRootKey, h[:])
+	}, transaction...)
+}
+
+// GetBlockIndexRoot gets the hash of the first block in the block index if the
+// chain was started from a checkpoint.
+func (b *BadgerDB) GetBlockIndexRoot(transaction ...interface{}) (*chainhash.Hash, error) {
+	txn := b.extractTransaction(transaction...)
+	if txn == nil {
+		txn = b.db.NewTransaction(false)
+		defer txn.Discard()
+	}
+	i, err := txn.Get(blockIndexRootKey)
+	if err != nil {
+		return nil, err
+	}
+	blockBytesCopy, err := i.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainhash.NewHash(blockBytesCopy)
+}
+
 var blockNodePrefix = []byte("block_node")
 
 // BlockNodeDiskWithoutChildren is a block node stored on the disk.
@@ -368,6 +427,14 @@ func (b *BadgerDB) GetBlockNode(h chainhash.Hash, transaction ...interface{}) (*
 	}, nil
 }
 
+// DeleteBlockNode deletes a block node from the database.
+func (b *BadgerDB) DeleteBlockNode(h chainhash.Hash, transaction ...interface{}) error {
+	key := append(blockNodePrefix, h[:]...)
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	}, transaction...)
+}
+
 var finalizedStateKey = []byte("finalized_state")
 var justifiedStateKey = []byte("justified_state")
 
@@ -443,8 +510,88 @@ func (b *BadgerDB) SetJustifiedState(state primitives.State, transaction ...inte
 	}, transaction...)
 }
 
+var stateCheckpointPrefix = []byte("state_checkpoint")
+
+// GetStateCheckpoint gets the state stored after processing the given block.
+func (b *BadgerDB) GetStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) (*primitives.State, error) {
+	key := append(stateCheckpointPrefix, blockHash[:]...)
+	txn := b.extractTransaction(transaction...)
+	if txn == nil {
+		txn = b.db.NewTransaction(false)
+		defer txn.Discard()
+	}
+	i, err := txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	stateBytesCopy, err := i.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(pb.State)
+	err = proto.Unmarshal(stateBytesCopy, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return primitives.StateFromProto(s)
+}
+
+// SetStateCheckpoint stores the state after processing the given block.
+func (b *BadgerDB) SetStateCheckpoint(blockHash chainhash.Hash, state primitives.State, transaction ...interface{}) error {
+	key := append(stateCheckpointPrefix, blockHash[:]...)
+	stateBytes, err := proto.Marshal(state.ToProto())
+	if err != nil {
+		return err
+	}
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Set(key, stateBytes)
+	}, transaction...)
+}
+
+// DeleteStateCheckpoint deletes the state stored after processing the given block.
+func (b *BadgerDB) DeleteStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) error {
+	key := append(stateCheckpointPrefix, blockHash[:]...)
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	}, transaction...)
+}
+
+var stateCheckpointPruneSlotKey = []byte("state_checkpoint_prune_slot")
+
+// GetStateCheckpointPruneSlot gets the slot before which state checkpoints have been pruned.
+func (b *BadgerDB) GetStateCheckpointPruneSlot(transaction ...interface{}) (uint64, error) {
+	txn := b.extractTransaction(transaction...)
+	if txn == nil {
+		txn = b.db.NewTransaction(false)
+		defer txn.Discard()
+	}
+	i, err := txn.Get(stateCheckpointPruneSlotKey)
+	if err != nil {
+		return 0, err
+	}
+	slotBytes, err := i.ValueCopy(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(slotBytes), nil
+}
+
+// SetStateCheckpointPruneSlot sets the slot before which state checkpoints have been pruned.
+func (b *BadgerDB) SetStateCheckpointPruneSlot(slot uint64, transaction ...interface{}) error {
+	slotBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(slotBytes, slot)
+	return b.updateInTransaction(func(txn *badger.Txn) error {
+		return txn.Set(stateCheckpointPruneSlotKey, slotBytes)
+	}, transaction...)
+}
+
 // Close closes the database.
 func (b *BadgerDB) Close() error {
+	close(b.stopGC)
+	<-b.gcStopped
 	return b.db.Close()
 }
 
@@ -534,12 +681,18 @@ func (b *BadgerDB) SetHostKey(key crypto.PrivKey, transaction ...interface{}) er
 	}, transaction...)
 }
 
-// GarbageCollect runs badger garbage collection.
+// GarbageCollect runs badger garbage collection until the database is closed.
 func (b *BadgerDB) GarbageCollect() {
+	defer close(b.gcStopped)
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 	_ = b.db.RunValueLogGC(0.5)
-	for range ticker.C {
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.stopGC:
+			return
+		}
 		logrus.Debug("running database garbage collection")
 	again:
 		err := b.db.RunValueLogGC(0.5)
@@ -0,0 +1,51 @@
+package db_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/db/dbtest"
+)
+
+func TestInMemoryDBConformance(t *testing.T) {
+	dbtest.RunDatabaseTests(t, dbtest.Backend{
+		New: func(t *testing.T) (db.Database, func()) {
+			return db.NewInMemoryDB(), func() {}
+		},
+	})
+}
+
+func TestBadgerDBConformance(t *testing.T) {
+	dirs := make(map[db.Database]string)
+
+	dbtest.RunDatabaseTests(t, dbtest.Backend{
+		New: func(t *testing.T) (db.Database, func()) {
+			dir, err := ioutil.TempDir("", "synapse-db")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			database := db.NewBadgerDB(dir)
+			dirs[database] = dir
+
+			return database, func() {
+				os.RemoveAll(dir)
+			}
+		},
+		Reopen: func(t *testing.T, database db.Database) db.Database {
+			dir := dirs[database]
+			delete(dirs, database)
+
+			err := database.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reopened := db.NewBadgerDB(dir)
+			dirs[reopened] = dir
+			return reopened
+		},
+	})
+}
@@ -0,0 +1,577 @@
+// Package dbtest contains a conformance test suite for db.Database implementations.
+package dbtest
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	"github.com/prysmaticlabs/go-ssz"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+)
+
+// Backend creates databases for the conformance tests.
+type Backend struct {
+	// New creates an empty database and returns a function that removes any data left
+	// after the database is closed.
+	New func(t *testing.T) (db.Database, func())
+
+	// Reopen closes a database and opens it again with the same data. It should be nil if
+	// the database doesn't persist data after it's closed.
+	Reopen func(t *testing.T, database db.Database) db.Database
+}
+
+// RunDatabaseTests runs the conformance tests against a database backend.
+func RunDatabaseTests(t *testing.T, backend Backend) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, backend Backend, database db.Database) db.Database
+	}{
+		{"Blocks", testBlocks},
+		{"BlockNodes", testBlockNodes},
+		{"Heads", testHeads},
+		{"States", testStates},
+		{"GenesisTimeAndHostKey", testGenesisTimeAndHostKey},
+		{"LatestAttestations", testLatestAttestations},
+		{"Transaction", testTransaction},
+		{"TransactionRollback", testTransactionRollback},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			database, cleanup := backend.New(t)
+			defer cleanup()
+
+			// tests return the database in case they reopened it
+			database = test.test(t, backend, database)
+
+			if err := database.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// reopen reopens the database if the backend persists data.
+func reopen(t *testing.T, backend Backend, database db.Database) db.Database {
+	if backend.Reopen == nil {
+		return database
+	}
+	return backend.Reopen(t, database)
+}
+
+func testHash(name string) chainhash.Hash {
+	return chainhash.HashH([]byte(name))
+}
+
+func testBlock(slot uint64) primitives.Block {
+	return primitives.Block{
+		BlockHeader: primitives.BlockHeader{
+			SlotNumber:   slot,
+			ParentRoot:   testHash("parent"),
+			StateRoot:    testHash("state"),
+			RandaoReveal: bls.EmptySignature.Serialize(),
+			Signature:    bls.EmptySignature.Serialize(),
+		},
+		BlockBody: primitives.BlockBody{
+			Attestations:      []primitives.Attestation{},
+			ProposerSlashings: []primitives.ProposerSlashing{},
+			CasperSlashings:   []primitives.CasperSlashing{},
+			Deposits:          []primitives.Deposit{},
+			Exits:             []primitives.Exit{},
+			Votes:             []primitives.AggregatedVote{},
+		},
+	}
+}
+
+func testAttestation(slot uint64, shard uint64) primitives.Attestation {
+	return primitives.Attestation{
+		Data: primitives.AttestationData{
+			Slot:            slot,
+			Shard:           shard,
+			BeaconBlockHash: testHash("beacon block"),
+		},
+		ParticipationBitfield: []byte{0xff},
+		CustodyBitfield:       []byte{0x00},
+		AggregateSig:          bls.EmptySignature.Serialize(),
+	}
+}
+
+func testState(t *testing.T, genesisTime uint64) primitives.State {
+	state, err := primitives.InitializeState(&config.RegtestConfig, []primitives.InitialValidatorEntry{}, genesisTime, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *state
+}
+
+func mustHash(t *testing.T, v interface{}) chainhash.Hash {
+	h, err := ssz.HashTreeRoot(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func testBlocks(t *testing.T, backend Backend, database db.Database) db.Database {
+	block := testBlock(1)
+	blockHash := mustHash(t, block)
+
+	if _, err := database.GetBlockForHash(blockHash); err == nil {
+		t.Fatal("expected missing block to return an error")
+	}
+
+	if err := database.SetBlock(block); err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	storedBlock, err := database.GetBlockForHash(blockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mustHash(t, storedBlock) != blockHash {
+		t.Fatal("stored block does not match")
+	}
+
+	// changing the returned block should not change the stored block
+	storedBlock.BlockHeader.SlotNumber = 2
+	storedBlock, err = database.GetBlockForHash(blockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedBlock.BlockHeader.SlotNumber != 1 {
+		t.Fatal("expected stored block to be unaffected by changes to a returned block")
+	}
+
+	if err := database.DeleteBlock(blockHash); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.GetBlockForHash(blockHash); err == nil {
+		t.Fatal("expected deleted block to return an error")
+	}
+
+	return database
+}
+
+func testBlockNodes(t *testing.T, backend Backend, database db.Database) db.Database {
+	node := db.BlockNodeDisk{
+		Hash:      testHash("node"),
+		Height:    3,
+		Slot:      5,
+		Parent:    testHash("parent"),
+		StateRoot: testHash("state"),
+		Children:  []chainhash.Hash{testHash("child 1"), testHash("child 2")},
+	}
+
+	if _, err := database.GetBlockNode(node.Hash); err == nil {
+		t.Fatal("expected missing block node to return an error")
+	}
+
+	if err := database.SetBlockNode(node); err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	storedNode, err := database.GetBlockNode(node.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if storedNode.Hash != node.Hash || storedNode.Height != node.Height || storedNode.Slot != node.Slot ||
+		storedNode.Parent != node.Parent || storedNode.StateRoot != node.StateRoot {
+		t.Fatal("stored block node does not match")
+	}
+
+	if len(storedNode.Children) != 2 || storedNode.Children[0] != node.Children[0] || storedNode.Children[1] != node.Children[1] {
+		t.Fatal("stored block node children do not match")
+	}
+
+	if err := database.DeleteBlockNode(node.Hash); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.GetBlockNode(node.Hash); err == nil {
+		t.Fatal("expected deleted block node to return an error")
+	}
+
+	return database
+}
+
+func testHeads(t *testing.T, backend Backend, database db.Database) db.Database {
+	heads := []struct {
+		name string
+		get  func(transaction ...interface{}) (*chainhash.Hash, error)
+		set  func(h chainhash.Hash, transaction ...interface{}) error
+	}{
+		{"head block", database.GetHeadBlock, database.SetHeadBlock},
+		{"justified head", database.GetJustifiedHead, database.SetJustifiedHead},
+		{"finalized head", database.GetFinalizedHead, database.SetFinalizedHead},
+		{"block index root", database.GetBlockIndexRoot, database.SetBlockIndexRoot},
+	}
+
+	for _, head := range heads {
+		if _, err := head.get(); err == nil {
+			t.Fatalf("expected %s to return an error before it is set", head.name)
+		}
+
+		if err := head.set(testHash("old " + head.name)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := head.set(testHash(head.name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	database = reopen(t, backend, database)
+
+	gets := map[string]func(transaction ...interface{}) (*chainhash.Hash, error){
+		"head block":       database.GetHeadBlock,
+		"justified head":   database.GetJustifiedHead,
+		"finalized head":   database.GetFinalizedHead,
+		"block index root": database.GetBlockIndexRoot,
+	}
+
+	for name, get := range gets {
+		h, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *h != testHash(name) {
+			t.Fatalf("expected %s to be the last value set", name)
+		}
+	}
+
+	return database
+}
+
+func testStates(t *testing.T, backend Backend, database db.Database) db.Database {
+	finalizedState := testState(t, 1)
+	justifiedState := testState(t, 2)
+	checkpointState := testState(t, 3)
+	checkpointHash := testHash("checkpoint")
+
+	if _, err := database.GetFinalizedState(); err == nil {
+		t.Fatal("expected finalized state to return an error before it is set")
+	}
+
+	if _, err := database.GetJustifiedState(); err == nil {
+		t.Fatal("expected justified state to return an error before it is set")
+	}
+
+	if _, err := database.GetStateCheckpoint(checkpointHash); err == nil {
+		t.Fatal("expected missing state checkpoint to return an error")
+	}
+
+	if err := database.SetFinalizedState(finalizedState); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.SetJustifiedState(justifiedState); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.GetStateCheckpointPruneSlot(); err == nil {
+		t.Fatal("expected state checkpoint prune slot to return an error before it is set")
+	}
+
+	if err := database.SetStateCheckpoint(checkpointHash, checkpointState); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.SetStateCheckpointPruneSlot(64); err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	storedFinalizedState, err := database.GetFinalizedState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mustHash(t, *storedFinalizedState) != mustHash(t, finalizedState) {
+		t.Fatal("stored finalized state does not match")
+	}
+
+	storedJustifiedState, err := database.GetJustifiedState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mustHash(t, *storedJustifiedState) != mustHash(t, justifiedState) {
+		t.Fatal("stored justified state does not match")
+	}
+
+	storedCheckpointState, err := database.GetStateCheckpoint(checkpointHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mustHash(t, *storedCheckpointState) != mustHash(t, checkpointState) {
+		t.Fatal("stored state checkpoint does not match")
+	}
+
+	pruneSlot, err := database.GetStateCheckpointPruneSlot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruneSlot != 64 {
+		t.Fatalf("expected state checkpoint prune slot 64, got %d", pruneSlot)
+	}
+
+	if err := database.DeleteStateCheckpoint(checkpointHash); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.GetStateCheckpoint(checkpointHash); err == nil {
+		t.Fatal("expected deleted state checkpoint to return an error")
+	}
+
+	return database
+}
+
+func testGenesisTimeAndHostKey(t *testing.T, backend Backend, database db.Database) db.Database {
+	if _, err := database.GetGenesisTime(); err == nil {
+		t.Fatal("expected genesis time to return an error before it is set")
+	}
+
+	if _, err := database.GetHostKey(); err == nil {
+		t.Fatal("expected host key to return an error before it is set")
+	}
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.SetGenesisTime(1234); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.SetHostKey(key); err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	genesisTime, err := database.GetGenesisTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesisTime != 1234 {
+		t.Fatalf("expected genesis time 1234, got %d", genesisTime)
+	}
+
+	storedKey, err := database.GetHostKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !storedKey.Equals(key) {
+		t.Fatal("stored host key does not match")
+	}
+
+	return database
+}
+
+func testLatestAttestations(t *testing.T, backend Backend, database db.Database) db.Database {
+	if _, err := database.GetLatestAttestation(1); err == nil {
+		t.Fatal("expected missing latest attestation to return an error")
+	}
+
+	if err := database.SetLatestAttestationsIfNeeded([]uint32{1, 2}, testAttestation(5, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// older attestations don't replace newer ones
+	if err := database.SetLatestAttestationsIfNeeded([]uint32{2, 3}, testAttestation(3, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// attestations at the same slot don't replace the first one
+	if err := database.SetLatestAttestationsIfNeeded([]uint32{1}, testAttestation(5, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// newer attestations replace older ones
+	if err := database.SetLatestAttestationsIfNeeded([]uint32{2}, testAttestation(6, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	expected := map[uint32]primitives.Attestation{
+		1: testAttestation(5, 0),
+		2: testAttestation(6, 0),
+		3: testAttestation(3, 0),
+	}
+
+	for validator, expectedAtt := range expected {
+		att, err := database.GetLatestAttestation(validator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if mustHash(t, *att) != mustHash(t, expectedAtt) {
+			t.Fatalf("unexpected latest attestation for validator %d (slot: %d, shard: %d)", validator, att.Data.Slot, att.Data.Shard)
+		}
+	}
+
+	return database
+}
+
+func testTransaction(t *testing.T, backend Backend, database db.Database) db.Database {
+	block := testBlock(1)
+	blockHash := mustHash(t, block)
+
+	oldBlock := testBlock(2)
+	oldBlockHash := mustHash(t, oldBlock)
+	if err := database.SetBlock(oldBlock); err != nil {
+		t.Fatal(err)
+	}
+
+	err := database.TransactionalUpdate(func(transaction interface{}) error {
+		if err := database.SetBlock(block, transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetHeadBlock(blockHash, transaction); err != nil {
+			return err
+		}
+
+		if err := database.DeleteBlock(oldBlockHash, transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetLatestAttestationsIfNeeded([]uint32{1}, testAttestation(5, 0), transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetLatestAttestationsIfNeeded([]uint32{1}, testAttestation(4, 0), transaction); err != nil {
+			return err
+		}
+
+		// changes are visible inside of the transaction
+		if _, err := database.GetBlockForHash(blockHash, transaction); err != nil {
+			t.Errorf("expected block to be visible inside of the transaction: %s", err)
+		}
+
+		if h, err := database.GetHeadBlock(transaction); err != nil || *h != blockHash {
+			t.Error("expected head block to be visible inside of the transaction")
+		}
+
+		if _, err := database.GetBlockForHash(oldBlockHash, transaction); err == nil {
+			t.Error("expected deleted block to be missing inside of the transaction")
+		}
+
+		if att, err := database.GetLatestAttestation(1, transaction); err != nil || att.Data.Slot != 5 {
+			t.Error("expected latest attestation to be visible inside of the transaction")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database = reopen(t, backend, database)
+
+	if _, err := database.GetBlockForHash(blockHash); err != nil {
+		t.Fatal("expected block set in transaction to be committed")
+	}
+
+	if h, err := database.GetHeadBlock(); err != nil || *h != blockHash {
+		t.Fatal("expected head block set in transaction to be committed")
+	}
+
+	if _, err := database.GetBlockForHash(oldBlockHash); err == nil {
+		t.Fatal("expected block deleted in transaction to be deleted")
+	}
+
+	if att, err := database.GetLatestAttestation(1); err != nil || att.Data.Slot != 5 {
+		t.Fatal("expected latest attestation set in transaction to be committed")
+	}
+
+	return database
+}
+
+func testTransactionRollback(t *testing.T, backend Backend, database db.Database) db.Database {
+	block := testBlock(1)
+	blockHash := mustHash(t, block)
+
+	oldHead := testHash("old head")
+	if err := database.SetHeadBlock(oldHead); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.SetLatestAttestationsIfNeeded([]uint32{1}, testAttestation(3, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	errRollback := errors.New("rollback")
+
+	err := database.TransactionalUpdate(func(transaction interface{}) error {
+		if err := database.SetBlock(block, transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetHeadBlock(blockHash, transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetFinalizedHead(blockHash, transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetLatestAttestationsIfNeeded([]uint32{1, 2}, testAttestation(5, 0), transaction); err != nil {
+			return err
+		}
+
+		if err := database.SetStateCheckpoint(blockHash, testState(t, 1), transaction); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	if err != errRollback {
+		t.Fatalf("expected transaction to return the callback error, got: %v", err)
+	}
+
+	database = reopen(t, backend, database)
+
+	if _, err := database.GetBlockForHash(blockHash); err == nil {
+		t.Fatal("expected block set in failed transaction to be rolled back")
+	}
+
+	if h, err := database.GetHeadBlock(); err != nil || *h != oldHead {
+		t.Fatal("expected head block set in failed transaction to be rolled back")
+	}
+
+	if _, err := database.GetFinalizedHead(); err == nil {
+		t.Fatal("expected finalized head set in failed transaction to be rolled back")
+	}
+
+	if att, err := database.GetLatestAttestation(1); err != nil || att.Data.Slot != 3 {
+		t.Fatal("expected latest attestation set in failed transaction to be rolled back")
+	}
+
+	if _, err := database.GetLatestAttestation(2); err == nil {
+		t.Fatal("expected latest attestation set in failed transaction to be rolled back")
+	}
+
+	if _, err := database.GetStateCheckpoint(blockHash); err == nil {
+		t.Fatal("expected state checkpoint set in failed transaction to be rolled back")
+	}
+
+	return database
+}
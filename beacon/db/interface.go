@@ -21,6 +21,7 @@ type BlockNodeDisk struct {
 type Database interface {
 	GetBlockForHash(h chainhash.Hash, transaction ...interface{}) (*primitives.Block, error)
 	SetBlock(b primitives.Block, transaction ...interface{}) error
+	DeleteBlock(h chainhash.Hash, transaction ...interface{}) error
 	GetLatestAttestation(validator uint32, transaction ...interface{}) (*primitives.Attestation, error)
 	SetLatestAttestationsIfNeeded(validators []uint32, attestation primitives.Attestation, transaction ...interface{}) error
 	SetHeadBlock(block chainhash.Hash, transaction ...interface{}) error
@@ -29,12 +30,20 @@ type Database interface {
 	GetFinalizedState(transaction ...interface{}) (*primitives.State, error)
 	SetJustifiedState(state primitives.State, transaction ...interface{}) error
 	GetJustifiedState(transaction ...interface{}) (*primitives.State, error)
+	SetStateCheckpoint(blockHash chainhash.Hash, state primitives.State, transaction ...interface{}) error
+	GetStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) (*primitives.State, error)
+	DeleteStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) error
+	GetStateCheckpointPruneSlot(transaction ...interface{}) (uint64, error)
+	SetStateCheckpointPruneSlot(slot uint64, transaction ...interface{}) error
 	SetBlockNode(node BlockNodeDisk, transaction ...interface{}) error
 	GetBlockNode(h chainhash.Hash, transaction ...interface{}) (*BlockNodeDisk, error)
+	DeleteBlockNode(h chainhash.Hash, transaction ...interface{}) error
 	SetJustifiedHead(h chainhash.Hash, transaction ...interface{}) error
 	SetFinalizedHead(h chainhash.Hash, transaction ...interface{}) error
 	GetJustifiedHead(transaction ...interface{}) (*chainhash.Hash, error)
 	GetFinalizedHead(transaction ...interface{}) (*chainhash.Hash, error)
+	SetBlockIndexRoot(h chainhash.Hash, transaction ...interface{}) error
+	GetBlockIndexRoot(transaction ...interface{}) (*chainhash.Hash, error)
 	GetGenesisTime(transaction ...interface{}) (uint64, error)
 	SetGenesisTime(t uint64, transaction ...interface{}) error
 	GetHostKey(transaction ...interface{}) (crypto.PrivKey, error)
@@ -14,60 +14,188 @@ import (
 
 // InMemoryDB is a very basic block database.
 type InMemoryDB struct {
-	DB            map[chainhash.Hash]primitives.Block
-	AttestationDB map[uint32]primitives.Attestation
-	lock          *sync.Mutex
+	values map[string]interface{}
+	lock   *sync.Mutex
 }
 
 // NewInMemoryDB initializes a new in-memory DB
 func NewInMemoryDB() *InMemoryDB {
 	return &InMemoryDB{
-		DB:            make(map[chainhash.Hash]primitives.Block),
-		AttestationDB: make(map[uint32]primitives.Attestation),
-		lock:          new(sync.Mutex),
+		values: make(map[string]interface{}),
+		lock:   new(sync.Mutex),
 	}
 }
 
-// GetBlockForHash is a database lookup function
-func (db *InMemoryDB) GetBlockForHash(h chainhash.Hash, transaction ...interface{}) (*primitives.Block, error) {
+// memoryTransaction keeps track of changes to an in-memory database until they're committed.
+type memoryTransaction struct {
+	writes  map[string]interface{}
+	deletes map[string]struct{}
+}
+
+func newMemoryTransaction() *memoryTransaction {
+	return &memoryTransaction{
+		writes:  make(map[string]interface{}),
+		deletes: make(map[string]struct{}),
+	}
+}
+
+func (db *InMemoryDB) extractTransaction(transaction ...interface{}) *memoryTransaction {
+	if transaction != nil && transaction[0] != nil {
+		return transaction[0].(*memoryTransaction)
+	}
+
+	return nil
+}
+
+func (db *InMemoryDB) commit(txn *memoryTransaction) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for key := range txn.deletes {
+		delete(db.values, key)
+	}
+
+	for key, value := range txn.writes {
+		db.values[key] = value
+	}
+}
+
+func (db *InMemoryDB) updateInTransaction(cb func(txn *memoryTransaction) error, transaction ...interface{}) error {
+	txn := db.extractTransaction(transaction...)
+	if txn != nil {
+		return cb(txn)
+	}
+
+	txn = newMemoryTransaction()
+	err := cb(txn)
+	if err != nil {
+		return err
+	}
+
+	db.commit(txn)
+	return nil
+}
+
+func (db *InMemoryDB) get(key string, transaction ...interface{}) (interface{}, bool) {
+	if txn := db.extractTransaction(transaction...); txn != nil {
+		if value, found := txn.writes[key]; found {
+			return value, true
+		}
+
+		if _, found := txn.deletes[key]; found {
+			return nil, false
+		}
+	}
+
 	db.lock.Lock()
 	defer db.lock.Unlock()
-	out, found := db.DB[h]
+	value, found := db.values[key]
+	return value, found
+}
+
+func (txn *memoryTransaction) set(key string, value interface{}) {
+	delete(txn.deletes, key)
+	txn.writes[key] = value
+}
+
+func (txn *memoryTransaction) delete(key string) {
+	delete(txn.writes, key)
+	txn.deletes[key] = struct{}{}
+}
+
+func (db *InMemoryDB) set(key string, value interface{}, transaction ...interface{}) error {
+	return db.updateInTransaction(func(txn *memoryTransaction) error {
+		txn.set(key, value)
+		return nil
+	}, transaction...)
+}
+
+func (db *InMemoryDB) delete(key string, transaction ...interface{}) error {
+	return db.updateInTransaction(func(txn *memoryTransaction) error {
+		txn.delete(key)
+		return nil
+	}, transaction...)
+}
+
+func (db *InMemoryDB) getHash(key string, transaction ...interface{}) (*chainhash.Hash, error) {
+	value, found := db.get(key, transaction...)
+	if !found {
+		return nil, fmt.Errorf("could not find %s", key)
+	}
+	h := value.(chainhash.Hash)
+	return &h, nil
+}
+
+func (db *InMemoryDB) getState(key string, transaction ...interface{}) (*primitives.State, error) {
+	value, found := db.get(key, transaction...)
+	if !found {
+		return nil, fmt.Errorf("could not find %s", key)
+	}
+	state := value.(primitives.State)
+	stateCopy := state.Copy()
+	return &stateCopy, nil
+}
+
+func (db *InMemoryDB) setState(key string, state primitives.State, transaction ...interface{}) error {
+	return db.set(key, state.Copy(), transaction...)
+}
+
+func blockKey(h chainhash.Hash) string {
+	return "block" + string(h[:])
+}
+
+// GetBlockForHash is a database lookup function
+func (db *InMemoryDB) GetBlockForHash(h chainhash.Hash, transaction ...interface{}) (*primitives.Block, error) {
+	value, found := db.get(blockKey(h), transaction...)
 	if !found {
 		return nil, fmt.Errorf("could not find block with hash")
 	}
+	block := value.(primitives.Block)
+	out := block.Copy()
 	return &out, nil
 }
 
 // SetBlock adds the block to storage
 func (db *InMemoryDB) SetBlock(b primitives.Block, transaction ...interface{}) error {
-	db.lock.Lock()
 	blockHash, err := ssz.HashTreeRoot(b)
 	if err != nil {
 		return err
 	}
-	db.DB[blockHash] = b
-	db.lock.Unlock()
-	return nil
+	return db.set(blockKey(blockHash), b.Copy(), transaction...)
+}
+
+// DeleteBlock removes the block from storage.
+func (db *InMemoryDB) DeleteBlock(h chainhash.Hash, transaction ...interface{}) error {
+	return db.delete(blockKey(h), transaction...)
+}
+
+func attestationKey(validator uint32) string {
+	return fmt.Sprintf("att%d", validator)
 }
 
 // GetLatestAttestation gets the latest attestation from a validator.
 func (db *InMemoryDB) GetLatestAttestation(validator uint32, transaction ...interface{}) (*primitives.Attestation, error) {
-	if att, found := db.AttestationDB[validator]; found {
-		return &att, nil
+	value, found := db.get(attestationKey(validator), transaction...)
+	if !found {
+		return nil, errors.New("could not find attestation for validator")
 	}
-	return nil, errors.New("could not find attestation for validator")
+	att := value.(primitives.Attestation)
+	attCopy := att.Copy()
+	return &attCopy, nil
 }
 
 // SetLatestAttestationsIfNeeded sets the latest attestation received from a validator.
 func (db *InMemoryDB) SetLatestAttestationsIfNeeded(validators []uint32, att primitives.Attestation, transaction ...interface{}) error {
-	for _, validator := range validators {
-		if a, found := db.AttestationDB[validator]; found && a.Data.Slot >= att.Data.Slot {
-			return nil
+	return db.updateInTransaction(func(txn *memoryTransaction) error {
+		for _, validator := range validators {
+			key := attestationKey(validator)
+			if value, found := db.get(key, txn); found && value.(primitives.Attestation).Data.Slot >= att.Data.Slot {
+				continue
+			}
+			txn.set(key, att.Copy())
 		}
-		db.AttestationDB[validator] = att
-	}
-	return nil
+		return nil
+	}, transaction...)
 }
 
 // Close closes the database.
@@ -75,94 +203,185 @@ func (db *InMemoryDB) Close() error {
 	return nil
 }
 
+const (
+	headBlockMemoryKey      = "head_block"
+	justifiedHeadMemoryKey  = "justified_head"
+	finalizedHeadMemoryKey  = "finalized_head"
+	blockIndexRootMemoryKey = "block_index_root"
+	finalizedStateMemoryKey = "finalized_state"
+	justifiedStateMemoryKey = "justified_state"
+	genesisTimeMemoryKey    = "genesis_time"
+	hostKeyMemoryKey        = "host_key"
+
+	stateCheckpointPruneSlotMemoryKey = "state_checkpoint_prune_slot"
+)
+
 // SetHeadBlock sets the head block.
 func (db *InMemoryDB) SetHeadBlock(h chainhash.Hash, transaction ...interface{}) error {
-	return nil
+	return db.set(headBlockMemoryKey, h, transaction...)
 }
 
 // GetHeadBlock gets the head block.
 func (db *InMemoryDB) GetHeadBlock(transaction ...interface{}) (*chainhash.Hash, error) {
-	return nil, errors.New("no head block yet")
+	h, err := db.getHash(headBlockMemoryKey, transaction...)
+	if err != nil {
+		return nil, errors.New("no head block yet")
+	}
+	return h, nil
 }
 
-// GetBlockNode gets the block node with slot.
-func (db *InMemoryDB) GetBlockNode(h chainhash.Hash, transaction ...interface{}) (*BlockNodeDisk, error) {
-	return nil, errors.New("not implemented")
+func blockNodeKey(h chainhash.Hash) string {
+	return "block_node" + string(h[:])
 }
 
-// GetFinalizedHead gets the finalized head block for a chain.
-func (db *InMemoryDB) GetFinalizedHead(transaction ...interface{}) (*chainhash.Hash, error) {
-	return nil, errors.New("not implemented")
+func copyBlockNodeDisk(node BlockNodeDisk) BlockNodeDisk {
+	children := make([]chainhash.Hash, len(node.Children))
+	copy(children, node.Children)
+	node.Children = children
+	return node
 }
 
-// GetJustifiedHead gets the justified head block for a chain.
-func (db *InMemoryDB) GetJustifiedHead(transaction ...interface{}) (*chainhash.Hash, error) {
-	return nil, errors.New("not implemented")
+// GetBlockNode gets the block node with slot.
+func (db *InMemoryDB) GetBlockNode(h chainhash.Hash, transaction ...interface{}) (*BlockNodeDisk, error) {
+	value, found := db.get(blockNodeKey(h), transaction...)
+	if !found {
+		return nil, fmt.Errorf("could not find block node %s", h)
+	}
+	node := copyBlockNodeDisk(value.(BlockNodeDisk))
+	return &node, nil
 }
 
 // SetBlockNode sets the block node in the database.
 func (db *InMemoryDB) SetBlockNode(node BlockNodeDisk, transaction ...interface{}) error {
-	return nil
+	return db.set(blockNodeKey(node.Hash), copyBlockNodeDisk(node), transaction...)
 }
 
-// SetBlockState sets the block state in the database.
-func (db *InMemoryDB) SetBlockState(chainhash.Hash, primitives.State) error {
-	return nil
+// DeleteBlockNode deletes the block node from the database.
+func (db *InMemoryDB) DeleteBlockNode(h chainhash.Hash, transaction ...interface{}) error {
+	return db.delete(blockNodeKey(h), transaction...)
+}
+
+// GetFinalizedHead gets the finalized head block for a chain.
+func (db *InMemoryDB) GetFinalizedHead(transaction ...interface{}) (*chainhash.Hash, error) {
+	return db.getHash(finalizedHeadMemoryKey, transaction...)
+}
+
+// GetJustifiedHead gets the justified head block for a chain.
+func (db *InMemoryDB) GetJustifiedHead(transaction ...interface{}) (*chainhash.Hash, error) {
+	return db.getHash(justifiedHeadMemoryKey, transaction...)
 }
 
 // SetFinalizedHead sets the finalized head for the chain in the database.
 func (db *InMemoryDB) SetFinalizedHead(h chainhash.Hash, transaction ...interface{}) error {
-	return nil
+	return db.set(finalizedHeadMemoryKey, h, transaction...)
 }
 
 // SetJustifiedHead sets the justified head for the chain in the database.
 func (db *InMemoryDB) SetJustifiedHead(h chainhash.Hash, transaction ...interface{}) error {
-	return nil
+	return db.set(justifiedHeadMemoryKey, h, transaction...)
+}
+
+// GetBlockIndexRoot gets the first block in the block index if the chain was started from a checkpoint.
+func (db *InMemoryDB) GetBlockIndexRoot(transaction ...interface{}) (*chainhash.Hash, error) {
+	return db.getHash(blockIndexRootMemoryKey, transaction...)
+}
+
+// SetBlockIndexRoot sets the first block in the block index.
+func (db *InMemoryDB) SetBlockIndexRoot(h chainhash.Hash, transaction ...interface{}) error {
+	return db.set(blockIndexRootMemoryKey, h, transaction...)
 }
 
 // GetGenesisTime gets the genesis time for the chain represented by this database.
 func (db *InMemoryDB) GetGenesisTime(transaction ...interface{}) (uint64, error) {
-	return 0, errors.New("in-memory database does not keep track of genesis time")
+	value, found := db.get(genesisTimeMemoryKey, transaction...)
+	if !found {
+		return 0, errors.New("genesis time has not been set")
+	}
+	return value.(uint64), nil
 }
 
 // SetGenesisTime sets the genesis time for the chain represented by this database.
 func (db *InMemoryDB) SetGenesisTime(t uint64, transaction ...interface{}) error {
-	return nil
+	return db.set(genesisTimeMemoryKey, t, transaction...)
 }
 
 // GetHostKey gets the host key
 func (db *InMemoryDB) GetHostKey(transaction ...interface{}) (crypto.PrivKey, error) {
-	return nil, errors.New("in-memory database does not keep track of host key")
+	value, found := db.get(hostKeyMemoryKey, transaction...)
+	if !found {
+		return nil, errors.New("host key has not been set")
+	}
+	return value.(crypto.PrivKey), nil
 }
 
 // SetHostKey sets the host key
 func (db *InMemoryDB) SetHostKey(key crypto.PrivKey, transaction ...interface{}) error {
-	return nil
+	return db.set(hostKeyMemoryKey, key, transaction...)
 }
 
 // GetFinalizedState gets the finalized state from the database.
 func (db *InMemoryDB) GetFinalizedState(transaction ...interface{}) (*primitives.State, error) {
-	return nil, errors.New("in-memory database does not keep track of finalized state")
+	return db.getState(finalizedStateMemoryKey, transaction...)
 }
 
 // GetJustifiedState gets the justified state from the database.
 func (db *InMemoryDB) GetJustifiedState(transaction ...interface{}) (*primitives.State, error) {
-	return nil, errors.New("in-memory database does not keep track of finalized state")
+	return db.getState(justifiedStateMemoryKey, transaction...)
 }
 
 // SetFinalizedState sets the finalized state
 func (db *InMemoryDB) SetFinalizedState(state primitives.State, transaction ...interface{}) error {
-	return nil
+	return db.setState(finalizedStateMemoryKey, state, transaction...)
 }
 
 // SetJustifiedState sets the justified state
 func (db *InMemoryDB) SetJustifiedState(state primitives.State, transaction ...interface{}) error {
-	return nil
+	return db.setState(justifiedStateMemoryKey, state, transaction...)
+}
+
+func stateCheckpointKey(h chainhash.Hash) string {
+	return "state_checkpoint" + string(h[:])
+}
+
+// SetStateCheckpoint stores the state after processing the given block.
+func (db *InMemoryDB) SetStateCheckpoint(blockHash chainhash.Hash, state primitives.State, transaction ...interface{}) error {
+	return db.setState(stateCheckpointKey(blockHash), state, transaction...)
+}
+
+// GetStateCheckpoint gets the state stored after processing the given block.
+func (db *InMemoryDB) GetStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) (*primitives.State, error) {
+	state, err := db.getState(stateCheckpointKey(blockHash), transaction...)
+	if err != nil {
+		return nil, fmt.Errorf("could not find state checkpoint for block %s", blockHash)
+	}
+	return state, nil
+}
+
+// DeleteStateCheckpoint deletes the state stored after processing the given block.
+func (db *InMemoryDB) DeleteStateCheckpoint(blockHash chainhash.Hash, transaction ...interface{}) error {
+	return db.delete(stateCheckpointKey(blockHash), transaction...)
+}
+
+// GetStateCheckpointPruneSlot gets the slot before which state checkpoints have been pruned.
+func (db *InMemoryDB) GetStateCheckpointPruneSlot(transaction ...interface{}) (uint64, error) {
+	value, found := db.get(stateCheckpointPruneSlotMemoryKey, transaction...)
+	if !found {
+		return 0, errors.New("state checkpoint prune slot has not been set")
+	}
+	return value.(uint64), nil
+}
+
+// SetStateCheckpointPruneSlot sets the slot before which state checkpoints have been pruned.
+func (db *InMemoryDB) SetStateCheckpointPruneSlot(slot uint64, transaction ...interface{}) error {
+	return db.set(stateCheckpointPruneSlotMemoryKey, slot, transaction...)
 }
 
-// TransactionalUpdate executes cb in an update transaction
+// TransactionalUpdate executes cb in an update transaction. Changes made in the
+// transaction are only applied if cb doesn't return an error.
 func (db *InMemoryDB) TransactionalUpdate(cb func(transaction interface{}) error) error {
-	return cb(nil)
+	return db.updateInTransaction(func(txn *memoryTransaction) error {
+		return cb(txn)
+	})
 }
 
 var _ Database = &InMemoryDB{}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dgraph-io/badger"
+	"github.com/sirupsen/logrus"
+)
+
+// migration upgrades the database from the previous schema version to the next one.
+type migration struct {
+	description string
+	migrate     func(txn *badger.Txn) error
+}
+
+// migrations upgrade the database one schema version at a time. migrations[i] upgrades the
+// database from version i to version i+1, so new migrations should only be appended.
+var migrations = []migration{
+	{
+		// data directories created before schema versioning have the same layout as version 1
+		description: "add schema version",
+		migrate: func(txn *badger.Txn) error {
+			return nil
+		},
+	},
+}
+
+var schemaVersionKey = []byte("schema_version")
+
+// getSchemaVersion gets the schema version of the database. Databases without a schema
+// version are version 0.
+func getSchemaVersion(txn *badger.Txn) (uint32, error) {
+	i, err := txn.Get(schemaVersionKey)
+	if err == badger.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	versionBytes, err := i.ValueCopy(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(versionBytes), nil
+}
+
+func setSchemaVersion(txn *badger.Txn, version uint32) error {
+	var versionBytes [4]byte
+	binary.BigEndian.PutUint32(versionBytes[:], version)
+	return txn.Set(schemaVersionKey, versionBytes[:])
+}
+
+// isEmpty checks if the database doesn't have any keys.
+func isEmpty(txn *badger.Txn) bool {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	it.Rewind()
+	return !it.Valid()
+}
+
+// migrateSchema upgrades the database to the latest version in migrations. Each migration runs
+// in its own transaction along with the version update, so an interrupted upgrade resumes from
+// the last completed step.
+func migrateSchema(db *badger.DB, migrations []migration) error {
+	latestVersion := uint32(len(migrations))
+
+	var version uint32
+	err := db.Update(func(txn *badger.Txn) error {
+		if isEmpty(txn) {
+			version = latestVersion
+			return setSchemaVersion(txn, latestVersion)
+		}
+
+		v, err := getSchemaVersion(txn)
+		version = v
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if version > latestVersion {
+		return fmt.Errorf("database has schema version %d, but this version of synapse only supports up to version %d", version, latestVersion)
+	}
+
+	for ; version < latestVersion; version++ {
+		m := migrations[version]
+
+		logrus.WithFields(logrus.Fields{
+			"from":        version,
+			"to":          version + 1,
+			"description": m.description,
+		}).Info("migrating database")
+
+		err := db.Update(func(txn *badger.Txn) error {
+			err := m.migrate(txn)
+			if err != nil {
+				return err
+			}
+
+			return setSchemaVersion(txn, version+1)
+		})
+		if err != nil {
+			return fmt.Errorf("could not migrate database to schema version %d: %s", version+1, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package db
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+)
+
+func openTestBadger(t *testing.T) (*badger.DB, func()) {
+	dir, err := ioutil.TempDir("", "synapse-migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func readSchemaVersion(t *testing.T, db *badger.DB) uint32 {
+	var version uint32
+	err := db.View(func(txn *badger.Txn) error {
+		v, err := getSchemaVersion(txn)
+		version = v
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+var testKey = []byte("test")
+
+// testMigrations appends a character to the value of testKey for each version.
+var testMigrations = []migration{
+	{
+		description: "append a",
+		migrate: func(txn *badger.Txn) error {
+			return appendTestValue(txn, 'a')
+		},
+	},
+	{
+		description: "append b",
+		migrate: func(txn *badger.Txn) error {
+			return appendTestValue(txn, 'b')
+		},
+	},
+	{
+		description: "append c",
+		migrate: func(txn *badger.Txn) error {
+			return appendTestValue(txn, 'c')
+		},
+	},
+}
+
+func appendTestValue(txn *badger.Txn, c byte) error {
+	i, err := txn.Get(testKey)
+	if err != nil {
+		return err
+	}
+	value, err := i.ValueCopy(nil)
+	if err != nil {
+		return err
+	}
+	return txn.Set(testKey, append(value, c))
+}
+
+func readTestValue(t *testing.T, db *badger.DB) string {
+	var value []byte
+	err := db.View(func(txn *badger.Txn) error {
+		i, err := txn.Get(testKey)
+		if err != nil {
+			return err
+		}
+		value, err = i.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(value)
+}
+
+func TestMigrateEmptyDatabase(t *testing.T) {
+	db, cleanup := openTestBadger(t)
+	defer cleanup()
+
+	err := migrateSchema(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version := readSchemaVersion(t, db); version != 3 {
+		t.Fatalf("expected new database to have latest schema version 3, got %d", version)
+	}
+}
+
+func TestMigrateStepByStep(t *testing.T) {
+	db, cleanup := openTestBadger(t)
+	defer cleanup()
+
+	// a database from before schema versioning
+	err := db.Update(func(txn *badger.Txn) error {
+		return txn.Set(testKey, []byte{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = migrateSchema(db, testMigrations[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := readTestValue(t, db); value != "a" {
+		t.Fatalf("expected migration to version 1 to run, got value %q", value)
+	}
+
+	err = migrateSchema(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := readTestValue(t, db); value != "abc" {
+		t.Fatalf("expected only migrations after version 1 to run in order, got value %q", value)
+	}
+
+	if version := readSchemaVersion(t, db); version != 3 {
+		t.Fatalf("expected schema version 3, got %d", version)
+	}
+
+	// migrating an up-to-date database shouldn't do anything
+	err = migrateSchema(db, testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value := readTestValue(t, db); value != "abc" {
+		t.Fatalf("expected no migrations to run, got value %q", value)
+	}
+}
+
+func TestMigrateFailureKeepsVersion(t *testing.T) {
+	db, cleanup := openTestBadger(t)
+	defer cleanup()
+
+	err := db.Update(func(txn *badger.Txn) error {
+		return txn.Set(testKey, []byte{})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failingMigrations := append(testMigrations[:1:1], migration{
+		description: "fail",
+		migrate: func(txn *badger.Txn) error {
+			err := appendTestValue(txn, 'x')
+			if err != nil {
+				return err
+			}
+			return errors.New("migration failed")
+		},
+	})
+
+	err = migrateSchema(db, failingMigrations)
+	if err == nil {
+		t.Fatal("expected failing migration to return an error")
+	}
+
+	if version := readSchemaVersion(t, db); version != 1 {
+		t.Fatalf("expected schema version to stay at the last successful migration, got %d", version)
+	}
+
+	if value := readTestValue(t, db); value != "a" {
+		t.Fatalf("expected failed migration to be rolled back, got value %q", value)
+	}
+}
+
+func TestRefuseNewerSchemaVersion(t *testing.T) {
+	db, cleanup := openTestBadger(t)
+	defer cleanup()
+
+	err := db.Update(func(txn *badger.Txn) error {
+		return setSchemaVersion(txn, uint32(len(testMigrations)+1))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = migrateSchema(db, testMigrations)
+	if err == nil {
+		t.Fatal("expected database with a newer schema version to be refused")
+	}
+}
@@ -0,0 +1,357 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+)
+
+// SnapshotVersion is the version of the snapshot format written by ExportSnapshot.
+const SnapshotVersion = 1
+
+// maxSnapshotRecordSize is the maximum size of a single record in a snapshot.
+const maxSnapshotRecordSize = 1 << 28
+
+var snapshotMagic = []byte("synapsesnapshot")
+
+// writeSnapshotRecord writes a length-prefixed message to a snapshot.
+func writeSnapshotRecord(w io.Writer, msg proto.Message) error {
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	var lengthBytes [4]byte
+	binary.BigEndian.PutUint32(lengthBytes[:], uint32(len(msgBytes)))
+
+	if _, err := w.Write(lengthBytes[:]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(msgBytes)
+	return err
+}
+
+// readSnapshotRecord reads a length-prefixed message from a snapshot.
+func readSnapshotRecord(r io.Reader, msg proto.Message) error {
+	var lengthBytes [4]byte
+	if _, err := io.ReadFull(r, lengthBytes[:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	length := binary.BigEndian.Uint32(lengthBytes[:])
+	if length > maxSnapshotRecordSize {
+		return fmt.Errorf("record in snapshot is too large (%d bytes)", length)
+	}
+
+	msgBytes := make([]byte, length)
+	if _, err := io.ReadFull(r, msgBytes); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	return proto.Unmarshal(msgBytes, msg)
+}
+
+// findBlockIndexRoot walks back from a block to the first block in the block index.
+func findBlockIndexRoot(database Database, h chainhash.Hash) (*BlockNodeDisk, error) {
+	node, err := database.GetBlockNode(h)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		parent, err := database.GetBlockNode(node.Parent)
+		if err != nil {
+			return node, nil
+		}
+		node = parent
+	}
+}
+
+// ExportSnapshot writes the finalized and justified states, the head, the block index and
+// the latest attestations of each validator so that they can be imported into another
+// database using ImportSnapshot. The snapshot is written as a header followed by a record
+// for each block node, so the whole block index is never held in memory.
+func ExportSnapshot(database Database, w io.Writer) error {
+	genesisTime, err := database.GetGenesisTime()
+	if err != nil {
+		return err
+	}
+
+	headBlock, err := database.GetHeadBlock()
+	if err != nil {
+		return err
+	}
+
+	finalizedHead, err := database.GetFinalizedHead()
+	if err != nil {
+		return err
+	}
+
+	finalizedState, err := database.GetFinalizedState()
+	if err != nil {
+		return err
+	}
+
+	justifiedHead, err := database.GetJustifiedHead()
+	if err != nil {
+		return err
+	}
+
+	justifiedState, err := database.GetJustifiedState()
+	if err != nil {
+		return err
+	}
+
+	header := &pb.SnapshotHeader{
+		GenesisTime:    genesisTime,
+		HeadBlock:      headBlock[:],
+		FinalizedHead:  finalizedHead[:],
+		FinalizedState: finalizedState.ToProto(),
+		JustifiedHead:  justifiedHead[:],
+		JustifiedState: justifiedState.ToProto(),
+	}
+
+	var root *BlockNodeDisk
+	if rootHash, err := database.GetBlockIndexRoot(); err == nil {
+		header.BlockIndexRoot = rootHash[:]
+
+		root, err = database.GetBlockNode(*rootHash)
+		if err != nil {
+			return err
+		}
+	} else {
+		root, err = findBlockIndexRoot(database, *finalizedHead)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the header includes the number of block nodes, so find them before writing any blocks
+	var nodes []*BlockNodeDisk
+	queue := []chainhash.Hash{root.Hash}
+	for len(queue) > 0 {
+		node, err := database.GetBlockNode(queue[0])
+		if err != nil {
+			return err
+		}
+		queue = append(queue[1:], node.Children...)
+
+		nodes = append(nodes, node)
+	}
+	header.NumBlockNodes = uint64(len(nodes))
+
+	for i := range justifiedState.ValidatorRegistry {
+		att, err := database.GetLatestAttestation(uint32(i))
+		if err != nil {
+			continue
+		}
+
+		header.LatestAttestations = append(header.LatestAttestations, &pb.SnapshotLatestAttestation{
+			Validator:   uint32(i),
+			Attestation: att.ToProto(),
+		})
+	}
+
+	var versionBytes [4]byte
+	binary.BigEndian.PutUint32(versionBytes[:], SnapshotVersion)
+
+	for _, b := range [][]byte{snapshotMagic, versionBytes[:]} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	err = writeSnapshotRecord(w, header)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		block, err := database.GetBlockForHash(node.Hash)
+		if err != nil {
+			return err
+		}
+
+		children := make([][]byte, len(node.Children))
+		for i := range node.Children {
+			children[i] = node.Children[i][:]
+		}
+
+		err = writeSnapshotRecord(w, &pb.SnapshotBlockNode{
+			Hash:      node.Hash[:],
+			Height:    node.Height,
+			Slot:      node.Slot,
+			Parent:    node.Parent[:],
+			StateRoot: node.StateRoot[:],
+			Children:  children,
+			Block:     block.ToProto(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot into an empty database.
+func ImportSnapshot(database Database, r io.Reader) error {
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return err
+	}
+	if !bytes.Equal(magic, snapshotMagic) {
+		return errors.New("file is not a snapshot")
+	}
+
+	var versionBytes [4]byte
+	if _, err := io.ReadFull(r, versionBytes[:]); err != nil {
+		return err
+	}
+	if version := binary.BigEndian.Uint32(versionBytes[:]); version != SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d (expected version %d)", version, SnapshotVersion)
+	}
+
+	header := new(pb.SnapshotHeader)
+	err := readSnapshotRecord(r, header)
+	if err != nil {
+		return err
+	}
+
+	if _, err := database.GetHeadBlock(); err == nil {
+		return errors.New("can't import snapshot into a database that already has a blockchain")
+	}
+
+	err = database.SetGenesisTime(header.GenesisTime)
+	if err != nil {
+		return err
+	}
+
+	// snapshots can be too large for a single transaction, so the head block is set last
+	// to mark the import as complete
+	for i := uint64(0); i < header.NumBlockNodes; i++ {
+		nodeProto := new(pb.SnapshotBlockNode)
+		err := readSnapshotRecord(r, nodeProto)
+		if err != nil {
+			return err
+		}
+
+		block, err := primitives.BlockFromProto(nodeProto.Block)
+		if err != nil {
+			return err
+		}
+
+		err = database.SetBlock(*block)
+		if err != nil {
+			return err
+		}
+
+		node := BlockNodeDisk{
+			Height:   nodeProto.Height,
+			Slot:     nodeProto.Slot,
+			Children: make([]chainhash.Hash, len(nodeProto.Children)),
+		}
+		copy(node.Hash[:], nodeProto.Hash)
+		copy(node.Parent[:], nodeProto.Parent)
+		copy(node.StateRoot[:], nodeProto.StateRoot)
+		for i := range nodeProto.Children {
+			copy(node.Children[i][:], nodeProto.Children[i])
+		}
+
+		err = database.SetBlockNode(node)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, latestAttestation := range header.LatestAttestations {
+		att, err := primitives.AttestationFromProto(latestAttestation.Attestation)
+		if err != nil {
+			return err
+		}
+
+		err = database.SetLatestAttestationsIfNeeded([]uint32{latestAttestation.Validator}, *att)
+		if err != nil {
+			return err
+		}
+	}
+
+	finalizedHead, err := chainhash.NewHash(header.FinalizedHead)
+	if err != nil {
+		return err
+	}
+
+	finalizedState, err := primitives.StateFromProto(header.FinalizedState)
+	if err != nil {
+		return err
+	}
+
+	justifiedHead, err := chainhash.NewHash(header.JustifiedHead)
+	if err != nil {
+		return err
+	}
+
+	justifiedState, err := primitives.StateFromProto(header.JustifiedState)
+	if err != nil {
+		return err
+	}
+
+	headBlock, err := chainhash.NewHash(header.HeadBlock)
+	if err != nil {
+		return err
+	}
+
+	err = database.SetFinalizedHead(*finalizedHead)
+	if err != nil {
+		return err
+	}
+
+	err = database.SetFinalizedState(*finalizedState)
+	if err != nil {
+		return err
+	}
+
+	// states before the finalized state are regenerated from this checkpoint
+	err = database.SetStateCheckpoint(*finalizedHead, *finalizedState)
+	if err != nil {
+		return err
+	}
+
+	err = database.SetJustifiedHead(*justifiedHead)
+	if err != nil {
+		return err
+	}
+
+	err = database.SetJustifiedState(*justifiedState)
+	if err != nil {
+		return err
+	}
+
+	if len(header.BlockIndexRoot) > 0 {
+		blockIndexRoot, err := chainhash.NewHash(header.BlockIndexRoot)
+		if err != nil {
+			return err
+		}
+
+		err = database.SetBlockIndexRoot(*blockIndexRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return database.SetHeadBlock(*headBlock)
+}
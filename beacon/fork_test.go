@@ -0,0 +1,126 @@
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func TestScheduledForkActivates(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	oldConfig := c
+
+	maxDeposits := 2
+	c.ForkSchedule = []config.Fork{
+		{
+			Epoch:   2,
+			Version: 1,
+			Parameters: config.ForkParameters{
+				MaxDeposits: &maxDeposits,
+			},
+		},
+	}
+
+	genesisTime := time.Unix(1560000000, 0)
+
+	b, keys, err := util.SetupBlockchainWithTime(c.ShardCount*c.TargetCommitteeSize*2+5, &c, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the epoch transition into epoch 2 happens after the block at slot 16
+	mineBlocks(t, b, keys, &c, 2*c.EpochLength)
+
+	forkData := b.GetState().ForkData
+	if forkData.GetVersionForSlot(2*c.EpochLength+1) != 0 {
+		t.Fatal("expected fork to not be active before the fork epoch")
+	}
+
+	mineBlocks(t, b, keys, &c, 3*c.EpochLength)
+
+	expectedForkData := primitives.ForkData{
+		PreForkVersion:  0,
+		PostForkVersion: 1,
+		ForkSlotNumber:  2*c.EpochLength + 1,
+	}
+	if b.GetState().ForkData != expectedForkData {
+		t.Fatalf("expected fork data %+v, got %+v", expectedForkData, b.GetState().ForkData)
+	}
+
+	// attestations for the fork epoch are signed with the new fork version, so it can only be
+	// finalized if they're verified with it too
+	if b.GetState().FinalizedEpoch < 2 {
+		t.Fatalf("expected chain to finalize the fork epoch, got finalized epoch %d", b.GetState().FinalizedEpoch)
+	}
+
+	clock := utils.NewManualClock(genesisTime.Add(time.Duration(2*c.EpochLength*uint64(c.SlotDuration)) * time.Second))
+	b.SetClock(clock)
+	if b.ForkVersion() != 0 {
+		t.Fatalf("expected fork version 0 at the first slot of the fork epoch, got %d", b.ForkVersion())
+	}
+
+	clock.Advance(time.Duration(c.SlotDuration) * time.Second)
+	if b.ForkVersion() != 1 {
+		t.Fatalf("expected fork version 1 after the first slot of the fork epoch, got %d", b.ForkVersion())
+	}
+
+	// a node that doesn't know about the fork rejects blocks signed with the new fork version
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &oldConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldNode, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &oldConfig, validators, true, uint64(genesisTime.Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+	for i := range blocks {
+		_, _, err := oldNode.ProcessBlock(&blocks[i], false, true)
+		if blocks[i].BlockHeader.SlotNumber <= 2*c.EpochLength && err != nil {
+			t.Fatalf("expected block at slot %d from before the fork to be accepted: %s", blocks[i].BlockHeader.SlotNumber, err)
+		}
+		if blocks[i].BlockHeader.SlotNumber > 2*c.EpochLength {
+			if err == nil {
+				t.Fatalf("expected block at slot %d from after the fork to be rejected", blocks[i].BlockHeader.SlotNumber)
+			}
+			break
+		}
+	}
+
+	// checkpoints after the fork can only be used by nodes that know about the fork
+	mineBlocks(t, b, keys, &c, 5*c.EpochLength)
+
+	checkpoint, err := b.GetFinalizedCheckpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if checkpoint.State.Slot <= 2*c.EpochLength {
+		t.Fatalf("expected chain to finalize past the fork, got checkpoint at slot %d", checkpoint.State.Slot)
+	}
+
+	_, err = beacon.NewBlockchainWithCheckpoint(db.NewInMemoryDB(), &c, validators, true, uint64(genesisTime.Unix()), checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = beacon.NewBlockchainWithCheckpoint(db.NewInMemoryDB(), &oldConfig, validators, true, uint64(genesisTime.Unix()), checkpoint)
+	if err == nil {
+		t.Fatal("expected checkpoint from after the fork to be rejected by a node without the fork")
+	}
+}
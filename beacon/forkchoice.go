@@ -0,0 +1,253 @@
+package beacon
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/phoreproject/synapse/chainhash"
+)
+
+// protoNode is a block node in the fork choice store along with the weight of the votes
+// for it and all of its descendants.
+type protoNode struct {
+	node           *BlockNode
+	parent         int
+	weight         uint64
+	bestChild      int
+	bestDescendant int
+}
+
+// latestVote is the latest vote of a validator. The vote in current is the one counted in
+// the node weights and next is the vote that will be counted the next time the head is found.
+type latestVote struct {
+	current  chainhash.Hash
+	next     chainhash.Hash
+	nextSlot uint64
+	balance  uint64
+	counted  bool
+}
+
+// ForkChoice keeps track of the weight of every block node so that the LMD-GHOST head can be
+// found without recounting every vote. When votes or balances change, only the changes are
+// applied to the nodes voted for and then propagated up the tree in a single pass.
+type ForkChoice struct {
+	// lock protects all fields below
+	lock    *sync.Mutex
+	nodes   []protoNode
+	indices map[chainhash.Hash]int
+	votes   map[uint32]*latestVote
+}
+
+// NewForkChoice creates a new, empty fork choice store.
+func NewForkChoice() *ForkChoice {
+	return &ForkChoice{
+		lock:    new(sync.Mutex),
+		nodes:   make([]protoNode, 0),
+		indices: make(map[chainhash.Hash]int),
+		votes:   make(map[uint32]*latestVote),
+	}
+}
+
+// AddBlock adds a block node to the fork choice store. The parent of the node must already
+// have been added unless the node is the root of the tree.
+func (fc *ForkChoice) AddBlock(node *BlockNode) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	if idx, found := fc.indices[node.Hash]; found {
+		// the node was reloaded, so keep the newest copy around
+		fc.nodes[idx].node = node
+		return
+	}
+
+	parent := -1
+	if node.Parent != nil {
+		if idx, found := fc.indices[node.Parent.Hash]; found {
+			parent = idx
+		}
+	}
+
+	fc.nodes = append(fc.nodes, protoNode{
+		node:           node,
+		parent:         parent,
+		bestChild:      -1,
+		bestDescendant: -1,
+	})
+	fc.indices[node.Hash] = len(fc.nodes) - 1
+
+	if parent >= 0 {
+		fc.maybeUpdateBestChild(parent, len(fc.nodes)-1)
+	}
+}
+
+// Prune removes every node that does not descend from the finalized node. Finding the head
+// always starts from the justified node, which descends from the finalized node, so the
+// removed nodes can never be part of the head.
+func (fc *ForkChoice) Prune(finalizedHash chainhash.Hash) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	finalizedIndex, found := fc.indices[finalizedHash]
+	if !found {
+		return
+	}
+
+	// nodes are always added after their parents, so every descendant of the finalized node
+	// comes after it and its parent has already been assigned a new index
+	newIndices := make(map[int]int)
+	newNodes := make([]protoNode, 0, len(fc.nodes)-finalizedIndex)
+	for i := finalizedIndex; i < len(fc.nodes); i++ {
+		n := fc.nodes[i]
+		if i == finalizedIndex {
+			n.parent = -1
+		} else if newParent, found := newIndices[n.parent]; found {
+			n.parent = newParent
+		} else {
+			delete(fc.indices, n.node.Hash)
+			continue
+		}
+		newIndices[i] = len(newNodes)
+		newNodes = append(newNodes, n)
+	}
+
+	for i := 0; i < finalizedIndex; i++ {
+		delete(fc.indices, fc.nodes[i].node.Hash)
+	}
+
+	for i := range newNodes {
+		n := &newNodes[i]
+		fc.indices[n.node.Hash] = i
+		if n.bestChild >= 0 {
+			n.bestChild = newIndices[n.bestChild]
+		}
+		if n.bestDescendant >= 0 {
+			n.bestDescendant = newIndices[n.bestDescendant]
+		}
+	}
+
+	fc.nodes = newNodes
+}
+
+// ProcessAttestation updates the latest vote of each validator to the given block if the
+// attestation is newer than the validator's current vote.
+func (fc *ForkChoice) ProcessAttestation(validators []uint32, blockHash chainhash.Hash, slot uint64) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	for _, v := range validators {
+		vote, found := fc.votes[v]
+		if !found {
+			fc.votes[v] = &latestVote{next: blockHash, nextSlot: slot}
+			continue
+		}
+		if vote.nextSlot >= slot {
+			continue
+		}
+		vote.next = blockHash
+		vote.nextSlot = slot
+	}
+}
+
+// FindHead applies any changes in votes or balances and finds the head of the chain
+// starting from the justified node. balances holds the vote weight of each validator.
+func (fc *ForkChoice) FindHead(justifiedNode *BlockNode, balances []uint64) (*BlockNode, error) {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+
+	fc.applyScoreChanges(fc.computeDeltas(balances))
+
+	justifiedIndex, found := fc.indices[justifiedNode.Hash]
+	if !found {
+		return nil, errors.New("justified node is not in fork choice store")
+	}
+
+	bestDescendant := fc.nodes[justifiedIndex].bestDescendant
+	if bestDescendant < 0 {
+		return justifiedNode, nil
+	}
+
+	return fc.nodes[bestDescendant].node, nil
+}
+
+// computeDeltas gets the change in weight of each node since the last time the head was
+// found and marks the new votes as counted.
+func (fc *ForkChoice) computeDeltas(balances []uint64) []int64 {
+	deltas := make([]int64, len(fc.nodes))
+
+	for validator, vote := range fc.votes {
+		newBalance := uint64(0)
+		if int(validator) < len(balances) {
+			newBalance = balances[validator]
+		}
+
+		// votes for blocks we don't know about yet are counted once the block is added
+		next := vote.current
+		if _, found := fc.indices[vote.next]; found {
+			next = vote.next
+		}
+
+		if vote.counted && next.IsEqual(&vote.current) && newBalance == vote.balance {
+			continue
+		}
+
+		if vote.counted {
+			if idx, found := fc.indices[vote.current]; found {
+				deltas[idx] -= int64(vote.balance)
+			}
+		}
+
+		idx, found := fc.indices[next]
+		if !found {
+			vote.counted = false
+			continue
+		}
+		deltas[idx] += int64(newBalance)
+
+		vote.current = next
+		vote.balance = newBalance
+		vote.counted = true
+	}
+
+	return deltas
+}
+
+// applyScoreChanges applies the deltas to the node weights and updates the best child and
+// best descendant of every node. Nodes are always added after their parents, so iterating
+// backwards visits every node before its parent.
+func (fc *ForkChoice) applyScoreChanges(deltas []int64) {
+	for i := len(fc.nodes) - 1; i >= 0; i-- {
+		n := &fc.nodes[i]
+		n.weight = uint64(int64(n.weight) + deltas[i])
+		if n.parent >= 0 {
+			deltas[n.parent] += deltas[i]
+		}
+	}
+
+	for i := len(fc.nodes) - 1; i >= 0; i-- {
+		if fc.nodes[i].parent >= 0 {
+			fc.maybeUpdateBestChild(fc.nodes[i].parent, i)
+		}
+	}
+}
+
+// maybeUpdateBestChild sets the child as the best child of the parent if it has more weight
+// than the current best child. Ties go to the child that was added first.
+func (fc *ForkChoice) maybeUpdateBestChild(parentIndex int, childIndex int) {
+	parent := &fc.nodes[parentIndex]
+	child := fc.nodes[childIndex]
+
+	bestDescendant := child.bestDescendant
+	if bestDescendant < 0 {
+		bestDescendant = childIndex
+	}
+
+	if parent.bestChild >= 0 && parent.bestChild != childIndex {
+		best := fc.nodes[parent.bestChild]
+		if child.weight < best.weight || (child.weight == best.weight && childIndex > parent.bestChild) {
+			return
+		}
+	}
+
+	parent.bestChild = childIndex
+	parent.bestDescendant = bestDescendant
+}
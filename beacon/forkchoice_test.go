@@ -0,0 +1,256 @@
+package beacon
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/phoreproject/synapse/chainhash"
+)
+
+// findHeadByVoteCount finds the head by recounting the votes for every child from each
+// validator's latest vote. This is how the head was found before the fork choice store.
+func findHeadByVoteCount(justified *BlockNode, votes map[uint32]*BlockNode, balances []uint64) *BlockNode {
+	getVoteCount := func(block *BlockNode) uint64 {
+		count := uint64(0)
+		for validator, target := range votes {
+			node := target.GetAncestorAtSlot(block.Slot)
+			if node == nil {
+				continue
+			}
+			if node.Hash.IsEqual(&block.Hash) {
+				count += balances[validator]
+			}
+		}
+		return count
+	}
+
+	head := justified
+	for len(head.Children) > 0 {
+		best := head.Children[0]
+		bestVotes := getVoteCount(best)
+		for _, c := range head.Children[1:] {
+			if vc := getVoteCount(c); vc > bestVotes {
+				best = c
+				bestVotes = vc
+			}
+		}
+		head = best
+	}
+	return head
+}
+
+type testBlockTree struct {
+	nodes      []*BlockNode
+	forkChoice *ForkChoice
+}
+
+func newTestBlockTree() *testBlockTree {
+	genesis := &BlockNode{Hash: chainhash.HashH([]byte("genesis"))}
+	tree := &testBlockTree{
+		nodes:      []*BlockNode{genesis},
+		forkChoice: NewForkChoice(),
+	}
+	tree.forkChoice.AddBlock(genesis)
+	return tree
+}
+
+// addBlock adds a block building on one of the most recent blocks, so the tree forks often.
+func (tree *testBlockTree) addBlock(r *rand.Rand) *BlockNode {
+	recent := len(tree.nodes) - 4
+	if recent < 0 {
+		recent = 0
+	}
+	parent := tree.nodes[recent+r.Intn(len(tree.nodes)-recent)]
+
+	node := &BlockNode{
+		Hash:   chainhash.HashH(append(parent.Hash[:], byte(len(parent.Children)))),
+		Height: parent.Height + 1,
+		Slot:   parent.Slot + 1 + uint64(r.Intn(2)),
+		Parent: parent,
+	}
+	parent.Children = append(parent.Children, node)
+	tree.nodes = append(tree.nodes, node)
+	tree.forkChoice.AddBlock(node)
+	return node
+}
+
+// vote sets the latest vote of a validator in both the fork choice store and votes.
+func (tree *testBlockTree) vote(votes map[uint32]*BlockNode, validator uint32, node *BlockNode, slot uint64) {
+	votes[validator] = node
+	tree.forkChoice.ProcessAttestation([]uint32{validator}, node.Hash, slot)
+}
+
+func TestForkChoiceMatchesVoteCount(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	numValidators := 256
+
+	tree := newTestBlockTree()
+	votes := make(map[uint32]*BlockNode)
+	balances := make([]uint64, numValidators)
+	for i := range balances {
+		balances[i] = 32 + uint64(r.Intn(32))
+	}
+
+	for slot := uint64(1); slot < 200; slot++ {
+		tree.addBlock(r)
+
+		// some validators change their vote each slot
+		for _, validator := range r.Perm(numValidators)[:numValidators/8] {
+			tree.vote(votes, uint32(validator), tree.nodes[r.Intn(len(tree.nodes))], slot)
+		}
+
+		// and sometimes balances change
+		if slot%16 == 0 {
+			for i := 0; i < numValidators/4; i++ {
+				balances[r.Intn(numValidators)] = uint64(r.Intn(64))
+			}
+		}
+
+		expected := findHeadByVoteCount(tree.nodes[0], votes, balances)
+		actual, err := tree.forkChoice.FindHead(tree.nodes[0], balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !actual.Hash.IsEqual(&expected.Hash) {
+			t.Fatalf("fork choice head does not match at slot %d (expected: %s, got: %s)", slot, expected.Hash, actual.Hash)
+		}
+	}
+}
+
+func TestForkChoicePrune(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	numValidators := 64
+
+	tree := newTestBlockTree()
+	votes := make(map[uint32]*BlockNode)
+	balances := make([]uint64, numValidators)
+	for i := range balances {
+		balances[i] = 32
+	}
+
+	for slot := uint64(1); slot < 100; slot++ {
+		tree.addBlock(r)
+		for _, validator := range r.Perm(numValidators)[:numValidators/4] {
+			tree.vote(votes, uint32(validator), tree.nodes[r.Intn(len(tree.nodes))], slot)
+		}
+	}
+
+	head, err := tree.forkChoice.FindHead(tree.nodes[0], balances)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finalized := head.GetAncestorAtSlot(head.Slot / 2)
+	tree.forkChoice.Prune(finalized.Hash)
+
+	kept := make([]*BlockNode, 0)
+	for _, n := range tree.nodes {
+		isDescendant := n.GetAncestorAtSlot(finalized.Slot) == finalized
+		if _, found := tree.forkChoice.indices[n.Hash]; found != isDescendant {
+			t.Fatalf("expected node at slot %d to be kept: %t", n.Slot, isDescendant)
+		}
+		if isDescendant {
+			kept = append(kept, n)
+		}
+	}
+
+	// new blocks and votes can only build on blocks that weren't pruned
+	tree.nodes = kept
+
+	for slot := uint64(100); slot < 150; slot++ {
+		tree.addBlock(r)
+		for _, validator := range r.Perm(numValidators)[:numValidators/4] {
+			tree.vote(votes, uint32(validator), tree.nodes[r.Intn(len(tree.nodes))], slot)
+		}
+
+		expected := findHeadByVoteCount(finalized, votes, balances)
+		actual, err := tree.forkChoice.FindHead(finalized, balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !actual.Hash.IsEqual(&expected.Hash) {
+			t.Fatalf("fork choice head does not match after pruning at slot %d", slot)
+		}
+	}
+}
+
+func TestForkChoiceIgnoresOlderVotes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	tree := newTestBlockTree()
+	a := tree.addBlock(r)
+	b := &BlockNode{Hash: chainhash.HashH([]byte("b")), Height: 1, Slot: 1, Parent: tree.nodes[0]}
+	tree.nodes[0].Children = append(tree.nodes[0].Children, b)
+	tree.forkChoice.AddBlock(b)
+
+	tree.forkChoice.ProcessAttestation([]uint32{0}, b.Hash, 2)
+	tree.forkChoice.ProcessAttestation([]uint32{0}, a.Hash, 1)
+
+	head, err := tree.forkChoice.FindHead(tree.nodes[0], []uint64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !head.Hash.IsEqual(&b.Hash) {
+		t.Fatal("expected older vote to be ignored")
+	}
+}
+
+const benchmarkValidators = 4096
+
+func setupForkChoiceBenchmark() (*testBlockTree, map[uint32]*BlockNode, []uint64, *rand.Rand) {
+	r := rand.New(rand.NewSource(1))
+
+	tree := newTestBlockTree()
+	for i := 0; i < 256; i++ {
+		tree.addBlock(r)
+	}
+
+	votes := make(map[uint32]*BlockNode)
+	balances := make([]uint64, benchmarkValidators)
+	for i := range balances {
+		balances[i] = 64
+		tree.vote(votes, uint32(i), tree.nodes[len(tree.nodes)-1-r.Intn(64)], 1)
+	}
+
+	return tree, votes, balances, r
+}
+
+func BenchmarkFindHeadByVoteCount(b *testing.B) {
+	tree, votes, balances, r := setupForkChoiceBenchmark()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a committee changes their vote every slot
+		for j := 0; j < benchmarkValidators/64; j++ {
+			votes[uint32(r.Intn(benchmarkValidators))] = tree.nodes[len(tree.nodes)-1-r.Intn(64)]
+		}
+		findHeadByVoteCount(tree.nodes[0], votes, balances)
+	}
+}
+
+func BenchmarkForkChoiceFindHead(b *testing.B) {
+	tree, _, balances, r := setupForkChoiceBenchmark()
+
+	_, err := tree.forkChoice.FindHead(tree.nodes[0], balances)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// a committee changes their vote every slot
+		for j := 0; j < benchmarkValidators/64; j++ {
+			node := tree.nodes[len(tree.nodes)-1-r.Intn(64)]
+			tree.forkChoice.ProcessAttestation([]uint32{uint32(r.Intn(benchmarkValidators))}, node.Hash, uint64(i+2))
+		}
+		_, err := tree.forkChoice.FindHead(tree.nodes[0], balances)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
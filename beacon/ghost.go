@@ -1,70 +1,50 @@
 package beacon
 
-import "github.com/phoreproject/synapse/primitives"
-
-type blockNodeAndValidator struct {
-	node      *BlockNode
-	validator uint32
+import (
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/primitives"
+)
+
+// getVoteBalances gets the weight of the vote of each validator in the justified state.
+func getVoteBalances(justifiedState *primitives.State, c *config.Config) []uint64 {
+	balances := make([]uint64, len(justifiedState.ValidatorRegistry))
+	for _, i := range primitives.GetActiveValidatorIndices(justifiedState.ValidatorRegistry) {
+		balances[i] = justifiedState.GetEffectiveBalance(i, c) / 1e8
+	}
+	return balances
 }
 
 // UpdateChainHead updates the blockchain head if needed
 func (b *Blockchain) UpdateChainHead() error {
-	validators := b.View.justifiedHead.State.ValidatorRegistry
-	activeValidatorIndices := primitives.GetActiveValidatorIndices(validators)
-	var targets []blockNodeAndValidator
-	for _, i := range activeValidatorIndices {
-		bl, err := b.getLatestAttestationTarget(i)
-		if err != nil {
-			continue
-		}
-		targets = append(targets, blockNodeAndValidator{
-			node:      bl,
-			validator: i})
-	}
+	balances := getVoteBalances(&b.View.justifiedHead.State, b.config)
 
-	getVoteCount := func(block *BlockNode) uint64 {
-		votes := uint64(0)
-		for _, target := range targets {
-			node := target.node.GetAncestorAtSlot(block.Slot)
-			if node == nil {
-				return 0
-			}
-			if node.Hash.IsEqual(&block.Hash) {
-				votes += b.View.justifiedHead.State.GetEffectiveBalance(target.validator, b.config) / 1e8
-			}
-		}
-		return votes
-	}
+	previousHead := b.View.Chain.Tip()
 
-	head, _ := b.View.GetJustifiedHead()
+	justifiedNode, _ := b.View.GetJustifiedHead()
 
 	// this may seem weird, but it occurs when importing when the justified block is not
 	// imported, but the finalized head is. It should never occur other than that
-	if head == nil {
-		head, _ = b.View.GetFinalizedHead()
+	if justifiedNode == nil {
+		justifiedNode, _ = b.View.GetFinalizedHead()
 	}
 
-	for {
-		children := head.Children
-		if len(children) == 0 {
-			b.View.Chain.SetTip(head)
+	head, err := b.forkChoice.FindHead(justifiedNode, balances)
+	if err != nil {
+		return err
+	}
+
+	b.View.Chain.SetTip(head)
 
-			err := b.DB.SetHeadBlock(head.Hash)
-			if err != nil {
-				return err
-			}
+	err = b.DB.SetHeadBlock(head.Hash)
+	if err != nil {
+		return err
+	}
 
-			return nil
-		}
-		bestVoteCountChild := children[0]
-		bestVotes := getVoteCount(bestVoteCountChild)
-		for _, c := range children[1:] {
-			vc := getVoteCount(c)
-			if vc > bestVotes {
-				bestVoteCountChild = c
-				bestVotes = vc
-			}
-		}
-		head = bestVoteCountChild
+	if previousHead != head {
+		b.notify(func(n BlockchainNotifee) {
+			n.UpdateHead(head)
+		})
 	}
+
+	return nil
 }
@@ -0,0 +1,288 @@
+package beacon
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxSeenGossipMessages is the number of recent blocks and attestations kept to detect
+	// duplicates.
+	maxSeenGossipMessages = 1024
+
+	// maxGossipFutureSlots is how many slots ahead of the current slot gossiped blocks can be
+	// to allow for clock differences.
+	maxGossipFutureSlots = 1
+)
+
+// Reasons gossip messages are rejected.
+const (
+	RejectMalformed        = "malformed"
+	RejectDuplicate        = "duplicate"
+	RejectSlotOutOfRange   = "slot out of range"
+	RejectInvalidSignature = "invalid signature"
+)
+
+// Reasons gossip messages are ignored. Ignored messages aren't relayed, but unlike rejected
+// messages they may be valid.
+const (
+	IgnoreUnknownParent = "unknown parent"
+)
+
+// recentHashes is a set of the most recently added hashes.
+type recentHashes struct {
+	hashes map[chainhash.Hash]struct{}
+	order  []chainhash.Hash
+	next   int
+}
+
+func newRecentHashes(size int) *recentHashes {
+	return &recentHashes{
+		hashes: make(map[chainhash.Hash]struct{}),
+		order:  make([]chainhash.Hash, 0, size),
+	}
+}
+
+// add adds a hash to the set, replacing the oldest hash if the set is full. This returns
+// false if the hash was already in the set.
+func (r *recentHashes) add(h chainhash.Hash) bool {
+	if _, found := r.hashes[h]; found {
+		return false
+	}
+
+	if len(r.order) < cap(r.order) {
+		r.order = append(r.order, h)
+	} else {
+		delete(r.hashes, r.order[r.next])
+		r.order[r.next] = h
+		r.next = (r.next + 1) % len(r.order)
+	}
+
+	r.hashes[h] = struct{}{}
+	return true
+}
+
+// GossipValidator checks blocks and attestations received over gossip before they're handled
+// or relayed to other peers, and keeps track of how many were rejected.
+type GossipValidator struct {
+	blockchain *Blockchain
+
+	lock             *sync.Mutex
+	seenBlocks       *recentHashes
+	seenAttestations *recentHashes
+	rejected         map[string]map[string]uint64
+	ignored          map[string]map[string]uint64
+
+	// orphanHandler is called with blocks that aren't relayed because their parent is unknown
+	orphanHandler func(*primitives.Block, peer.ID)
+}
+
+// NewGossipValidator creates a gossip validator for a blockchain.
+func NewGossipValidator(blockchain *Blockchain) *GossipValidator {
+	return &GossipValidator{
+		blockchain:       blockchain,
+		lock:             new(sync.Mutex),
+		seenBlocks:       newRecentHashes(maxSeenGossipMessages),
+		seenAttestations: newRecentHashes(maxSeenGossipMessages),
+		rejected:         make(map[string]map[string]uint64),
+		ignored:          make(map[string]map[string]uint64),
+	}
+}
+
+// SetOrphanHandler sets the function called with blocks received over gossip that aren't
+// relayed because their parent is unknown, so they can be kept until the parent arrives.
+func (v *GossipValidator) SetOrphanHandler(handler func(*primitives.Block, peer.ID)) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.orphanHandler = handler
+}
+
+// markSeen marks a message as seen and returns false if it was already seen.
+func (v *GossipValidator) markSeen(seen *recentHashes, h chainhash.Hash) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return seen.add(h)
+}
+
+func (v *GossipValidator) reject(topic string, reason string, from peer.ID, err error) {
+	v.lock.Lock()
+	if _, found := v.rejected[topic]; !found {
+		v.rejected[topic] = make(map[string]uint64)
+	}
+	v.rejected[topic][reason]++
+	count := v.rejected[topic][reason]
+	v.lock.Unlock()
+
+	logger.WithFields(logger.Fields{
+		"topic":  topic,
+		"reason": reason,
+		"from":   from,
+		"total":  count,
+	}).Debugf("rejected gossip message: %s", err)
+}
+
+func (v *GossipValidator) ignore(topic string, reason string, from peer.ID) {
+	v.lock.Lock()
+	if _, found := v.ignored[topic]; !found {
+		v.ignored[topic] = make(map[string]uint64)
+	}
+	v.ignored[topic][reason]++
+	v.lock.Unlock()
+
+	logger.WithFields(logger.Fields{
+		"topic":  topic,
+		"reason": reason,
+		"from":   from,
+	}).Debug("ignored gossip message")
+}
+
+// GetIgnoredMessageCounts gets the number of messages ignored on a topic for each reason.
+func (v *GossipValidator) GetIgnoredMessageCounts(topic string) map[string]uint64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	counts := make(map[string]uint64)
+	for reason, count := range v.ignored[topic] {
+		counts[reason] = count
+	}
+	return counts
+}
+
+// GetRejectedMessageCounts gets the number of messages rejected on a topic for each reason.
+func (v *GossipValidator) GetRejectedMessageCounts(topic string) map[string]uint64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	counts := make(map[string]uint64)
+	for reason, count := range v.rejected[topic] {
+		counts[reason] = count
+	}
+	return counts
+}
+
+// ValidateBlock checks a block received on the block topic. Blocks must be after the
+// finalized block and not ahead of the current slot, must not have been seen recently, and
+// must have a valid proposer signature. The signature can only be checked once we have the
+// parent, so blocks with unknown parents are ignored instead of being relayed, and are only
+// passed to the orphan handler to be kept locally.
+func (v *GossipValidator) ValidateBlock(data []byte, from peer.ID) bool {
+	block, reason, err := v.validateBlock(data)
+	if err != nil {
+		v.reject("block", reason, from, err)
+		return false
+	}
+
+	if !v.blockchain.View.Index.Has(block.BlockHeader.ParentRoot) {
+		v.ignore("block", IgnoreUnknownParent, from)
+
+		v.lock.Lock()
+		handler := v.orphanHandler
+		v.lock.Unlock()
+
+		if handler != nil {
+			handler(block, from)
+		}
+		return false
+	}
+
+	return true
+}
+
+func (v *GossipValidator) validateBlock(data []byte) (*primitives.Block, string, error) {
+	blockProto := new(pb.Block)
+	err := proto.Unmarshal(data, blockProto)
+	if err != nil {
+		return nil, RejectMalformed, err
+	}
+
+	block, err := primitives.BlockFromProto(blockProto)
+	if err != nil {
+		return nil, RejectMalformed, err
+	}
+
+	slot := block.BlockHeader.SlotNumber
+	finalizedNode, _ := v.blockchain.View.GetFinalizedHead()
+	if slot > v.blockchain.GetCurrentSlot()+maxGossipFutureSlots || slot <= finalizedNode.Slot {
+		return nil, RejectSlotOutOfRange, fmt.Errorf("block slot %d is outside of the gossip window", slot)
+	}
+
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		return nil, RejectMalformed, err
+	}
+
+	if !v.markSeen(v.seenBlocks, blockHash) {
+		return nil, RejectDuplicate, fmt.Errorf("already saw block %s", chainhash.Hash(blockHash))
+	}
+
+	if v.blockchain.View.Index.Has(block.BlockHeader.ParentRoot) {
+		err := v.blockchain.VerifyProposerSignature(block)
+		if err != nil {
+			return nil, RejectInvalidSignature, err
+		}
+	}
+
+	return block, "", nil
+}
+
+// ValidateAttestation checks an attestation received on the attestation topic. Attestations
+// must be for a slot in the last epoch, must not have been seen recently and must have a valid
+// aggregate signature.
+func (v *GossipValidator) ValidateAttestation(data []byte, from peer.ID) bool {
+	reason, err := v.validateAttestation(data)
+	if err != nil {
+		v.reject("attestation", reason, from, err)
+		return false
+	}
+	return true
+}
+
+func (v *GossipValidator) validateAttestation(data []byte) (string, error) {
+	attestationProto := new(pb.Attestation)
+	err := proto.Unmarshal(data, attestationProto)
+	if err != nil {
+		return RejectMalformed, err
+	}
+
+	attestation, err := primitives.AttestationFromProto(attestationProto)
+	if err != nil {
+		return RejectMalformed, err
+	}
+
+	slot := attestation.Data.Slot
+	currentSlot := v.blockchain.GetCurrentSlot()
+	if slot > currentSlot || slot+v.blockchain.config.EpochLength < currentSlot {
+		return RejectSlotOutOfRange, fmt.Errorf("attestation slot %d is outside of the gossip window", slot)
+	}
+
+	attestationHash, err := ssz.HashTreeRoot(attestation)
+	if err != nil {
+		return RejectMalformed, err
+	}
+
+	if !v.markSeen(v.seenAttestations, attestationHash) {
+		return RejectDuplicate, fmt.Errorf("already saw attestation %s", chainhash.Hash(attestationHash))
+	}
+
+	state, err := v.blockchain.GetStateForAttestation(slot)
+	if err != nil {
+		return RejectSlotOutOfRange, err
+	}
+
+	err = state.VerifyAttestationSignature(*attestation, v.blockchain.config)
+	if err != nil {
+		return RejectInvalidSignature, err
+	}
+
+	return "", nil
+}
@@ -0,0 +1,232 @@
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func marshalBlock(t *testing.T, block *primitives.Block) []byte {
+	data, err := proto.Marshal(block.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func marshalAttestation(t *testing.T, attestation *primitives.Attestation) []byte {
+	data, err := proto.Marshal(attestation.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// signBlock signs a block that extends the tip of a blockchain as its proposer.
+func signBlock(t *testing.T, b *beacon.Blockchain, keys validator.Keystore, block *primitives.Block) {
+	slot := block.BlockHeader.SlotNumber
+
+	state, err := b.GetUpdatedState(slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposerIndex, err := state.GetBeaconProposerIndex(slot-1, b.GetConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block.BlockHeader.Signature = bls.EmptySignature.Serialize()
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposalRoot, err := ssz.HashTreeRoot(primitives.ProposalSignedData{
+		Slot:      slot,
+		Shard:     b.GetConfig().BeaconShardNumber,
+		BlockHash: blockHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := bls.Sign(keys.GetKeyForValidator(proposerIndex), proposalRoot[:], primitives.GetDomain(state.ForkData, slot, bls.DomainProposal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.BlockHeader.Signature = sig.Serialize()
+}
+
+func TestGossipValidator(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	// start the chain so the current slot is just after the blocks we mine
+	genesisTime := time.Now().Add(-time.Duration(c.SlotDuration) * 11 * time.Second)
+
+	b, keys, err := util.SetupBlockchainWithTime(c.ShardCount*c.TargetCommitteeSize*2+5, &c, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mineBlocks(t, b, keys, &c, 10)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &c, validators, true, uint64(genesisTime.Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+	for i := range blocks[:len(blocks)-1] {
+		_, _, err := b2.ProcessBlock(&blocks[i], false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := beacon.NewGossipValidator(b2)
+
+	newBlock := blocks[len(blocks)-1]
+	if !v.ValidateBlock(marshalBlock(t, &newBlock), "") {
+		t.Fatal("expected valid block to be accepted")
+	}
+
+	if v.ValidateBlock(marshalBlock(t, &newBlock), "") {
+		t.Fatal("expected duplicate block to be rejected")
+	}
+
+	invalidSignature := newBlock.Copy()
+	invalidSignature.BlockHeader.Signature = invalidSignature.BlockHeader.RandaoReveal
+	if v.ValidateBlock(marshalBlock(t, &invalidSignature), "") {
+		t.Fatal("expected block with an invalid signature to be rejected")
+	}
+
+	// the proposer can move the randao reveal into the block signature without changing the sum
+	// of the signatures, so the signatures must be checked separately
+	cancellingSignatures := newBlock.Copy()
+	cancellingSignatures.BlockHeader.RandaoReveal = bls.EmptySignature.Serialize()
+	signBlock(t, b2, keys, &cancellingSignatures)
+	blockSig, err := bls.DeserializeSignature(cancellingSignatures.BlockHeader.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	randaoSig, err := bls.DeserializeSignature(newBlock.BlockHeader.RandaoReveal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockSig.AggregateSig(randaoSig)
+	cancellingSignatures.BlockHeader.Signature = blockSig.Serialize()
+	if v.ValidateBlock(marshalBlock(t, &cancellingSignatures), "") {
+		t.Fatal("expected block with invalid signatures that cancel out to be rejected")
+	}
+
+	futureBlock := newBlock.Copy()
+	futureBlock.BlockHeader.SlotNumber += 5
+	if v.ValidateBlock(marshalBlock(t, &futureBlock), "") {
+		t.Fatal("expected block too far in the future to be rejected")
+	}
+
+	if v.ValidateBlock([]byte{1, 2, 3}, "") {
+		t.Fatal("expected malformed block to be rejected")
+	}
+
+	// blocks with unknown parents can't be checked yet, so they're kept locally but not relayed
+	var orphans []*primitives.Block
+	v.SetOrphanHandler(func(block *primitives.Block, from peer.ID) {
+		orphans = append(orphans, block)
+	})
+
+	orphan := newBlock.Copy()
+	orphan.BlockHeader.ParentRoot = chainhash.HashH([]byte("unknown parent"))
+	if v.ValidateBlock(marshalBlock(t, &orphan), "") {
+		t.Fatal("expected block with an unknown parent to not be relayed")
+	}
+
+	if len(orphans) != 1 || orphans[0].BlockHeader.ParentRoot != orphan.BlockHeader.ParentRoot {
+		t.Fatal("expected block with an unknown parent to be passed to the orphan handler")
+	}
+
+	ignored := v.GetIgnoredMessageCounts("block")
+	if ignored[beacon.IgnoreUnknownParent] != 1 {
+		t.Fatalf("unexpected ignored block counts: %v", ignored)
+	}
+
+	rejected := v.GetRejectedMessageCounts("block")
+	if rejected[beacon.RejectDuplicate] != 1 || rejected[beacon.RejectInvalidSignature] != 2 || rejected[beacon.RejectSlotOutOfRange] != 1 || rejected[beacon.RejectMalformed] != 1 {
+		t.Fatalf("unexpected rejected block counts: %v", rejected)
+	}
+
+	attestation := newBlock.BlockBody.Attestations[0]
+	if !v.ValidateAttestation(marshalAttestation(t, &attestation), "") {
+		t.Fatal("expected valid attestation to be accepted")
+	}
+
+	if v.ValidateAttestation(marshalAttestation(t, &attestation), "") {
+		t.Fatal("expected duplicate attestation to be rejected")
+	}
+
+	invalidAttestation := attestation.Copy()
+	invalidAttestation.AggregateSig = newBlock.BlockHeader.Signature
+	if v.ValidateAttestation(marshalAttestation(t, &invalidAttestation), "") {
+		t.Fatal("expected attestation with an invalid signature to be rejected")
+	}
+
+	oldAttestation := blocks[1].BlockBody.Attestations[0]
+	if v.ValidateAttestation(marshalAttestation(t, &oldAttestation), "") {
+		t.Fatal("expected old attestation to be rejected")
+	}
+
+	rejected = v.GetRejectedMessageCounts("attestation")
+	if rejected[beacon.RejectDuplicate] != 1 || rejected[beacon.RejectInvalidSignature] != 1 || rejected[beacon.RejectSlotOutOfRange] != 1 {
+		t.Fatalf("unexpected rejected attestation counts: %v", rejected)
+	}
+}
+
+func TestGossipValidatorAttestationAtStartOfEpoch(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	// start the chain so the current slot is the first slot of the second epoch
+	genesisTime := time.Now().Add(-time.Duration(c.SlotDuration) * time.Duration(c.EpochLength+1) * time.Second)
+
+	b, keys, err := util.SetupBlockchainWithTime(c.ShardCount*c.TargetCommitteeSize*2+5, &c, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mineBlocks(t, b, keys, &c, c.EpochLength)
+
+	v := beacon.NewGossipValidator(b)
+
+	// the committee attesting in the first slot of the epoch isn't in the head state yet
+	attestation := attestationAfterTip(t, b, keys, &c)
+	if !v.ValidateAttestation(marshalAttestation(t, &attestation), "") {
+		t.Fatalf("expected attestation at the start of an epoch to be accepted, rejected: %v", v.GetRejectedMessageCounts("attestation"))
+	}
+}
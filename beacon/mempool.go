@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"errors"
+	"math/bits"
 	"sort"
 	"sync"
 
@@ -17,15 +18,23 @@ import (
 
 // Mempool keeps track of actions (attestations, deposits, exits, slashings) to include in blocks.
 type Mempool struct {
-	AttestationMempool *attestationMempool
-	blockchain         *Blockchain
+	AttestationMempool      *attestationMempool
+	ProposerSlashingMempool *proposerSlashingMempool
+	CasperSlashingMempool   *casperSlashingMempool
+	DepositMempool          *depositMempool
+	ExitMempool             *exitMempool
+	blockchain              *Blockchain
 }
 
 // NewMempool creates a new mempool.
 func NewMempool(blockchain *Blockchain) *Mempool {
 	m := &Mempool{
-		AttestationMempool: newAttestationMempool(blockchain),
-		blockchain:         blockchain,
+		AttestationMempool:      newAttestationMempool(blockchain),
+		ProposerSlashingMempool: newProposerSlashingMempool(),
+		CasperSlashingMempool:   newCasperSlashingMempool(),
+		DepositMempool:          newDepositMempool(),
+		ExitMempool:             newExitMempool(),
+		blockchain:              blockchain,
 	}
 
 	blockchain.RegisterNotifee(m)
@@ -42,8 +51,19 @@ func (m *Mempool) ConnectBlock(b *primitives.Block) {
 		}
 		m.RemoveAttestationsFromBitfield(attHash, a.ParticipationBitfield)
 	}
+
+	m.removeIncludedActions(b)
 }
 
+// UpdateHead is part of the blockchain notifee.
+func (m *Mempool) UpdateHead(*BlockNode) {}
+
+// JustifyEpoch is part of the blockchain notifee.
+func (m *Mempool) JustifyEpoch(*BlockNode, uint64) {}
+
+// FinalizeEpoch is part of the blockchain notifee.
+func (m *Mempool) FinalizeEpoch(*BlockNode, uint64) {}
+
 type attestationMempool struct {
 	blockchain       *Blockchain
 	attestations     map[chainhash.Hash][]primitives.Attestation // maps the hashed data
@@ -58,8 +78,20 @@ func newAttestationMempool(blockchain *Blockchain) *attestationMempool {
 	}
 }
 
-// ProcessNewAttestation processes a new attestation to be included in a block.
+// ProcessNewAttestation processes a new attestation to be included in a block. If the
+// attestation doesn't intersect with an attestation already in the mempool for the same
+// data, it's aggregated into that attestation.
 func (m *Mempool) ProcessNewAttestation(att primitives.Attestation) error {
+	state, err := m.blockchain.GetStateForAttestation(att.Data.Slot)
+	if err != nil {
+		return err
+	}
+
+	err = state.VerifyAttestationSignature(att, m.blockchain.config)
+	if err != nil {
+		return err
+	}
+
 	m.AttestationMempool.attestationsLock.Lock()
 	defer m.AttestationMempool.attestationsLock.Unlock()
 
@@ -68,23 +100,83 @@ func (m *Mempool) ProcessNewAttestation(att primitives.Attestation) error {
 		return err
 	}
 
-	if atts, found := m.AttestationMempool.attestations[attHash]; found {
-		for _, a := range atts {
-			for i, b := range a.ParticipationBitfield {
-				if b&att.ParticipationBitfield[i] != 0 {
-					logrus.Debug("duplicate attestation, ignoring")
-					return nil
-				}
-			}
+	atts, found := m.AttestationMempool.attestations[attHash]
+	if !found {
+		m.AttestationMempool.attestations[attHash] = []primitives.Attestation{att.Copy()}
+		return nil
+	}
+
+	for _, a := range atts {
+		if len(a.ParticipationBitfield) != len(att.ParticipationBitfield) {
+			return errors.New("participation bitfield did not match")
+		}
+		if bitfieldContains(a.ParticipationBitfield, att.ParticipationBitfield) {
+			logrus.Debug("duplicate attestation, ignoring")
+			return nil
+		}
+	}
+
+	for i := range atts {
+		intersects, err := doAttestationsIntersect(atts[i].ParticipationBitfield, &att)
+		if err != nil {
+			return err
+		}
+		if intersects {
+			continue
 		}
 
-		m.AttestationMempool.attestations[attHash] = append(m.AttestationMempool.attestations[attHash], att)
-	} else {
-		m.AttestationMempool.attestations[attHash] = []primitives.Attestation{att}
+		aggregated, err := aggregateAttestations(atts[i], att)
+		if err != nil {
+			return err
+		}
+		atts[i] = *aggregated
+		return nil
 	}
+
+	// the attestation overlaps with every aggregate we have, so keep it separately
+	m.AttestationMempool.attestations[attHash] = append(atts, att.Copy())
 	return nil
 }
 
+// aggregateAttestations combines two attestations with the same data and
+// non-intersecting participation into a single attestation.
+func aggregateAttestations(a primitives.Attestation, b primitives.Attestation) (*primitives.Attestation, error) {
+	sigA, err := bls.DeserializeSignature(a.AggregateSig)
+	if err != nil {
+		return nil, err
+	}
+	sigB, err := bls.DeserializeSignature(b.AggregateSig)
+	if err != nil {
+		return nil, err
+	}
+	aggregateSig, err := bls.AggregateSigs([]*bls.Signature{sigA, sigB})
+	if err != nil {
+		return nil, err
+	}
+
+	aggregated := a.Copy()
+	aggregated.AggregateSig = aggregateSig.Serialize()
+	for i := range aggregated.ParticipationBitfield {
+		aggregated.ParticipationBitfield[i] |= b.ParticipationBitfield[i]
+	}
+	for i := range aggregated.CustodyBitfield {
+		if i < len(b.CustodyBitfield) {
+			aggregated.CustodyBitfield[i] |= b.CustodyBitfield[i]
+		}
+	}
+	return &aggregated, nil
+}
+
+// bitfieldContains checks if every bit set in other is also set in bitfield.
+func bitfieldContains(bitfield []uint8, other []uint8) bool {
+	for i := range other {
+		if bitfield[i]&other[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
 type attestationWithRealSigAndCount struct {
 	custodyBitfield       []uint8
 	participationBitfield []uint8
@@ -138,6 +230,9 @@ func (m *Mempool) GetAttestationsToInclude(slot uint64, lastBlockHash chainhash.
 		return nil, err
 	}
 
+	// the block uses the parameters of its epoch, which starts after the first slot of the epoch
+	c = c.ForEpoch((slot - 1) / c.EpochLength)
+
 	// go through all of the (separate) attestations we've received
 	for hash, atts := range am.attestations {
 		for _, att := range atts {
@@ -177,21 +272,14 @@ func (m *Mempool) GetAttestationsToInclude(slot uint64, lastBlockHash chainhash.
 					aggregateSignature:    sig,
 					participationBitfield: make([]uint8, len(att.ParticipationBitfield)),
 					custodyBitfield:       make([]uint8, len(att.CustodyBitfield)),
-					count:                 1,
+					count:                 countBits(att.ParticipationBitfield),
 				}
 
 				copy(aggregatedAttestationMap[hash].participationBitfield, att.ParticipationBitfield)
 				copy(aggregatedAttestationMap[hash].custodyBitfield, att.CustodyBitfield)
 			} else {
-				intersects := false
-
-				for i, b := range aggAtt.participationBitfield {
-					if b&att.ParticipationBitfield[i] != 0 {
-						intersects = true
-					}
-				}
-
-				if intersects {
+				intersects, err := doAttestationsIntersect(aggAtt.participationBitfield, &att)
+				if err != nil || intersects {
 					continue
 				}
 
@@ -201,7 +289,11 @@ func (m *Mempool) GetAttestationsToInclude(slot uint64, lastBlockHash chainhash.
 				}
 
 				// update the signature
-				aggregatedAttestationMap[hash].aggregateSignature.AggregateSig(sig)
+				aggregateSig, err := bls.AggregateSigs([]*bls.Signature{aggAtt.aggregateSignature, sig})
+				if err != nil {
+					return nil, err
+				}
+				aggregatedAttestationMap[hash].aggregateSignature = aggregateSig
 
 				// update the participation bitfield
 				for i := range aggregatedAttestationMap[hash].participationBitfield {
@@ -211,8 +303,8 @@ func (m *Mempool) GetAttestationsToInclude(slot uint64, lastBlockHash chainhash.
 					aggregatedAttestationMap[hash].custodyBitfield[i] |= att.CustodyBitfield[i]
 				}
 
-				// keep track of how many attestations are included in the aggregated attestation
-				aggregatedAttestationMap[hash].count++
+				// keep track of how many validators are included in the aggregated attestation
+				aggregatedAttestationMap[hash].count = countBits(aggregatedAttestationMap[hash].participationBitfield)
 			}
 		}
 	}
@@ -225,7 +317,8 @@ func (m *Mempool) GetAttestationsToInclude(slot uint64, lastBlockHash chainhash.
 		i++
 	}
 
-	sort.Sort(byCount(attestations))
+	// include the attestations with the most participants first
+	sort.Sort(sort.Reverse(byCount(attestations)))
 
 	numAttestationsToInclude := len(attestations)
 	if numAttestationsToInclude > c.MaxAttestations {
@@ -299,6 +392,15 @@ func (am *attestationMempool) Size() int {
 	return len(am.attestations)
 }
 
+// countBits counts the number of bits set in a bitfield.
+func countBits(bitfield []uint8) int {
+	count := 0
+	for _, b := range bitfield {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
 func doAttestationsIntersect(participationBitfield []byte, att *primitives.Attestation) (bool, error) {
 	if len(participationBitfield) != len(att.ParticipationBitfield) {
 		return false, errors.New("participation bitfield did not match")
@@ -337,7 +439,7 @@ func (am *attestationMempool) GetMempoolDifference(message *pb.GetMempoolMessage
 		} else {
 			// we do have the attestation, so figure out which attestations to send
 			for _, att := range atts {
-				intersect, err := doAttestationsIntersect(att.ParticipationBitfield, &att)
+				intersect, err := doAttestationsIntersect(mempoolInv.Participation, &att)
 				if err != nil {
 					return nil, err
 				}
@@ -373,7 +475,8 @@ func (am *attestationMempool) GetMempoolSummary() *pb.GetMempoolMessage {
 			continue
 		}
 
-		participationBitfield := atts[0].ParticipationBitfield[:]
+		participationBitfield := make([]uint8, len(atts[0].ParticipationBitfield))
+		copy(participationBitfield, atts[0].ParticipationBitfield)
 
 		for _, p := range atts[1:] {
 			for i := range participationBitfield {
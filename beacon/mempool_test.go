@@ -1 +1,291 @@
-package beacon
+package beacon_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAttestationAggregation(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := &config.RegtestConfig
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+1, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := beacon.NewMempool(b)
+
+	s := b.GetState()
+	proposerIndex, err := s.GetBeaconProposerIndex(0, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tip := b.View.Chain.Tip()
+
+	state, err := b.GetUpdatedState(tip.Slot + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fullAttestations, err := util.GenerateFakeAttestations(state, b, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fullAttestation := fullAttestations[0]
+
+	committees, err := state.GetShardCommitteesAtSlot(fullAttestation.Data.Slot-1, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var committee []uint32
+	for _, sc := range committees {
+		if sc.Shard == fullAttestation.Data.Shard {
+			committee = sc.Committee
+		}
+	}
+
+	dataRoot, err := ssz.HashTreeRoot(primitives.AttestationDataAndCustodyBit{Data: fullAttestation.Data, PoCBit: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// submit an attestation for each member of the committee separately
+	for i, validatorIndex := range committee {
+		sig, err := bls.Sign(keys.GetKeyForValidator(validatorIndex), dataRoot[:], bls.DomainAttestation)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		participation := make([]byte, len(fullAttestation.ParticipationBitfield))
+		participation, err = util.SetBit(participation, uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		att := primitives.Attestation{
+			Data:                  fullAttestation.Data,
+			ParticipationBitfield: participation,
+			CustodyBitfield:       make([]uint8, 32),
+			AggregateSig:          sig.Serialize(),
+		}
+
+		err = m.ProcessNewAttestation(att)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// submitting the same attestation again should be ignored
+		err = m.ProcessNewAttestation(att)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wrongSig, err := bls.Sign(keys.GetKeyForValidator(committee[0]), []byte("not the attestation"), bls.DomainAttestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := fullAttestation.Copy()
+	invalid.AggregateSig = wrongSig.Serialize()
+	if err := m.ProcessNewAttestation(invalid); err == nil {
+		t.Fatal("expected attestation with invalid signature to be rejected")
+	}
+
+	if m.AttestationMempool.Size() != 1 {
+		t.Fatalf("expected attestations to be stored under one hash, got %d", m.AttestationMempool.Size())
+	}
+
+	atts, err := m.GetAttestationsToInclude(tip.Slot+1, tip.Hash, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(atts) != 1 {
+		t.Fatalf("expected attestations to be aggregated into 1 attestation, got %d", len(atts))
+	}
+
+	if !bytes.Equal(atts[0].ParticipationBitfield, fullAttestation.ParticipationBitfield) {
+		t.Fatalf("expected aggregated participation %v, got %v", fullAttestation.ParticipationBitfield, atts[0].ParticipationBitfield)
+	}
+
+	err = state.VerifyAttestationSignature(atts[0], c)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// attestationAfterTip creates an attestation for the slot after the tip, which must be the first
+// slot of an epoch, signed by the first member of its committee.
+func attestationAfterTip(t *testing.T, b *beacon.Blockchain, keys validator.Keystore, c *config.Config) primitives.Attestation {
+	// the first slot of the next epoch doesn't have a block yet, so the committee attesting in
+	// it isn't in the head state
+	tip := b.View.Chain.Tip()
+	attestationSlot := tip.Slot + 1
+
+	headState := b.GetState()
+	if _, err := headState.GetShardCommitteesAtSlot(attestationSlot-1, c); err == nil {
+		t.Fatal("expected head state not to have the committees of the next epoch")
+	}
+
+	state, err := b.GetUpdatedState(attestationSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	committees, err := state.GetShardCommitteesAtSlot(attestationSlot-1, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committee := committees[0]
+
+	data := primitives.AttestationData{
+		Slot:            attestationSlot,
+		Shard:           committee.Shard,
+		BeaconBlockHash: tip.Hash,
+		SourceEpoch:     state.JustifiedEpoch,
+		TargetEpoch:     state.EpochIndex,
+		TargetHash:      tip.Hash,
+	}
+
+	dataRoot, err := ssz.HashTreeRoot(primitives.AttestationDataAndCustodyBit{Data: data, PoCBit: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := bls.Sign(keys.GetKeyForValidator(committee.Committee[0]), dataRoot[:], primitives.GetDomain(state.ForkData, attestationSlot, bls.DomainAttestation))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	participation, err := util.SetBit(make([]byte, (len(committee.Committee)+7)/8), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return primitives.Attestation{
+		Data:                  data,
+		ParticipationBitfield: participation,
+		CustodyBitfield:       make([]uint8, 32),
+		AggregateSig:          sig.Serialize(),
+	}
+}
+
+func TestAttestationAtStartOfEpoch(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := beacon.NewMempool(b)
+
+	for b.View.Chain.Tip().Slot < c.EpochLength {
+		state, err := b.GetUpdatedState(b.View.Chain.Tip().Slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := state.GetBeaconProposerIndex(b.View.Chain.Tip().Slot, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = m.ProcessNewAttestation(attestationAfterTip(t, b, keys, &c))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.AttestationMempool.Size() != 1 {
+		t.Fatalf("expected attestation to be added to the mempool, got %d", m.AttestationMempool.Size())
+	}
+}
+
+func TestStateForAttestationIsCached(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for b.View.Chain.Tip().Slot < c.EpochLength {
+		state, err := b.GetUpdatedState(b.View.Chain.Tip().Slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := state.GetBeaconProposerIndex(b.View.Chain.Tip().Slot, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tipSlot := b.View.Chain.Tip().Slot
+
+	headState, err := b.GetStateForAttestation(tipSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if headState.Slot != tipSlot {
+		t.Fatalf("expected head state for attestation at the tip, got state at slot %d", headState.Slot)
+	}
+
+	state, err := b.GetStateForAttestation(tipSlot + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.GetShardCommitteesAtSlot(tipSlot, &c); err != nil {
+		t.Fatalf("expected state to have the committees of the next epoch: %s", err)
+	}
+
+	sameEpochState, err := b.GetStateForAttestation(tipSlot + 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sameEpochState != state {
+		t.Fatal("expected attestations in the same epoch to reuse the processed state")
+	}
+}
@@ -1,13 +1,80 @@
 package beacon
 
-import "github.com/phoreproject/synapse/primitives"
+import (
+	"sync"
+
+	"github.com/phoreproject/synapse/primitives"
+)
 
 // BlockchainNotifee is a blockchain notifee.
 type BlockchainNotifee interface {
+	// ConnectBlock is called when a new block is added to the block index.
 	ConnectBlock(*primitives.Block)
+
+	// UpdateHead is called when the head of the main chain changes.
+	UpdateHead(head *BlockNode)
+
+	// JustifyEpoch is called when a new epoch is justified by a processed block.
+	JustifyEpoch(justifiedNode *BlockNode, epoch uint64)
+
+	// FinalizeEpoch is called when a new epoch is finalized by a processed block.
+	FinalizeEpoch(finalizedNode *BlockNode, epoch uint64)
+}
+
+// notifeeQueue calls a notifee in the order the blockchain sent notifications without
+// blocking the blockchain while the notifee handles them.
+type notifeeQueue struct {
+	notifee BlockchainNotifee
+
+	// lock protects pending
+	lock    *sync.Mutex
+	cond    *sync.Cond
+	pending []func(BlockchainNotifee)
+}
+
+func newNotifeeQueue(n BlockchainNotifee) *notifeeQueue {
+	lock := new(sync.Mutex)
+	q := &notifeeQueue{
+		notifee: n,
+		lock:    lock,
+		cond:    sync.NewCond(lock),
+	}
+	go q.run()
+	return q
+}
+
+func (q *notifeeQueue) push(notify func(BlockchainNotifee)) {
+	q.lock.Lock()
+	q.pending = append(q.pending, notify)
+	q.lock.Unlock()
+
+	q.cond.Signal()
+}
+
+func (q *notifeeQueue) run() {
+	for {
+		q.lock.Lock()
+		for len(q.pending) == 0 {
+			q.cond.Wait()
+		}
+		notify := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		q.lock.Unlock()
+
+		notify(q.notifee)
+	}
 }
 
 // RegisterNotifee registers a notifee for blockchain
 func (b *Blockchain) RegisterNotifee(n BlockchainNotifee) {
-	b.Notifees = append(b.Notifees, n)
+	b.notifees = append(b.notifees, newNotifeeQueue(n))
+}
+
+// notify sends a notification to every notifee. Each notifee receives notifications in the
+// order they were sent.
+func (b *Blockchain) notify(notify func(BlockchainNotifee)) {
+	for _, q := range b.notifees {
+		q.push(notify)
+	}
 }
@@ -0,0 +1,142 @@
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+type channelNotifee struct {
+	blocks chan *primitives.Block
+	heads  chan *beacon.BlockNode
+}
+
+func (c *channelNotifee) ConnectBlock(b *primitives.Block) {
+	c.blocks <- b
+}
+
+func (c *channelNotifee) UpdateHead(head *beacon.BlockNode) {
+	c.heads <- head
+}
+
+func (c *channelNotifee) JustifyEpoch(*beacon.BlockNode, uint64) {}
+
+func (c *channelNotifee) FinalizeEpoch(*beacon.BlockNode, uint64) {}
+
+func TestNotifeeReceivesBlockAndHead(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	b, keys, err := util.SetupBlockchain(config.RegtestConfig.ShardCount*config.RegtestConfig.TargetCommitteeSize*2+1, &config.RegtestConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := &channelNotifee{
+		blocks: make(chan *primitives.Block, 1),
+		heads:  make(chan *beacon.BlockNode, 1),
+	}
+	b.RegisterNotifee(n)
+
+	s := b.GetState()
+	proposerIndex, err := s.GetBeaconProposerIndex(0, b.GetConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case connected := <-n.blocks:
+		if connected.BlockHeader.SlotNumber != block.BlockHeader.SlotNumber {
+			t.Fatalf("expected connected block at slot %d, got %d", block.BlockHeader.SlotNumber, connected.BlockHeader.SlotNumber)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifee did not receive connected block")
+	}
+
+	select {
+	case head := <-n.heads:
+		tip := b.View.Chain.Tip()
+		if !head.Hash.IsEqual(&tip.Hash) {
+			t.Fatalf("expected head %s, got %s", tip.Hash, head.Hash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifee did not receive head update")
+	}
+}
+
+type notification struct {
+	head bool
+	slot uint64
+}
+
+type orderedNotifee struct {
+	notifications chan notification
+}
+
+func (o *orderedNotifee) ConnectBlock(b *primitives.Block) {
+	o.notifications <- notification{head: false, slot: b.BlockHeader.SlotNumber}
+}
+
+func (o *orderedNotifee) UpdateHead(head *beacon.BlockNode) {
+	o.notifications <- notification{head: true, slot: head.Slot}
+}
+
+func (o *orderedNotifee) JustifyEpoch(*beacon.BlockNode, uint64) {}
+
+func (o *orderedNotifee) FinalizeEpoch(*beacon.BlockNode, uint64) {}
+
+func TestNotifeeReceivesNotificationsInOrder(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	b, keys, err := util.SetupBlockchain(config.RegtestConfig.ShardCount*config.RegtestConfig.TargetCommitteeSize*2+1, &config.RegtestConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const blocks = 10
+
+	n := &orderedNotifee{
+		notifications: make(chan notification, blocks*2),
+	}
+	b.RegisterNotifee(n)
+
+	for i := 0; i < blocks; i++ {
+		s, err := b.GetUpdatedState(b.View.Chain.Tip().Slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := s.GetBeaconProposerIndex(b.View.Chain.Tip().Slot, b.GetConfig())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the head is updated before the block is connected
+	for slot := uint64(1); slot <= blocks; slot++ {
+		for _, head := range []bool{true, false} {
+			select {
+			case got := <-n.notifications:
+				if got.head != head || got.slot != slot {
+					t.Fatalf("expected notification %+v, got %+v", notification{head: head, slot: slot}, got)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("notifee did not receive notification")
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package beacon
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// pruneOrphanedForks removes any forks that branch off before the finalized node from the
+// block index, the fork choice store, the state map and the database. Those blocks can
+// never become part of the main chain.
+func (b *Blockchain) pruneOrphanedForks(finalizedNode *BlockNode) error {
+	b.pruneLock.Lock()
+	defer b.pruneLock.Unlock()
+
+	if finalizedNode == b.lastPruned {
+		return nil
+	}
+
+	// only prune if the finalized node is part of the main chain
+	tip := b.View.Chain.Tip()
+	if tip == nil || tip.GetAncestorAtSlot(finalizedNode.Slot) != finalizedNode {
+		return nil
+	}
+
+	pruned, updated := b.View.Index.PruneOrphans(finalizedNode, b.lastPruned)
+	b.forkChoice.Prune(finalizedNode.Hash)
+
+	b.lastPruned = finalizedNode
+
+	if len(pruned) == 0 {
+		return nil
+	}
+
+	for _, node := range pruned {
+		b.stateManager.DeleteStateForHash(node.Hash)
+	}
+
+	err := b.DB.TransactionalUpdate(func(transaction interface{}) error {
+		for _, node := range pruned {
+			if err := b.DB.DeleteBlock(node.Hash, transaction); err != nil {
+				return err
+			}
+			if err := b.DB.DeleteBlockNode(node.Hash, transaction); err != nil {
+				return err
+			}
+			if err := b.DB.DeleteStateCheckpoint(node.Hash, transaction); err != nil {
+				return err
+			}
+		}
+
+		for _, node := range updated {
+			if err := b.DB.SetBlockNode(blockNodeToDisk(*node), transaction); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	b.prunedBlocks += uint64(len(pruned))
+
+	logrus.WithFields(logrus.Fields{
+		"finalizedSlot": finalizedNode.Slot,
+		"pruned":        len(pruned),
+		"totalPruned":   b.prunedBlocks,
+	}).Info("pruned orphaned blocks")
+
+	return nil
+}
+
+// GetPrunedBlockCount gets the number of orphaned blocks removed since the blockchain
+// was loaded.
+func (b *Blockchain) GetPrunedBlockCount() uint64 {
+	b.pruneLock.Lock()
+	defer b.pruneLock.Unlock()
+
+	return b.prunedBlocks
+}
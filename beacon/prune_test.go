@@ -0,0 +1,162 @@
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+// unattestedBlock creates a block competing with another block by removing its attestations.
+func unattestedBlock(t *testing.T, block *primitives.Block, keys validator.Keystore, proposerIndex uint32, c *config.Config) primitives.Block {
+	fork := block.Copy()
+	fork.BlockBody.Attestations = []primitives.Attestation{}
+	fork.BlockHeader.Signature = bls.EmptySignature.Serialize()
+
+	unsignedHash, err := ssz.HashTreeRoot(fork)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	psdHash, err := ssz.HashTreeRoot(primitives.ProposalSignedData{
+		Slot:      fork.BlockHeader.SlotNumber,
+		Shard:     c.BeaconShardNumber,
+		BlockHash: unsignedHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := bls.Sign(keys.GetKeyForValidator(proposerIndex), psdHash[:], bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork.BlockHeader.Signature = sig.Serialize()
+
+	return fork
+}
+
+func TestPruneOrphanedForks(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var forkHash chainhash.Hash
+	var canonicalHash chainhash.Hash
+	var lateFork primitives.Block
+
+	for i := uint64(0); i < c.EpochLength*5+1; i++ {
+		s, err := b.GetUpdatedState(i + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := s.GetBeaconProposerIndex(i, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, err := util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if block.BlockHeader.SlotNumber == 3 {
+			// keep a competing block until after the chain is finalized
+			lateFork = unattestedBlock(t, block, keys, proposerIndex, &c)
+		}
+
+		if block.BlockHeader.SlotNumber != 2 {
+			continue
+		}
+
+		canonicalHash, err = ssz.HashTreeRoot(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// propose a competing block without any attestations at the same slot
+		fork := unattestedBlock(t, block, keys, proposerIndex, &c)
+
+		forkHash, err = ssz.HashTreeRoot(fork)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err = b.ProcessBlock(&fork, false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !b.View.Index.Has(forkHash) {
+			t.Fatal("expected fork block to be added to the block index")
+		}
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	if finalizedNode.Slot <= 2 {
+		t.Fatalf("expected chain to finalize past slot 2 (finalized slot: %d)", finalizedNode.Slot)
+	}
+
+	if b.View.Index.Has(forkHash) {
+		t.Fatal("expected fork block to be pruned from the block index")
+	}
+
+	if _, err := b.DB.GetBlockForHash(forkHash); err == nil {
+		t.Fatal("expected fork block to be pruned from the database")
+	}
+
+	if !b.View.Index.Has(canonicalHash) {
+		t.Fatal("expected canonical block to be kept")
+	}
+
+	if _, err := b.DB.GetBlockForHash(canonicalHash); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.GetPrunedBlockCount() != 1 {
+		t.Fatalf("expected 1 pruned block, got %d", b.GetPrunedBlockCount())
+	}
+
+	// blocks branching off before the finalized block are rejected instead of being added to
+	// the block index where they would never be pruned
+	lateForkHash, err := ssz.HashTreeRoot(lateFork)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := b.ProcessBlock(&lateFork, false, true); err == nil {
+		t.Fatal("expected block branching off before the finalized block to be rejected")
+	}
+
+	if b.View.Index.Has(lateForkHash) {
+		t.Fatal("expected block branching off before the finalized block to not be added to the block index")
+	}
+
+	// the chain should keep working after pruning
+	s, err := b.GetUpdatedState(b.View.Chain.Tip().Slot + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposerIndex, err := s.GetBeaconProposerIndex(b.View.Chain.Tip().Slot, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
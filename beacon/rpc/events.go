@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+// eventBufferSize is the number of events buffered for each subscriber before the
+// subscriber is considered too slow and is disconnected.
+const eventBufferSize = 128
+
+var errSubscriberTooSlow = errors.New("subscriber fell too far behind the chain and was disconnected")
+
+type chainEventSubscriber struct {
+	request *pb.SubscribeChainEventsRequest
+	events  chan *pb.ChainEvent
+}
+
+func (s *chainEventSubscriber) wants(eventType pb.ChainEventType) bool {
+	switch eventType {
+	case pb.ChainEventType_BLOCK:
+		return s.request.Blocks
+	case pb.ChainEventType_HEAD:
+		return s.request.Heads
+	case pb.ChainEventType_JUSTIFIED:
+		return s.request.Justifications
+	case pb.ChainEventType_FINALIZED:
+		return s.request.Finalizations
+	}
+	return false
+}
+
+// chainEventNotifee receives notifications from the blockchain and relays them
+// to any RPC clients subscribed to chain events. The blockchain notifies it in order,
+// so subscribers receive events in the order they happened.
+type chainEventNotifee struct {
+	config *config.Config
+
+	// lock protects subscribers
+	lock        *sync.Mutex
+	subscribers map[*chainEventSubscriber]struct{}
+}
+
+func newChainEventNotifee(c *config.Config) *chainEventNotifee {
+	return &chainEventNotifee{
+		config:      c,
+		lock:        new(sync.Mutex),
+		subscribers: make(map[*chainEventSubscriber]struct{}),
+	}
+}
+
+func (n *chainEventNotifee) subscribe(request *pb.SubscribeChainEventsRequest) *chainEventSubscriber {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	sub := &chainEventSubscriber{
+		request: request,
+		events:  make(chan *pb.ChainEvent, eventBufferSize),
+	}
+	n.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (n *chainEventNotifee) unsubscribe(sub *chainEventSubscriber) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, found := n.subscribers[sub]; found {
+		delete(n.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (n *chainEventNotifee) broadcast(event *pb.ChainEvent) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for sub := range n.subscribers {
+		if !sub.wants(event.Type) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// the subscriber isn't keeping up, so drop it instead of blocking the blockchain
+			logrus.Warn("chain event subscriber is not keeping up, disconnecting")
+			delete(n.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// ConnectBlock is part of the blockchain notifee.
+func (n *chainEventNotifee) ConnectBlock(block *primitives.Block) {
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	n.broadcast(&pb.ChainEvent{
+		Type:      pb.ChainEventType_BLOCK,
+		BlockHash: blockHash[:],
+		Slot:      block.BlockHeader.SlotNumber,
+		Epoch:     block.BlockHeader.SlotNumber / n.config.EpochLength,
+		Block:     block.ToProto(),
+	})
+}
+
+// UpdateHead is part of the blockchain notifee.
+func (n *chainEventNotifee) UpdateHead(head *beacon.BlockNode) {
+	n.broadcast(&pb.ChainEvent{
+		Type:      pb.ChainEventType_HEAD,
+		BlockHash: head.Hash[:],
+		Slot:      head.Slot,
+		Epoch:     head.Slot / n.config.EpochLength,
+	})
+}
+
+// JustifyEpoch is part of the blockchain notifee.
+func (n *chainEventNotifee) JustifyEpoch(justifiedNode *beacon.BlockNode, epoch uint64) {
+	n.broadcast(&pb.ChainEvent{
+		Type:      pb.ChainEventType_JUSTIFIED,
+		BlockHash: justifiedNode.Hash[:],
+		Slot:      justifiedNode.Slot,
+		Epoch:     epoch,
+	})
+}
+
+// FinalizeEpoch is part of the blockchain notifee.
+func (n *chainEventNotifee) FinalizeEpoch(finalizedNode *beacon.BlockNode, epoch uint64) {
+	n.broadcast(&pb.ChainEvent{
+		Type:      pb.ChainEventType_FINALIZED,
+		BlockHash: finalizedNode.Hash[:],
+		Slot:      finalizedNode.Slot,
+		Epoch:     epoch,
+	})
+}
+
+var _ beacon.BlockchainNotifee = &chainEventNotifee{}
@@ -8,7 +8,6 @@ import (
 	"net"
 
 	"github.com/phoreproject/synapse/p2p"
-	"github.com/phoreproject/synapse/utils"
 
 	"github.com/golang/protobuf/proto"
 
@@ -26,11 +25,17 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// Broadcaster broadcasts messages to peers on a topic.
+type Broadcaster interface {
+	Broadcast(topic string, data []byte) error
+}
+
 // server is used to implement rpc.BlockchainRPCServer.
 type server struct {
 	chain   *beacon.Blockchain
-	p2p     *p2p.HostNode
+	p2p     Broadcaster
 	mempool *beacon.Mempool
+	events  *chainEventNotifee
 }
 
 // SubmitAttestation submits an attestation to the mempool.
@@ -57,6 +62,115 @@ func (s *server) SubmitAttestation(ctx context.Context, att *pb.Attestation) (*e
 	return &empty.Empty{}, nil
 }
 
+// SubmitProposerSlashing submits a proposer slashing to the mempool.
+func (s *server) SubmitProposerSlashing(ctx context.Context, in *pb.ProposerSlashing) (*empty.Empty, error) {
+	slashing, err := primitives.ProposerSlashingFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mempool.ProcessNewProposerSlashing(*slashing)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.p2p.Broadcast("proposerSlashing", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// SubmitCasperSlashing submits a casper slashing to the mempool.
+func (s *server) SubmitCasperSlashing(ctx context.Context, in *pb.CasperSlashing) (*empty.Empty, error) {
+	slashing, err := primitives.CasperSlashingFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mempool.ProcessNewCasperSlashing(*slashing)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.p2p.Broadcast("casperSlashing", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// SetDepositRoot sets the latest deposit tree root blocks built from the mempool vote for.
+func (s *server) SetDepositRoot(ctx context.Context, in *pb.SetDepositRootRequest) (*empty.Empty, error) {
+	var root chainhash.Hash
+	err := root.SetBytes(in.DepositRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mempool.SetDepositRoot(root)
+
+	return &empty.Empty{}, nil
+}
+
+// SubmitDeposit submits a deposit to the mempool.
+func (s *server) SubmitDeposit(ctx context.Context, in *pb.Deposit) (*empty.Empty, error) {
+	deposit, err := primitives.DepositFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mempool.ProcessNewDeposit(*deposit)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.p2p.Broadcast("deposit", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// SubmitExit submits a exit to the mempool.
+func (s *server) SubmitExit(ctx context.Context, in *pb.Exit) (*empty.Empty, error) {
+	exit, err := primitives.ExitFromProto(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mempool.ProcessNewExit(*exit)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.p2p.Broadcast("exit", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 // GetMempool gets the mempool for a block.
 func (s *server) GetMempool(ctx context.Context, req *pb.MempoolRequest) (*pb.BlockBody, error) {
 	if req == nil {
@@ -73,13 +187,11 @@ func (s *server) GetMempool(ctx context.Context, req *pb.MempoolRequest) (*pb.Bl
 		return nil, err
 	}
 
-	bb := primitives.BlockBody{
-		Attestations:      atts,
-		ProposerSlashings: make([]primitives.ProposerSlashing, 0),
-		CasperSlashings:   make([]primitives.CasperSlashing, 0),
-		Deposits:          make([]primitives.Deposit, 0),
-		Exits:             make([]primitives.Exit, 0),
+	bb, err := s.mempool.GetActionsToInclude(s.chain.GetCurrentSlot(), *lastBlockHash, s.chain.GetConfig())
+	if err != nil {
+		return nil, err
 	}
+	bb.Attestations = atts
 
 	return bb.ToProto(), nil
 }
@@ -118,7 +230,7 @@ func (s *server) GetSlotNumber(ctx context.Context, in *empty.Empty) (*pb.SlotNu
 	config := s.chain.GetConfig()
 	genesisTime := state.GenesisTime
 	timePerSlot := config.SlotDuration
-	currentTime := utils.Now().Unix()
+	currentTime := s.chain.GetClock().Now().Unix()
 	currentSlot := (currentTime - int64(genesisTime)) / int64(timePerSlot)
 	if currentSlot < 0 {
 		currentSlot = 0
@@ -155,6 +267,42 @@ func (s *server) GetState(ctx context.Context, in *empty.Empty) (*pb.GetStateRes
 	return &pb.GetStateResponse{State: stateProto}, nil
 }
 
+// GetStateAtSlot gets the state of the main chain at a certain slot.
+func (s *server) GetStateAtSlot(ctx context.Context, in *pb.GetStateAtSlotRequest) (*pb.GetStateResponse, error) {
+	state, err := s.chain.GetStateAtSlot(in.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetStateResponse{State: state.ToProto()}, nil
+}
+
+// GetStateForBlock gets the state after processing a certain block.
+func (s *server) GetStateForBlock(ctx context.Context, in *pb.GetStateForBlockRequest) (*pb.GetStateResponse, error) {
+	h, err := chainhash.NewHash(in.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.chain.GetStateForBlock(*h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetStateResponse{State: state.ToProto()}, nil
+}
+
+// GetFinalizedCheckpoint gets the finalized block and state that new nodes can start
+// syncing from.
+func (s *server) GetFinalizedCheckpoint(ctx context.Context, in *empty.Empty) (*pb.Checkpoint, error) {
+	checkpoint, err := s.chain.GetFinalizedCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint.ToProto(), nil
+}
+
 // GetStateRoot gets the hash of the state in the main chain.
 func (s *server) GetStateRoot(ctx context.Context, in *empty.Empty) (*pb.GetStateRootResponse, error) {
 	return &pb.GetStateRootResponse{StateRoot: s.chain.View.Chain.Tip().StateRoot[:]}, nil
@@ -163,26 +311,11 @@ func (s *server) GetStateRoot(ctx context.Context, in *empty.Empty) (*pb.GetStat
 // GetEpochInformation gets information about the current epoch used for attestation
 // assignment and generation.
 func (s *server) GetEpochInformation(ctx context.Context, in *pb.EpochInformationRequest) (*pb.EpochInformationResponse, error) {
-	state := s.chain.GetState()
 	config := s.chain.GetConfig()
 
-	requestedEpochSlot := uint64(in.EpochIndex) * s.chain.GetConfig().EpochLength
-
-	if requestedEpochSlot > state.Slot {
-		updatedState, err := s.chain.GetUpdatedState(requestedEpochSlot)
-		if err != nil {
-			return nil, err
-		}
-		state = *updatedState
-	}
-
-	if state.EpochIndex < in.EpochIndex {
-		state = state.Copy()
-
-		_, err := state.ProcessEpochTransition(s.chain.GetConfig())
-		if err != nil {
-			return nil, err
-		}
+	state, err := s.getStateForEpoch(in.EpochIndex)
+	if err != nil {
+		return nil, err
 	}
 
 	epochBoundaryRoot, err := s.chain.View.Chain.GetBlockBySlot(in.EpochIndex * config.EpochLength)
@@ -217,14 +350,17 @@ func (s *server) GetEpochInformation(ctx context.Context, in *pb.EpochInformatio
 
 	earliestSlot := int64(state.Slot) - int64(state.Slot%config.EpochLength) - int64(config.EpochLength)
 
-	slots := make([]*pb.ShardCommitteesForSlot, len(state.ShardAndCommitteeForSlots))
-	for s := range slots {
-		shardAndCommittees := make([]*pb.ShardCommittee, len(state.ShardAndCommitteeForSlots[s]))
-		for i := range shardAndCommittees {
-			shardAndCommittees[i] = state.ShardAndCommitteeForSlots[s][i].ToProto()
-		}
-		slots[s] = &pb.ShardCommitteesForSlot{
-			Committees: shardAndCommittees,
+	var slots []*pb.ShardCommitteesForSlot
+	if !in.ExcludeCommittees {
+		slots = make([]*pb.ShardCommitteesForSlot, len(state.ShardAndCommitteeForSlots))
+		for s := range slots {
+			shardAndCommittees := make([]*pb.ShardCommittee, len(state.ShardAndCommitteeForSlots[s]))
+			for i := range shardAndCommittees {
+				shardAndCommittees[i] = state.ShardAndCommitteeForSlots[s][i].ToProto()
+			}
+			slots[s] = &pb.ShardCommitteesForSlot{
+				Committees: shardAndCommittees,
+			}
 		}
 	}
 
@@ -245,6 +381,100 @@ func (s *server) GetEpochInformation(ctx context.Context, in *pb.EpochInformatio
 	}, nil
 }
 
+// getStateForEpoch gets the state of the main chain after processing the epoch transition
+// for a certain epoch.
+func (s *server) getStateForEpoch(epoch uint64) (*primitives.State, error) {
+	state := s.chain.GetState()
+
+	requestedEpochSlot := epoch * s.chain.GetConfig().EpochLength
+
+	if requestedEpochSlot > state.Slot {
+		updatedState, err := s.chain.GetUpdatedState(requestedEpochSlot)
+		if err != nil {
+			return nil, err
+		}
+		state = *updatedState
+	}
+
+	if state.EpochIndex < epoch {
+		state = state.Copy()
+
+		_, err := state.ProcessEpochTransition(s.chain.GetConfig())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &state, nil
+}
+
+// GetValidatorDuties gets the proposer and attester assignments of certain validators
+// for an epoch.
+func (s *server) GetValidatorDuties(ctx context.Context, in *pb.GetValidatorDutiesRequest) (*pb.GetValidatorDutiesResponse, error) {
+	config := s.chain.GetConfig()
+
+	state, err := s.getStateForEpoch(in.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// validators requested more than once only get one entry
+	dutiesByValidator := make(map[uint32]*pb.ValidatorDuties, len(in.Validators))
+	duties := make([]*pb.ValidatorDuties, 0, len(in.Validators))
+	for _, v := range in.Validators {
+		if uint32(len(state.ValidatorRegistry)) <= v {
+			return nil, fmt.Errorf("could not find validator with ID %d", v)
+		}
+
+		if _, found := dutiesByValidator[v]; found {
+			continue
+		}
+
+		d := &pb.ValidatorDuties{
+			Validator:      v,
+			ProposerSlots:  []uint64{},
+			AttesterDuties: []*pb.AttesterDuty{},
+		}
+		duties = append(duties, d)
+		dutiesByValidator[v] = d
+	}
+
+	firstSlot := in.Epoch * config.EpochLength
+	for slot := firstSlot; slot < firstSlot+config.EpochLength; slot++ {
+		committees, err := state.GetShardCommitteesAtSlot(slot, config)
+		if err != nil {
+			return nil, err
+		}
+
+		// the committees assigned at a slot propose and attest in the following slot
+		proposer, err := state.GetBeaconProposerIndex(slot, config)
+		if err != nil {
+			return nil, err
+		}
+		if d, found := dutiesByValidator[proposer]; found {
+			d.ProposerSlots = append(d.ProposerSlots, slot+1)
+		}
+
+		for _, committee := range committees {
+			for committeeIndex, v := range committee.Committee {
+				if d, found := dutiesByValidator[v]; found {
+					d.AttesterDuties = append(d.AttesterDuties, &pb.AttesterDuty{
+						Slot:           slot + 1,
+						Shard:          committee.Shard,
+						CommitteeIndex: uint64(committeeIndex),
+						CommitteeSize:  uint64(len(committee.Committee)),
+					})
+				}
+			}
+		}
+	}
+
+	return &pb.GetValidatorDutiesResponse{
+		Epoch:  in.Epoch,
+		Duties: duties,
+	}, nil
+}
+
 // GetCommitteesForSlot gets the current committees at a slot.
 func (s *server) GetCommitteesForSlot(ctx context.Context, in *pb.GetCommitteesForSlotRequest) (*pb.ShardCommitteesForSlot, error) {
 	state := s.chain.GetState()
@@ -263,7 +493,7 @@ func (s *server) GetCommitteesForSlot(ctx context.Context, in *pb.GetCommitteesF
 	}, nil
 }
 
-// GetForkData gets the current fork data.
+// GetForkData gets the fork data of the head state.
 func (s *server) GetForkData(ctx context.Context, in *empty.Empty) (*pb.ForkData, error) {
 	state := s.chain.GetState()
 	return state.ForkData.ToProto(), nil
@@ -310,16 +540,48 @@ func (s *server) GetValidatorInformation(ctx context.Context, in *pb.GetValidato
 	return validator.ToProto(), nil
 }
 
+// SubscribeChainEvents streams new blocks, head changes, justifications and finalizations
+// to the client as they happen.
+func (s *server) SubscribeChainEvents(in *pb.SubscribeChainEventsRequest, stream pb.BlockchainRPC_SubscribeChainEventsServer) error {
+	sub := s.events.subscribe(in)
+	defer s.events.unsubscribe(sub)
+
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				return errSubscriberTooSlow
+			}
+
+			err := stream.Send(event)
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// NewServer creates an RPC server for a blockchain. Blocks and actions submitted through the
+// server are broadcast to peers using the broadcaster.
+func NewServer(b *beacon.Blockchain, broadcaster Broadcaster, mempool *beacon.Mempool) *grpc.Server {
+	events := newChainEventNotifee(b.GetConfig())
+	b.RegisterNotifee(events)
+
+	s := grpc.NewServer()
+	pb.RegisterBlockchainRPCServer(s, &server{b, broadcaster, mempool, events})
+	// Register reflection service on gRPC server.
+	reflection.Register(s)
+	return s
+}
+
 // Serve serves the RPC server
 func Serve(proto string, listenAddr string, b *beacon.Blockchain, hostNode *p2p.HostNode, mempool *beacon.Mempool) error {
 	lis, err := net.Listen(proto, listenAddr)
 	if err != nil {
 		return err
 	}
-	s := grpc.NewServer()
-	pb.RegisterBlockchainRPCServer(s, &server{b, hostNode, mempool})
-	// Register reflection service on gRPC server.
-	reflection.Register(s)
-	err = s.Serve(lis)
-	return err
+
+	return NewServer(b, hostNode, mempool).Serve(lis)
 }
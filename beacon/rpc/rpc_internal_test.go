@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// expectedDuties gets the duties of every validator during an epoch from a state that has
+// processed the epoch transition.
+func expectedDuties(t *testing.T, state *primitives.State, epoch uint64, c *config.Config) map[uint32]*pb.ValidatorDuties {
+	duties := make(map[uint32]*pb.ValidatorDuties)
+	for v := range state.ValidatorRegistry {
+		duties[uint32(v)] = &pb.ValidatorDuties{
+			Validator:      uint32(v),
+			ProposerSlots:  []uint64{},
+			AttesterDuties: []*pb.AttesterDuty{},
+		}
+	}
+
+	for slot := epoch * c.EpochLength; slot < (epoch+1)*c.EpochLength; slot++ {
+		proposer, err := state.GetBeaconProposerIndex(slot, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		duties[proposer].ProposerSlots = append(duties[proposer].ProposerSlots, slot+1)
+
+		committees, err := state.GetShardCommitteesAtSlot(slot, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, committee := range committees {
+			for i, v := range committee.Committee {
+				duties[v].AttesterDuties = append(duties[v].AttesterDuties, &pb.AttesterDuty{
+					Slot:           slot + 1,
+					Shard:          committee.Shard,
+					CommitteeIndex: uint64(i),
+					CommitteeSize:  uint64(len(committee.Committee)),
+				})
+			}
+		}
+	}
+
+	return duties
+}
+
+func TestGetValidatorDuties(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the tip is the last slot before the epoch boundary, so the duties of the next epoch
+	// aren't in the head state
+	for b.View.Chain.Tip().Slot < c.EpochLength-1 {
+		state, err := b.GetUpdatedState(b.View.Chain.Tip().Slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := state.GetBeaconProposerIndex(b.View.Chain.Tip().Slot, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &server{chain: b}
+
+	validators := make([]uint32, len(b.GetState().ValidatorRegistry))
+	for i := range validators {
+		validators[i] = uint32(i)
+	}
+
+	for epoch := uint64(0); epoch < 2; epoch++ {
+		// duties for epoch 0 come from the head state and duties for epoch 1 come from the
+		// state after the epoch transition
+		state := b.GetState()
+		if epoch > 0 {
+			updatedState, err := b.GetUpdatedState(epoch*c.EpochLength + 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state = *updatedState
+		}
+
+		if state.EpochIndex != epoch {
+			t.Fatalf("expected state at epoch %d, got %d", epoch, state.EpochIndex)
+		}
+
+		resp, err := s.GetValidatorDuties(context.Background(), &pb.GetValidatorDutiesRequest{
+			Epoch:      epoch,
+			Validators: validators,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(resp.Duties) != len(validators) {
+			t.Fatalf("expected duties for %d validators, got %d", len(validators), len(resp.Duties))
+		}
+
+		expected := expectedDuties(t, &state, epoch, &c)
+
+		proposals := 0
+		for _, d := range resp.Duties {
+			if !reflect.DeepEqual(d.ProposerSlots, expected[d.Validator].ProposerSlots) {
+				t.Fatalf("expected validator %d to propose at %v in epoch %d, got %v", d.Validator, expected[d.Validator].ProposerSlots, epoch, d.ProposerSlots)
+			}
+
+			if len(d.AttesterDuties) != len(expected[d.Validator].AttesterDuties) {
+				t.Fatalf("expected validator %d to have %d attester duties in epoch %d, got %d", d.Validator, len(expected[d.Validator].AttesterDuties), epoch, len(d.AttesterDuties))
+			}
+
+			for i, duty := range d.AttesterDuties {
+				e := expected[d.Validator].AttesterDuties[i]
+				if duty.Slot != e.Slot || duty.Shard != e.Shard || duty.CommitteeIndex != e.CommitteeIndex || duty.CommitteeSize != e.CommitteeSize {
+					t.Fatalf("expected validator %d to attest %v in epoch %d, got %v", d.Validator, e, epoch, duty)
+				}
+			}
+
+			proposals += len(d.ProposerSlots)
+		}
+
+		if proposals != int(c.EpochLength) {
+			t.Fatalf("expected %d proposals in epoch %d, got %d", c.EpochLength, epoch, proposals)
+		}
+	}
+}
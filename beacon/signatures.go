@@ -0,0 +1,164 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// pendingChainView is a view of a chain extended by blocks that are not in the block index yet.
+type pendingChainView struct {
+	base ChainView
+
+	hashes []chainhash.Hash
+	slots  []uint64
+
+	stateRoot        chainhash.Hash
+	effectiveTipSlot uint64
+}
+
+func (v *pendingChainView) addBlock(hash chainhash.Hash, slot uint64) {
+	v.hashes = append(v.hashes, hash)
+	v.slots = append(v.slots, slot)
+	v.effectiveTipSlot = slot
+}
+
+// SetTipSlot sets the effective tip slot.
+func (v *pendingChainView) SetTipSlot(slot uint64) {
+	v.effectiveTipSlot = slot
+}
+
+// GetHashBySlot gets a hash of a block in a certain slot.
+func (v *pendingChainView) GetHashBySlot(slot uint64) (chainhash.Hash, error) {
+	if slot > v.effectiveTipSlot {
+		return chainhash.Hash{}, errors.New("could not get block past tip")
+	}
+
+	for i := len(v.hashes) - 1; i >= 0; i-- {
+		if v.slots[i] <= slot {
+			return v.hashes[i], nil
+		}
+	}
+
+	return v.base.GetHashBySlot(slot)
+}
+
+// Tip gets the last pending block or the tip of the base view.
+func (v *pendingChainView) Tip() (chainhash.Hash, error) {
+	if len(v.hashes) > 0 {
+		return v.hashes[len(v.hashes)-1], nil
+	}
+
+	return v.base.Tip()
+}
+
+// GetLastStateRoot gets the state root expected by the block being processed. State roots
+// aren't checked here because they're checked when the block is actually processed.
+func (v *pendingChainView) GetLastStateRoot() (chainhash.Hash, error) {
+	return v.stateRoot, nil
+}
+
+var _ primitives.BlockView = (*pendingChainView)(nil)
+
+// VerifyBlockSignatures verifies the signatures in a sequence of blocks that extend a block
+// in the block index using a single batch verification. If the batch is invalid, each block
+// is checked separately to find the block with the invalid signature.
+func (b *Blockchain) VerifyBlockSignatures(blocks []primitives.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	parentRoot := blocks[0].BlockHeader.ParentRoot
+
+	view, err := b.GetSubView(parentRoot)
+	if err != nil {
+		return err
+	}
+
+	state, err := b.stateManager.RegenerateStateForHash(parentRoot)
+	if err != nil {
+		return err
+	}
+
+	pendingView := &pendingChainView{
+		base:             view,
+		effectiveTipSlot: view.effectiveTipSlot,
+	}
+
+	blockSets := make([]*bls.SignatureSet, len(blocks))
+	batch := bls.NewSignatureSet()
+
+	for i := range blocks {
+		block := &blocks[i]
+
+		if i > 0 && !block.BlockHeader.ParentRoot.IsEqual(&pendingView.hashes[i-1]) {
+			return fmt.Errorf("block at slot %d does not extend the previous block", block.BlockHeader.SlotNumber)
+		}
+
+		_, err := state.ProcessSlots(block.BlockHeader.SlotNumber, pendingView, b.config)
+		if err != nil {
+			return err
+		}
+
+		blockSets[i], err = state.GetBlockSignatureSet(block, b.config)
+		if err != nil {
+			return err
+		}
+		batch.Merge(blockSets[i])
+
+		pendingView.stateRoot = block.BlockHeader.StateRoot
+		err = state.ProcessBlock(block, b.config, pendingView, false)
+		if err != nil {
+			return err
+		}
+
+		blockHash, err := ssz.HashTreeRoot(block)
+		if err != nil {
+			return err
+		}
+		pendingView.addBlock(blockHash, block.BlockHeader.SlotNumber)
+	}
+
+	if batch.Verify() {
+		return nil
+	}
+
+	for i, set := range blockSets {
+		if !set.Verify() {
+			return fmt.Errorf("block %s at slot %d has an invalid signature", pendingView.hashes[i], blocks[i].BlockHeader.SlotNumber)
+		}
+	}
+
+	return errors.New("block signatures are invalid")
+}
+
+// VerifyProposerSignature verifies the proposer and randao signatures of a block that extends a
+// block in the block index without processing the block.
+func (b *Blockchain) VerifyProposerSignature(block *primitives.Block) error {
+	parentRoot := block.BlockHeader.ParentRoot
+
+	view, err := b.GetSubView(parentRoot)
+	if err != nil {
+		return err
+	}
+
+	_, state, err := b.stateManager.GetStateForHashAtSlot(parentRoot, block.BlockHeader.SlotNumber, &view, b.config)
+	if err != nil {
+		return err
+	}
+
+	set, err := state.GetProposerSignatureSet(block, b.config)
+	if err != nil {
+		return err
+	}
+
+	if !set.Verify() {
+		return errors.New("block has an invalid proposer signature")
+	}
+
+	return nil
+}
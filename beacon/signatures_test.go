@@ -0,0 +1,129 @@
+package beacon_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func TestVerifyBlockSignatures(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &c, validators, true, b.GetState().GenesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+
+	err = b2.VerifyBlockSignatures(blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sign the last block with the wrong signature. Changing any earlier block would break the
+	// chain of parent roots.
+	invalidBlock := &blocks[len(blocks)-1]
+	invalidBlock.BlockHeader.Signature = invalidBlock.BlockHeader.RandaoReveal
+
+	invalidHash, err := ssz.HashTreeRoot(invalidBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b2.VerifyBlockSignatures(blocks)
+	if err == nil {
+		t.Fatal("expected batch with an invalid signature to fail verification")
+	}
+
+	if !strings.Contains(err.Error(), chainhash.Hash(invalidHash).String()) {
+		t.Fatalf("expected error to name the block with the invalid signature (got error: %v)", err)
+	}
+}
+
+// epochChunks splits blocks into chunks of one epoch like blocks received while syncing.
+func epochChunks(blocks []primitives.Block, c *config.Config) [][]primitives.Block {
+	var chunks [][]primitives.Block
+	start := 0
+	for i := range blocks {
+		if i > start && blocks[i].BlockHeader.SlotNumber/c.EpochLength != blocks[start].BlockHeader.SlotNumber/c.EpochLength {
+			chunks = append(chunks, blocks[start:i])
+			start = i
+		}
+	}
+	return append(chunks, blocks[start:])
+}
+
+func benchmarkSync(b *testing.B, batched bool) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	chain, keys := setupFinalizedChain(b, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	genesisTime := chain.GetState().GenesisTime
+	chunks := epochChunks(blocksAfter(b, chain, chain.View.Chain.Genesis()), &c)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		syncing, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &c, validators, true, genesisTime)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		for _, chunk := range chunks {
+			if batched {
+				err := syncing.VerifyBlockSignatures(chunk)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			for j := range chunk {
+				_, _, err := syncing.ProcessBlock(&chunk[j], false, !batched)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSyncIndividualVerification(b *testing.B) {
+	benchmarkSync(b, false)
+}
+
+func BenchmarkSyncBatchedVerification(b *testing.B) {
+	benchmarkSync(b, true)
+}
@@ -0,0 +1,115 @@
+package beacon_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/sirupsen/logrus"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisTime := b.GetState().GenesisTime
+
+	exportDir, err := ioutil.TempDir("", "synapse-snapshot-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(exportDir)
+
+	exportDB := db.NewBadgerDB(exportDir)
+	defer exportDB.Close()
+
+	err = exportDB.SetGenesisTime(genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(exportDB, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, block := range blocksAfter(t, b, b.View.Chain.Genesis()) {
+		_, _, err := b2.ProcessBlock(&block, false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	err = db.ExportSnapshot(exportDB, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := buf.Bytes()
+
+	importDir, err := ioutil.TempDir("", "synapse-snapshot-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(importDir)
+
+	importDB := db.NewBadgerDB(importDir)
+	defer importDB.Close()
+
+	err = db.ImportSnapshot(importDB, bytes.NewReader(snapshot))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b3, err := beacon.NewBlockchainWithInitialValidators(importDB, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b3.View.Chain.Tip().Hash != b.View.Chain.Tip().Hash {
+		t.Fatal("expected imported snapshot to have the same tip")
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	finalizedNode3, _ := b3.View.GetFinalizedHead()
+	if finalizedNode3.Hash != finalizedNode.Hash {
+		t.Fatal("expected imported snapshot to have the same finalized head")
+	}
+
+	err = db.ImportSnapshot(importDB, bytes.NewReader(snapshot))
+	if err == nil {
+		t.Fatal("expected importing into a database with a blockchain to fail")
+	}
+
+	// bump the version after the magic bytes
+	newerSnapshot := append([]byte{}, snapshot...)
+	binary.BigEndian.PutUint32(newerSnapshot[len("synapsesnapshot"):], db.SnapshotVersion+1)
+
+	err = db.ImportSnapshot(db.NewInMemoryDB(), bytes.NewReader(newerSnapshot))
+	if err == nil {
+		t.Fatal("expected importing a snapshot with an unsupported version to fail")
+	}
+
+	// the last block node record is cut off
+	err = db.ImportSnapshot(db.NewInMemoryDB(), bytes.NewReader(snapshot[:len(snapshot)-1]))
+	if err == nil {
+		t.Fatal("expected importing a truncated snapshot to fail")
+	}
+}
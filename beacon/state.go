@@ -8,7 +8,6 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 
 	"github.com/phoreproject/synapse/primitives"
-	"github.com/phoreproject/synapse/utils"
 	logger "github.com/sirupsen/logrus"
 )
 
@@ -36,7 +35,7 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 	validationStart := time.Now()
 
 	// VALIDATE BLOCK HERE
-	if checkTime && (block.BlockHeader.SlotNumber*uint64(b.config.SlotDuration)+genesisTime > uint64(utils.Now().Unix()) || block.BlockHeader.SlotNumber == 0) {
+	if checkTime && (block.BlockHeader.SlotNumber*uint64(b.config.SlotDuration)+genesisTime > uint64(b.clock.Now().Unix()) || block.BlockHeader.SlotNumber == 0) {
 		return nil, nil, errors.New("block slot too soon")
 	}
 
@@ -57,6 +56,14 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 		return nil, nil, nil
 	}
 
+	// blocks branching off before the finalized block can never be part of the main chain and
+	// would never be pruned
+	finalizedHead, _ := b.View.GetFinalizedHead()
+	parentNode := b.View.Index.GetBlockNodeByHash(block.BlockHeader.ParentRoot)
+	if finalizedHead != nil && parentNode.GetAncestorAtSlot(finalizedHead.Slot) != finalizedHead {
+		return nil, nil, errors.New("block does not build on the finalized block")
+	}
+
 	validationTime := time.Since(validationStart)
 
 	blockHashStr := fmt.Sprintf("%x", blockHash)
@@ -115,7 +122,7 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 
 	blockStorageTime := time.Since(blockStorageStart)
 
-	stateRoot, err := ssz.HashTreeRoot(newState)
+	stateRoot, err := newState.HashTreeRoot()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,6 +133,7 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 	if err != nil {
 		return nil, nil, err
 	}
+	b.forkChoice.AddBlock(node)
 
 	databaseTipUpdateStart := time.Now()
 
@@ -142,6 +150,15 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 			return err
 		}
 
+		// store a checkpoint for the first block of each epoch so that states can be
+		// regenerated by replaying at most one epoch of blocks
+		if isFirstBlockOfEpoch(node, b.config.EpochLength) {
+			err = b.DB.SetStateCheckpoint(node.Hash, *newState, transaction)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 
@@ -163,6 +180,8 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 		if err != nil {
 			return nil, nil, err
 		}
+
+		b.forkChoice.ProcessAttestation(participants, a.Data.BeaconBlockHash, a.Data.Slot)
 	}
 
 	attestationUpdateEnd := time.Since(attestationUpdateStart)
@@ -180,15 +199,20 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 
 	connectBlockSignalStart := time.Now()
 
-	for _, n := range b.Notifees {
-		go n.ConnectBlock(block)
-	}
+	b.notify(func(n BlockchainNotifee) {
+		n.ConnectBlock(block)
+	})
 
 	connectBlockSignalTime := time.Since(connectBlockSignalStart)
 
 	finalizedStateUpdateStart := time.Now()
 
 	finalizedNode := node.GetAncestorAtSlot(newState.FinalizedEpoch * b.config.EpochLength)
+	if finalizedNode == nil {
+		// the finalized block is before the checkpoint the chain was started from, so use
+		// the checkpoint instead
+		finalizedNode = b.View.Chain.Genesis()
+	}
 	if finalizedNode == nil {
 		return nil, nil, errors.New("could not find finalized node in block index")
 	}
@@ -202,10 +226,42 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 			"finalizedEpoch": newState.FinalizedEpoch,
 		}).Info("finalized epoch")
 
-		err := b.DB.SetFinalizedState(*finalizedState)
+		previousFinalizedNode := b.View.finalizedHead.BlockNode
+
+		err := b.DB.TransactionalUpdate(func(transaction interface{}) error {
+			err := b.DB.SetFinalizedState(*finalizedState, transaction)
+			if err != nil {
+				return err
+			}
+
+			// keep the finalized state around so historical states can be regenerated from it
+			err = b.DB.SetStateCheckpoint(finalizedNode.Hash, *finalizedState, transaction)
+			if err != nil {
+				return err
+			}
+
+			// the previous finalized checkpoint is no longer needed unless it's also an epoch
+			// checkpoint or the first block in the block index
+			if previousFinalizedNode != nil && previousFinalizedNode != finalizedNode &&
+				previousFinalizedNode.Parent != nil && !isFirstBlockOfEpoch(previousFinalizedNode, b.config.EpochLength) {
+				return b.DB.DeleteStateCheckpoint(previousFinalizedNode.Hash, transaction)
+			}
+
+			return nil
+		})
 		if err != nil {
 			return nil, nil, err
 		}
+
+		err = b.stateManager.PruneStateCheckpoints(finalizedNode)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		finalizedEpoch := newState.FinalizedEpoch
+		b.notify(func(n BlockchainNotifee) {
+			n.FinalizeEpoch(finalizedNode, finalizedEpoch)
+		})
 	}
 
 	finalizedNodeAndState := blockNodeAndState{finalizedNode, *finalizedState}
@@ -216,7 +272,15 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 		return nil, nil, err
 	}
 
+	err = b.pruneOrphanedForks(finalizedNode)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	justifiedNode := node.GetAncestorAtSlot(newState.JustifiedEpoch * b.config.EpochLength)
+	if justifiedNode == nil {
+		justifiedNode = b.View.Chain.Genesis()
+	}
 	if justifiedNode == nil {
 		return nil, nil, errors.New("could not find justified node in block index")
 	}
@@ -239,6 +303,11 @@ func (b *Blockchain) ProcessBlock(block *primitives.Block, checkTime bool, verif
 		if err != nil {
 			return nil, nil, err
 		}
+
+		justifiedEpoch := newState.JustifiedEpoch
+		b.notify(func(n BlockchainNotifee) {
+			n.JustifyEpoch(justifiedNode, justifiedEpoch)
+		})
 	}
 
 	err = b.DB.SetJustifiedHead(justifiedNode.Hash)
@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/db"
 	"github.com/phoreproject/synapse/chainhash"
 	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/utils"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/sirupsen/logrus"
 
@@ -219,3 +222,122 @@ func BenchmarkBlockTransition(t *testing.B) {
 		}
 	}
 }
+
+func TestRegenerateFinalizedState(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := uint64(0); i < c.EpochLength*5+1; i++ {
+		s, err := b.GetUpdatedState(i + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := s.GetBeaconProposerIndex(i, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	if finalizedNode.Slot <= 3 {
+		t.Fatalf("expected chain to finalize past slot 3 (finalized slot: %d)", finalizedNode.Slot)
+	}
+
+	oldNode, err := b.View.Chain.GetBlockBySlot(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := b.GetStateForBlock(oldNode.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateRoot, err := ssz.HashTreeRoot(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !oldNode.StateRoot.IsEqual((*chainhash.Hash)(&stateRoot)) {
+		t.Fatalf("regenerated state root does not match (expected: %s, got: %s)", oldNode.StateRoot, chainhash.Hash(stateRoot))
+	}
+
+	stateAtSlot, err := b.GetStateAtSlot(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stateAtSlot.Slot != 3 {
+		t.Fatalf("expected state at slot 3, got slot %d", stateAtSlot.Slot)
+	}
+
+	_, err = b.GetStateAtSlot(b.View.Chain.Tip().Slot + 1000000)
+	if err == nil {
+		t.Fatal("expected state far past the current slot to be rejected")
+	}
+}
+
+func TestProcessBlockWaitsForSlot(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+
+	genesisTime := time.Unix(1560000000, 0)
+
+	b, keys, err := util.SetupBlockchainWithTime(c.ShardCount*c.TargetCommitteeSize*2+5, &c, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mineBlocks(t, b, keys, &c, 1)
+
+	block, err := b.GetBlockByHash(b.View.Chain.Tip().Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), &c, validators, true, uint64(genesisTime.Unix()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := utils.NewManualClock(genesisTime)
+	b2.SetClock(clock)
+
+	_, _, err = b2.ProcessBlock(block, true, true)
+	if err == nil {
+		t.Fatal("expected block to be rejected before its slot starts")
+	}
+
+	clock.Advance(time.Duration(c.SlotDuration) * time.Second)
+
+	if b2.GetCurrentSlot() != 1 {
+		t.Fatalf("expected current slot to be 1, got %d", b2.GetCurrentSlot())
+	}
+
+	_, _, err = b2.ProcessBlock(block, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,264 @@
+package beacon_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func mineBlocks(t *testing.T, b *beacon.Blockchain, keys validator.Keystore, c *config.Config, n uint64) {
+	for i := uint64(0); i < n; i++ {
+		slot := b.View.Chain.Tip().Slot
+		s, err := b.GetUpdatedState(slot + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proposerIndex, err := s.GetBeaconProposerIndex(slot, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = util.MineBlockWithFullAttestations(b, keys, proposerIndex)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// copyDir copies the files in a database directory so the copy can be opened while the
+// original is still open, like after a crash.
+func copyDir(t *testing.T, from string, to string) {
+	files, err := ioutil.ReadDir(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(filepath.Join(from, f.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(filepath.Join(to, f.Name()), contents, f.Mode())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRestartMidEpoch(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys := setupFinalizedChain(t, &c)
+
+	validators, err := util.InitialValidators(c.ShardCount*c.TargetCommitteeSize*2+5, keys, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisTime := b.GetState().GenesisTime
+
+	dir, err := ioutil.TempDir("", "synapse-state-checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	database := db.NewBadgerDB(dir)
+
+	err = database.SetGenesisTime(genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := beacon.NewBlockchainWithInitialValidators(database, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// stop a few blocks into an epoch
+	blocks := blocksAfter(t, b, b.View.Chain.Genesis())
+	for _, block := range blocks {
+		if block.BlockHeader.SlotNumber%c.EpochLength == c.EpochLength/2 {
+			break
+		}
+		_, _, err := b2.ProcessBlock(&block, false, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tip := b2.View.Chain.Tip()
+	if tip.Slot%c.EpochLength == 0 {
+		t.Fatal("expected chain to stop in the middle of an epoch")
+	}
+
+	for node := tip; node != nil; node = node.Parent {
+		if node.Parent != nil && node.Parent.Slot/c.EpochLength == node.Slot/c.EpochLength {
+			continue
+		}
+		if _, err := database.GetStateCheckpoint(node.Hash); err != nil {
+			t.Fatalf("expected state checkpoint for first block of epoch at slot %d", node.Slot)
+		}
+	}
+
+	tipState, err := b2.GetStateForBlock(tip.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crashDir, err := ioutil.TempDir("", "synapse-state-checkpoints-crash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(crashDir)
+
+	// crash in the middle of a transaction by copying the database without closing it
+	uncommittedHash := chainhash.HashH([]byte("uncommitted"))
+	errCrash := errors.New("crash")
+	err = database.TransactionalUpdate(func(transaction interface{}) error {
+		err := database.SetStateCheckpoint(uncommittedHash, *tipState, transaction)
+		if err != nil {
+			return err
+		}
+
+		copyDir(t, dir, crashDir)
+
+		return errCrash
+	})
+	if err != errCrash {
+		t.Fatal("expected transaction to be dropped")
+	}
+	defer database.Close()
+
+	database = db.NewBadgerDB(crashDir)
+	defer database.Close()
+
+	if _, err := database.GetStateCheckpoint(uncommittedHash); err == nil {
+		t.Fatal("expected uncommitted state checkpoint to be dropped")
+	}
+
+	b3, err := beacon.NewBlockchainWithInitialValidators(database, &c, validators, true, genesisTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b3.View.Chain.Tip().Hash != tip.Hash {
+		t.Fatal("expected restarted chain to have the same tip")
+	}
+
+	if replayed := b3.GetReplayedBlockCount(); replayed > c.EpochLength {
+		t.Fatalf("expected restart to replay at most one epoch of blocks, replayed %d", replayed)
+	}
+
+	restartedTipState, err := b3.GetStateForBlock(tip.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tipStateRoot, err := ssz.HashTreeRoot(tipState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restartedTipStateRoot, err := ssz.HashTreeRoot(restartedTipState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tipStateRoot != restartedTipStateRoot {
+		t.Fatal("expected restarted chain to regenerate the same tip state")
+	}
+
+	// the restarted chain should keep finalizing
+	finalizedEpoch := b3.GetState().FinalizedEpoch
+
+	mineBlocks(t, b3, keys, &c, c.EpochLength*3)
+
+	if b3.GetState().FinalizedEpoch <= finalizedEpoch {
+		t.Fatal("expected restarted chain to keep finalizing")
+	}
+}
+
+func TestStateCheckpointRetention(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	b, keys, err := util.SetupBlockchain(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.SetStateCheckpointRetention(1)
+
+	mineBlocks(t, b, keys, &c, c.EpochLength*6+1)
+
+	finalizedNode, _ := b.View.GetFinalizedHead()
+	if finalizedNode.Slot < c.EpochLength*3 {
+		t.Fatal("expected chain to finalize")
+	}
+
+	pruneBefore := finalizedNode.Slot - c.EpochLength
+
+	// the prune slot is stored so pruning doesn't start over after a restart
+	pruneSlot, err := b.DB.GetStateCheckpointPruneSlot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruneSlot != pruneBefore {
+		t.Fatalf("expected stored prune slot %d, got %d", pruneBefore, pruneSlot)
+	}
+
+	for node := b.View.Chain.Tip(); node.Parent != nil; node = node.Parent {
+		if node.Parent.Slot/c.EpochLength == node.Slot/c.EpochLength {
+			continue
+		}
+
+		_, err := b.DB.GetStateCheckpoint(node.Hash)
+		if node.Slot < pruneBefore && err == nil {
+			t.Fatalf("expected state checkpoint at slot %d to be pruned", node.Slot)
+		}
+		if node.Slot >= pruneBefore && err != nil {
+			t.Fatalf("expected state checkpoint at slot %d to be kept", node.Slot)
+		}
+	}
+
+	if _, err := b.DB.GetStateCheckpoint(b.View.Chain.Genesis().Hash); err != nil {
+		t.Fatal("expected genesis state checkpoint to be kept")
+	}
+
+	// pruned states can still be regenerated
+	node, err := b.View.Chain.GetBlockBySlot(c.EpochLength + 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.GetStateForBlock(node.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
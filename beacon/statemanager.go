@@ -1,8 +1,10 @@
 package beacon
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/phoreproject/synapse/beacon/db"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/phoreproject/synapse/chainhash"
 	"github.com/phoreproject/synapse/primitives"
 	"github.com/prysmaticlabs/go-ssz"
+	logger "github.com/sirupsen/logrus"
 )
 
 type stateDerivedFromBlock struct {
@@ -57,6 +60,12 @@ func (s *stateDerivedFromBlock) deriveState(slot uint64, view primitives.BlockVi
 
 	view.SetTipSlot(s.lastSlot)
 
+	// the state after processing the block shouldn't change when deriving later states
+	if s.lastSlotState == s.firstSlotState {
+		stateCopy := s.firstSlotState.Copy()
+		s.lastSlotState = &stateCopy
+	}
+
 	receipts, err := s.lastSlotState.ProcessSlots(slot, view, c)
 	if err != nil {
 		return nil, nil, err
@@ -81,6 +90,13 @@ type StateManager struct {
 	stateMapLock *sync.RWMutex
 	stateLock    *sync.Mutex
 	genesisTime  uint64
+
+	// checkpointRetention is the number of epochs before the finalized epoch to keep state
+	// checkpoints for. If it is 0, state checkpoints are never deleted.
+	checkpointRetention     uint64
+	checkpointsPrunedBefore uint64
+
+	replayedBlocks uint64
 }
 
 // NewStateManager creates a new state manager.
@@ -94,6 +110,12 @@ func NewStateManager(c *config.Config, genesisTime uint64, blockchain *Blockchai
 		blockchain:   blockchain,
 		db:           db,
 	}
+
+	// the prune slot isn't stored until checkpoints are pruned for the first time
+	if pruneSlot, err := db.GetStateCheckpointPruneSlot(); err == nil {
+		s.checkpointsPrunedBefore = pruneSlot
+	}
+
 	return s, nil
 }
 
@@ -102,8 +124,28 @@ func (sm *StateManager) GetGenesisTime() uint64 {
 	return sm.genesisTime
 }
 
-// GetStateForHash gets the state for a certain block Hash.
+// GetStateForHash gets the state for a certain block Hash. If the state isn't in the state
+// map, it is regenerated from the closest state checkpoint in the database by replaying
+// blocks, which can be slow. Regenerated states before the finalized block aren't added to the
+// state map, so they're regenerated each time they're requested.
 func (sm *StateManager) GetStateForHash(blockHash chainhash.Hash) (*primitives.State, bool) {
+	if state, found := sm.getStateFromMap(blockHash); found {
+		return state, true
+	}
+
+	logger.WithField("hash", blockHash.String()).Debug("state not in state map, regenerating from database")
+
+	state, err := sm.loadState(blockHash)
+	if err != nil {
+		logger.WithField("hash", blockHash.String()).WithError(err).Debug("could not regenerate state")
+		return nil, false
+	}
+
+	return state, true
+}
+
+// getStateFromMap gets the state for a certain block hash if it is in the state map.
+func (sm *StateManager) getStateFromMap(blockHash chainhash.Hash) (*primitives.State, bool) {
 	sm.stateMapLock.RLock()
 	derivedState, found := sm.stateMap[blockHash]
 	sm.stateMapLock.RUnlock()
@@ -122,12 +164,54 @@ func (sm *StateManager) GetStateForHashAtSlot(blockHash chainhash.Hash, slot uin
 	derivedState, found := sm.stateMap[blockHash]
 	sm.stateMapLock.RUnlock()
 	if !found {
-		return nil, nil, fmt.Errorf("could not find state for block %s", blockHash)
+		state, err := sm.loadState(blockHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not find state for block %s", blockHash)
+		}
+
+		sm.stateMapLock.RLock()
+		derivedState, found = sm.stateMap[blockHash]
+		sm.stateMapLock.RUnlock()
+
+		// historical states aren't kept in the state map
+		if !found {
+			derivedState = newStateDerivedFromBlock(state)
+		}
 	}
 
 	return derivedState.deriveState(slot, view, c)
 }
 
+// loadState regenerates the state after processing a block that isn't in the state map. If
+// the block is not before the finalized block, the state is added to the state map.
+func (sm *StateManager) loadState(blockHash chainhash.Hash) (*primitives.State, error) {
+	node := sm.blockchain.View.Index.GetBlockNodeByHash(blockHash)
+	if node == nil {
+		return nil, fmt.Errorf("could not find block %s in block index", blockHash)
+	}
+
+	state, err := sm.RegenerateStateForHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedNode, _ := sm.blockchain.View.GetFinalizedHead()
+	if finalizedNode != nil && node.Slot < finalizedNode.Slot {
+		return state, nil
+	}
+
+	sm.stateMapLock.Lock()
+	defer sm.stateMapLock.Unlock()
+
+	// another caller may have loaded the state in the meantime
+	if derivedState, found := sm.stateMap[blockHash]; found {
+		return derivedState.firstSlotState, nil
+	}
+
+	sm.stateMap[blockHash] = newStateDerivedFromBlock(state)
+	return state, nil
+}
+
 // SetBlockState sets the state for a certain block. This SHOULD ONLY
 // BE USED FOR THE GENESIS BLOCK!
 func (sm *StateManager) SetBlockState(blockHash chainhash.Hash, state *primitives.State) error {
@@ -173,6 +257,66 @@ func (sm *StateManager) AddBlockToStateMap(block *primitives.Block, verifySignat
 	return receipts, &newState, nil
 }
 
+// SetCheckpointRetention sets the number of epochs before the finalized epoch to keep state
+// checkpoints for. If it is 0, state checkpoints are never deleted.
+func (sm *StateManager) SetCheckpointRetention(epochs uint64) {
+	sm.stateLock.Lock()
+	defer sm.stateLock.Unlock()
+	sm.checkpointRetention = epochs
+}
+
+// isFirstBlockOfEpoch checks if a block is the first block of its epoch. A state checkpoint
+// is stored for each of these blocks when they're processed.
+func isFirstBlockOfEpoch(node *BlockNode, epochLength uint64) bool {
+	return node.Parent == nil || node.Parent.Slot/epochLength != node.Slot/epochLength
+}
+
+// PruneStateCheckpoints deletes state checkpoints that are more than the checkpoint retention
+// before the finalized block. The checkpoint of the first block in the block index is never
+// deleted.
+func (sm *StateManager) PruneStateCheckpoints(finalizedNode *BlockNode) error {
+	sm.stateLock.Lock()
+	defer sm.stateLock.Unlock()
+
+	retentionSlots := sm.checkpointRetention * sm.config.EpochLength
+	if sm.checkpointRetention == 0 || finalizedNode.Slot < retentionSlots {
+		return nil
+	}
+
+	pruneBefore := finalizedNode.Slot - retentionSlots
+	if pruneBefore <= sm.checkpointsPrunedBefore {
+		return nil
+	}
+
+	// checkpoints are only stored for the first block of each epoch, so those are the only
+	// ones to delete. The finalized checkpoint is never before pruneBefore.
+	err := sm.db.TransactionalUpdate(func(transaction interface{}) error {
+		for node := finalizedNode; node.Parent != nil && node.Slot >= sm.checkpointsPrunedBefore; node = node.Parent {
+			if node.Slot < pruneBefore && isFirstBlockOfEpoch(node, sm.config.EpochLength) {
+				err := sm.db.DeleteStateCheckpoint(node.Hash, transaction)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// store how far we pruned so we don't walk back over the same blocks after a restart
+		return sm.db.SetStateCheckpointPruneSlot(pruneBefore, transaction)
+	})
+	if err != nil {
+		return err
+	}
+
+	sm.checkpointsPrunedBefore = pruneBefore
+
+	return nil
+}
+
+// GetReplayedBlockCount gets the number of blocks replayed to regenerate states.
+func (sm *StateManager) GetReplayedBlockCount() uint64 {
+	return atomic.LoadUint64(&sm.replayedBlocks)
+}
+
 // DeleteStateBeforeFinalizedSlot deletes any states before the current finalized slot.
 func (sm *StateManager) DeleteStateBeforeFinalizedSlot(finalizedSlot uint64) error {
 	sm.stateMapLock.Lock()
@@ -186,3 +330,72 @@ func (sm *StateManager) DeleteStateBeforeFinalizedSlot(finalizedSlot uint64) err
 	}
 	return nil
 }
+
+// DeleteStateForHash deletes the state after processing a certain block.
+func (sm *StateManager) DeleteStateForHash(blockHash chainhash.Hash) {
+	sm.stateMapLock.Lock()
+	defer sm.stateMapLock.Unlock()
+	delete(sm.stateMap, blockHash)
+}
+
+// RegenerateStateForHash gets the state after processing a certain block. If the state is no
+// longer in the state map, it is regenerated by replaying blocks on top of the closest state
+// checkpoint stored in the database.
+func (sm *StateManager) RegenerateStateForHash(blockHash chainhash.Hash) (*primitives.State, error) {
+	if state, found := sm.getStateFromMap(blockHash); found {
+		stateCopy := state.Copy()
+		return &stateCopy, nil
+	}
+
+	node := sm.blockchain.View.Index.GetBlockNodeByHash(blockHash)
+	if node == nil {
+		return nil, fmt.Errorf("could not find block %s in block index", blockHash)
+	}
+
+	// walk back until we find a state to start from, keeping track of the blocks we'll need to replay
+	var toReplay []*BlockNode
+	var startState *primitives.State
+	for current := node; current != nil; current = current.Parent {
+		if state, found := sm.getStateFromMap(current.Hash); found {
+			stateCopy := state.Copy()
+			startState = &stateCopy
+			break
+		}
+
+		if state, err := sm.db.GetStateCheckpoint(current.Hash); err == nil {
+			startState = state
+			break
+		}
+
+		toReplay = append(toReplay, current)
+	}
+
+	if startState == nil {
+		return nil, errors.New("could not find state checkpoint to regenerate state from")
+	}
+
+	for i := len(toReplay) - 1; i >= 0; i-- {
+		blockNode := toReplay[i]
+
+		block, err := sm.db.GetBlockForHash(blockNode.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		view := NewChainView(blockNode.Parent)
+
+		_, err = startState.ProcessSlots(block.BlockHeader.SlotNumber, &view, sm.config)
+		if err != nil {
+			return nil, err
+		}
+
+		err = startState.ProcessBlock(block, sm.config, &view, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	atomic.AddUint64(&sm.replayedBlocks, uint64(len(toReplay)))
+
+	return startState, nil
+}
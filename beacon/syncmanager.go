@@ -2,6 +2,9 @@ package beacon
 
 import (
 	"bytes"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/phoreproject/synapse/beacon/config"
 	ssz "github.com/prysmaticlabs/go-ssz"
@@ -32,18 +35,55 @@ type SyncManager struct {
 	blockchain      *Blockchain
 	mempool         *Mempool
 	postProcessHook func(*primitives.Block, *primitives.State, []primitives.Receipt)
+	scheduler       *SyncScheduler
+	processLock     *sync.Mutex
+	orphans         *OrphanBlockPool
+	futureBlocks    *FutureBlockQueue
+	gossipValidator *GossipValidator
 }
 
+const (
+	// syncRangeSize is the number of slots requested from a peer at a time.
+	syncRangeSize = 64
+
+	// syncRequestTimeout is how long to wait for a peer to respond to a request for blocks.
+	syncRequestTimeout = 30 * time.Second
+
+	// maxOrphanBlocks is the number of blocks to keep while waiting for their parents.
+	maxOrphanBlocks = 256
+
+	// orphanParentRetry is how long to wait for the missing parent of an orphan block before
+	// requesting it again.
+	orphanParentRetry = 10 * time.Second
+
+	// maxFutureBlocks is the number of blocks to keep while waiting for their slots to start.
+	maxFutureBlocks = 256
+
+	// maxFutureSlots is how many slots after the current slot blocks are kept for.
+	maxFutureSlots = 64
+)
+
 // NewSyncManager creates a new sync manager
 func NewSyncManager(hostNode *p2p.HostNode, blockchain *Blockchain, mempool *Mempool) SyncManager {
 	return SyncManager{
-		hostNode:    hostNode,
-		syncStarted: false,
-		blockchain:  blockchain,
-		mempool:     mempool,
+		hostNode:        hostNode,
+		syncStarted:     false,
+		blockchain:      blockchain,
+		mempool:         mempool,
+		scheduler:       NewSyncScheduler(blockchain.View.Chain.Tip().Slot+1, syncRangeSize, syncRequestTimeout),
+		processLock:     new(sync.Mutex),
+		orphans:         NewOrphanBlockPool(maxOrphanBlocks, orphanParentRetry),
+		futureBlocks:    NewFutureBlockQueue(maxFutureBlocks, maxFutureSlots),
+		gossipValidator: NewGossipValidator(blockchain),
 	}
 }
 
+// GetGossipValidator gets the validator used to check blocks and attestations received over
+// gossip.
+func (s SyncManager) GetGossipValidator() *GossipValidator {
+	return s.gossipValidator
+}
+
 // Connected returns whether the client should be considered connected to the
 // network.
 func (s SyncManager) Connected() bool {
@@ -75,8 +115,20 @@ func (s SyncManager) onMessageGetBlock(peer *p2p.Peer, message proto.Message) er
 	}
 
 	if !bytes.Equal(firstCommonBlock.Hash[:], getBlockMesssage.LocatorHashes[len(getBlockMesssage.LocatorHashes)-1]) {
-		// TODO: ban peer
-		return nil
+		// peers that started from a checkpoint end their locator with the checkpoint instead
+		// of the genesis block, so it must be in our main chain
+		checkpointHash, err := chainhash.NewHash(getBlockMesssage.LocatorHashes[len(getBlockMesssage.LocatorHashes)-1])
+		if err != nil {
+			return err
+		}
+
+		checkpointNode := s.blockchain.View.Index.GetBlockNodeByHash(*checkpointHash)
+		if checkpointNode == nil || !s.blockchain.View.Chain.Contains(checkpointNode) {
+			// TODO: ban peer
+			return nil
+		}
+
+		firstCommonBlock = checkpointNode
 	}
 
 	// find the first block that the peer has in our main chain
@@ -136,6 +188,7 @@ func (s SyncManager) onMessageGetBlock(peer *p2p.Peer, message proto.Message) er
 	blockMessage := &pb.BlockMessage{
 		Blocks:          make([]*pb.Block, len(toSend)),
 		LatestBlockHash: tipHash.Hash[:],
+		RequestID:       getBlockMesssage.RequestID,
 	}
 
 	for i := range toSend {
@@ -147,6 +200,53 @@ func (s SyncManager) onMessageGetBlock(peer *p2p.Peer, message proto.Message) er
 	return nil
 }
 
+func (s SyncManager) onMessageGetBlocksBySlot(peer *p2p.Peer, message proto.Message) error {
+	getBlocksMessage := message.(*pb.GetBlocksBySlotMessage)
+
+	startSlot := getBlocksMessage.StartSlot
+	endSlot := getBlocksMessage.EndSlot
+	if endSlot < startSlot {
+		return fmt.Errorf("invalid slot range %d to %d", startSlot, endSlot)
+	}
+
+	if endSlot-startSlot >= limitBlocksToSend {
+		endSlot = startSlot + limitBlocksToSend - 1
+	}
+
+	toSend := make([]*pb.Block, 0)
+
+	currentBlockNode, err := s.blockchain.View.Chain.GetBlockBySlot(startSlot)
+	if err != nil {
+		// if we started from a checkpoint, we don't have blocks before the checkpoint
+		currentBlockNode = s.blockchain.View.Chain.Genesis()
+	}
+
+	if currentBlockNode.Slot < startSlot {
+		currentBlockNode = s.blockchain.View.Chain.Next(currentBlockNode)
+	}
+
+	for currentBlockNode != nil && currentBlockNode.Slot <= endSlot {
+		currentBlock, err := s.blockchain.DB.GetBlockForHash(currentBlockNode.Hash)
+		if err != nil {
+			return err
+		}
+
+		toSend = append(toSend, currentBlock.ToProto())
+
+		currentBlockNode = s.blockchain.View.Chain.Next(currentBlockNode)
+	}
+
+	tip := s.blockchain.View.Chain.Tip()
+
+	peer.SendMessage(&pb.BlockMessage{
+		Blocks:          toSend,
+		LatestBlockHash: tip.Hash[:],
+		RequestID:       getBlocksMessage.RequestID,
+	})
+
+	return nil
+}
+
 // BlockFilter is a filter for block hashes that returns whether the block hash
 // is in the filter or not.
 type BlockFilter interface {
@@ -208,215 +308,272 @@ func splitIncomingBlocksIntoChunks(blocks []*primitives.Block, c *config.Config,
 }
 
 func (s SyncManager) onMessageBlock(peer *p2p.Peer, message proto.Message) error {
-	// TODO: limits for this and only should receive blocks if requested
-
 	blockMessage := message.(*pb.BlockMessage)
 
+	blocks := make([]*primitives.Block, len(blockMessage.Blocks))
+	for i := range blockMessage.Blocks {
+		b, err := primitives.BlockFromProto(blockMessage.Blocks[i])
+		if err != nil {
+			return err
+		}
+		blocks[i] = b
+	}
+
+	request, err := s.scheduler.CompleteRequest(peer.ID, blockMessage.RequestID, blocks)
+	if err == ErrUnsolicitedBlocks {
+		logger.WithField("peer", peer.ID).Debug("ignoring blocks that were not requested")
+		return nil
+	}
+	if err != nil {
+		logger.WithFields(logger.Fields{
+			"peer":      peer.ID,
+			"startSlot": request.StartSlot,
+			"endSlot":   request.EndSlot,
+		}).Warnf("invalid blocks received from sync: %s", err)
+
+		s.disconnectIfBanned(peer.ID)
+		s.requestBlocks()
+		return nil
+	}
+
 	logger.WithFields(logger.Fields{
-		"from":   blockMessage.Blocks[0].Header.SlotNumber,
-		"to":     blockMessage.Blocks[len(blockMessage.Blocks)-1].Header.SlotNumber,
-		"number": len(blockMessage.Blocks),
+		"peer":   peer.ID,
+		"number": len(blocks),
 	}).Debug("received blocks from sync")
 
-	logger.Debug("checking signatures")
+	if request.IsRange() {
+		if s.processBatches() && s.scheduler.Done() {
+			// get any blocks after the last slot we scheduled
+			s.requestBlocksByLocator(peer, zeroHash[:])
+		}
 
-	peer.ProcessingRequest = true
+		s.requestBlocks()
+		return nil
+	}
 
-	if len(blockMessage.Blocks) == 0 {
-		// TODO: handle error of peer sending no blocks
+	if len(blocks) == 0 {
 		return nil
 	}
 
-	blocks := make([]*primitives.Block, len(blockMessage.Blocks))
-	for i := range blockMessage.Blocks {
-		b, err := primitives.BlockFromProto(blockMessage.Blocks[i])
+	err = s.processBlocks(blocks, peer)
+	if err != nil {
+		return err
+	}
+
+	lastBlockHash, err := ssz.HashTreeRoot(blocks[len(blocks)-1])
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(lastBlockHash[:], blockMessage.LatestBlockHash) && !bytes.Equal(blockMessage.LatestBlockHash, zeroHash[:]) {
+		logger.Infof("continuing sync to block %x", blockMessage.LatestBlockHash)
+
+		// request all blocks up to this block
+		s.requestBlocksByLocator(peer, blockMessage.LatestBlockHash)
+	}
+
+	return nil
+}
+
+// processBatches processes the batches received for range requests in order. If a batch
+// can't be processed, the peer that sent it is penalized and the range is requested again.
+// Batches that don't build on a block we have are held until the slots before them are
+// requested again. This returns true if any batches were processed.
+func (s SyncManager) processBatches() bool {
+	s.processLock.Lock()
+	defer s.processLock.Unlock()
+
+	processed := false
+	for {
+		batch, found := s.scheduler.NextBatch()
+		if !found {
+			return processed
+		}
+
+		if len(batch.Blocks) > 0 && s.blockchain.View.Index.GetBlockNodeByHash(batch.Blocks[0].BlockHeader.ParentRoot) == nil {
+			logger.WithFields(logger.Fields{
+				"peer":      batch.Request.Peer,
+				"startSlot": batch.Request.StartSlot,
+				"endSlot":   batch.Request.EndSlot,
+			}).Warn("blocks from sync do not build on the last processed block")
+
+			for _, p := range s.scheduler.MissingParent(batch) {
+				s.disconnectIfBanned(p)
+			}
+			return processed
+		}
+
+		err := s.processBlocks(batch.Blocks, nil)
 		if err != nil {
-			return err
+			logger.WithFields(logger.Fields{
+				"peer":      batch.Request.Peer,
+				"startSlot": batch.Request.StartSlot,
+				"endSlot":   batch.Request.EndSlot,
+			}).Warnf("could not process blocks from sync: %s", err)
+
+			s.scheduler.RetryBatch(batch)
+			s.disconnectIfBanned(batch.Request.Peer)
+			return processed
 		}
-		blocks[i] = b
+
+		s.scheduler.FinishBatch(batch)
+		processed = true
+	}
+}
+
+// processBlocks processes blocks received from sync in chunks of one epoch. If peer is nil,
+// blocks that don't extend a block we have are rejected instead of requesting the missing
+// blocks from the peer.
+func (s SyncManager) processBlocks(blocks []*primitives.Block, peer *p2p.Peer) error {
+	if len(blocks) == 0 {
+		return nil
 	}
 
-	for _, b := range blocks {
-		err := s.handleReceivedBlock(b, peer, true)
+	epochBlockChunks, err := splitIncomingBlocksIntoChunks(blocks, s.blockchain.config, s.blockchain.View.Index)
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range epochBlockChunks {
+		err := s.processBlockChunk(chunk, peer)
 		if err != nil {
 			return err
 		}
 	}
 
-	//epochBlockChunks, err := splitIncomingBlocksIntoChunks(blocks, s.blockchain.config, s.blockchain.View.Index)
-	//if err != nil {
-	//	return err
-	//}
-	//
-	//for _, chunk := range epochBlockChunks {
-	//	epochState, found := s.blockchain.stateManager.GetStateForHash(chunk[0].BlockHeader.ParentRoot)
-	//	if !found {
-	//		return fmt.Errorf("could not find parent block at slot %d with parent root %s", chunk[0].BlockHeader.SlotNumber, chunk[0].BlockHeader.ParentRoot)
-	//	}
-	//
-	//	tipNode := s.blockchain.View.Index.GetBlockNodeByHash(chunk[0].BlockHeader.ParentRoot)
-	//
-	//	epochStateCopy := epochState.Copy()
-	//
-	//	view := NewChainView(tipNode)
-	//
-	//	_, err := epochStateCopy.ProcessSlots(chunk[0].BlockHeader.SlotNumber, &view, s.blockchain.config)
-	//	if err != nil {
-	//		return err
-	//	}
-	//
-	//	// proposer public keys
-	//	var pubkeys []*bls.PublicKey
-	//
-	//	// attestation committee public keys
-	//	var attestationAggregatedPubkeys []*bls.PublicKey
-	//
-	//	aggregateSig := bls.NewAggregateSignature()
-	//	aggregateRandaoSig := bls.NewAggregateSignature()
-	//	aggregateAttestationSig := bls.NewAggregateSignature()
-	//
-	//	blockHashes := make([][]byte, 0)
-	//	randaoHashes := make([][]byte, 0)
-	//	attestationHashes := make([][]byte, 0)
-	//
-	//	// go through each of the blocks in the past epoch or so
-	//	for _, b := range chunk {
-	//		_, err := epochStateCopy.ProcessSlots(b.BlockHeader.SlotNumber-1, &view, s.blockchain.config)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		proposerIndex, err := epochStateCopy.GetBeaconProposerIndex(b.BlockHeader.SlotNumber-1, s.blockchain.config)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		proposerPub, err := epochStateCopy.ValidatorRegistry[proposerIndex].GetPublicKey()
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		// keep track of all proposer pubkeys for this epoch
-	//		pubkeys = append(pubkeys, proposerPub)
-	//
-	//		blockSig, err := bls.DeserializeSignature(b.BlockHeader.Signature)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		randaoSig, err := bls.DeserializeSignature(b.BlockHeader.RandaoReveal)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		// aggregate both the block sig and the randao sig
-	//		aggregateSig.AggregateSig(blockSig)
-	//		aggregateRandaoSig.AggregateSig(randaoSig)
-	//
-	//		//
-	//		blockWithoutSignature := b.Copy()
-	//		blockWithoutSignature.BlockHeader.Signature = bls.EmptySignature.Serialize()
-	//		blockWithoutSignatureRoot, err := ssz.HashTreeRoot(blockWithoutSignature)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		proposal := primitives.ProposalSignedData{
-	//			Slot:      b.BlockHeader.SlotNumber,
-	//			Shard:     s.blockchain.config.BeaconShardNumber,
-	//			BlockHash: blockWithoutSignatureRoot,
-	//		}
-	//
-	//		proposalRoot, err := ssz.HashTreeRoot(proposal)
-	//		if err != nil {
-	//			return err
-	//		}
-	//
-	//		blockHashes = append(blockHashes, proposalRoot[:])
-	//
-	//		var slotBytes [8]byte
-	//		binary.BigEndian.PutUint64(slotBytes[:], b.BlockHeader.SlotNumber)
-	//		slotBytesHash := chainhash.HashH(slotBytes[:])
-	//
-	//		randaoHashes = append(randaoHashes, slotBytesHash[:])
-	//
-	//		for _, att := range b.BlockBody.Attestations {
-	//			participants, err := epochStateCopy.GetAttestationParticipants(att.Data, att.ParticipationBitfield, s.blockchain.config)
-	//			if err != nil {
-	//				return err
-	//			}
-	//
-	//			dataRoot, err := ssz.HashTreeRoot(primitives.AttestationDataAndCustodyBit{Data: att.Data, PoCBit: false})
-	//			if err != nil {
-	//				return err
-	//			}
-	//
-	//			groupPublicKey := bls.NewAggregatePublicKey()
-	//			for _, p := range participants {
-	//				pub, err := epochStateCopy.ValidatorRegistry[p].GetPublicKey()
-	//				if err != nil {
-	//					return err
-	//				}
-	//				groupPublicKey.AggregatePubKey(pub)
-	//			}
-	//
-	//			aggSig, err := bls.DeserializeSignature(att.AggregateSig)
-	//			if err != nil {
-	//				return err
-	//			}
-	//
-	//			aggregateAttestationSig.AggregateSig(aggSig)
-	//
-	//			attestationHashes = append(attestationHashes, dataRoot[:])
-	//			attestationAggregatedPubkeys = append(attestationAggregatedPubkeys, groupPublicKey)
-	//		}
-	//	}
-	//
-	//	valid := bls.VerifyAggregate(pubkeys, blockHashes, aggregateSig, bls.DomainProposal)
-	//	if !valid {
-	//		return errors.New("blocks did not validate")
-	//	}
-	//
-	//	valid = bls.VerifyAggregate(pubkeys, randaoHashes, aggregateRandaoSig, bls.DomainRandao)
-	//	if !valid {
-	//		return errors.New("block randaos did not validate")
-	//	}
-	//
-	//	if len(attestationAggregatedPubkeys) > 0 {
-	//		valid = bls.VerifyAggregate(attestationAggregatedPubkeys, attestationHashes, aggregateAttestationSig, bls.DomainAttestation)
-	//		if !valid {
-	//			fmt.Printf("chunk: ")
-	//			for _, b := range chunk {
-	//				fmt.Printf("%d ", b.BlockHeader.SlotNumber)
-	//			}
-	//			fmt.Printf("\n")
-	//			return errors.New("block attestations did not validate")
-	//		}
-	//	}
-	//
-	//	for _, b := range chunk {
-	//
-	//	}
-	//}
-
-	lastBlockHash, err := ssz.HashTreeRoot(blockMessage.Blocks[len(blockMessage.Blocks)-1])
+	return nil
+}
+
+// processBlockChunk processes a chunk of blocks received while syncing. If the chunk extends a
+// block we already have, the signatures of all of the blocks are verified in a single batch
+// before processing them. Otherwise, each block is handled separately so the missing parent
+// is requested.
+func (s SyncManager) processBlockChunk(chunk []*primitives.Block, peer *p2p.Peer) error {
+	if s.blockchain.View.Index.GetBlockNodeByHash(chunk[0].BlockHeader.ParentRoot) == nil {
+		if peer == nil {
+			return fmt.Errorf("could not find parent of block at slot %d", chunk[0].BlockHeader.SlotNumber)
+		}
+
+		for _, b := range chunk {
+			err := s.handleReceivedBlock(b, peer, true)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	blocks := make([]primitives.Block, len(chunk))
+	for i := range chunk {
+		blocks[i] = *chunk[i]
+	}
+
+	// if the batch is invalid, this checks each block to find the one with the invalid signature
+	err := s.blockchain.VerifyBlockSignatures(blocks)
 	if err != nil {
 		return err
 	}
 
-	if !bytes.Equal(lastBlockHash[:], blockMessage.LatestBlockHash) && !bytes.Equal(blockMessage.LatestBlockHash, zeroHash[:]) {
-		logger.Infof("continuing sync to block %x", blockMessage.LatestBlockHash)
+	for _, b := range chunk {
+		err := s.handleReceivedBlock(b, peer, false)
+		if err != nil {
+			return err
+		}
+	}
 
-		// request all blocks up to this block
-		peer.SendMessage(&pb.GetBlockMessage{
-			LocatorHashes: s.blockchain.View.Chain.GetChainLocator(),
-			HashStop:      blockMessage.LatestBlockHash,
-		})
+	return nil
+}
+
+// requestBlocks sends requests for the next ranges of slots to sync to every peer that has
+// room for more requests.
+func (s SyncManager) requestBlocks() {
+	now := s.blockchain.clock.Now()
+	currentSlot := s.blockchain.GetCurrentSlot()
+
+	for _, p := range s.hostNode.GetPeerList() {
+		if p.Connecting {
+			continue
+		}
+
+		// peers can't have blocks for slots that haven't started yet
+		peerSlot := p.StartSlot()
+		if peerSlot > currentSlot {
+			peerSlot = currentSlot
+		}
+
+		for {
+			request, ok := s.scheduler.NextRequest(p.ID, peerSlot, now)
+			if !ok {
+				break
+			}
+
+			logger.WithFields(logger.Fields{
+				"peer":      p.ID,
+				"startSlot": request.StartSlot,
+				"endSlot":   request.EndSlot,
+			}).Debug("requesting blocks")
+
+			p.SendMessage(&pb.GetBlocksBySlotMessage{
+				RequestID: request.ID,
+				StartSlot: request.StartSlot,
+				EndSlot:   request.EndSlot,
+			})
+		}
 	}
+}
 
-	peer.ProcessingRequest = false
+// requestBlocksByLocator requests the blocks after the last block we have in common with a
+// peer up to hashStop.
+func (s SyncManager) requestBlocksByLocator(peer *p2p.Peer, hashStop []byte) {
+	peer.SendMessage(&pb.GetBlockMessage{
+		RequestID:     s.scheduler.TrackLocatorRequest(peer.ID, s.blockchain.clock.Now()),
+		LocatorHashes: s.blockchain.View.Chain.GetChainLocator(),
+		HashStop:      hashStop,
+	})
+}
 
-	return nil
+// expireSyncRequests retries requests that peers took too long to respond to until the
+// initial sync is done.
+func (s SyncManager) expireSyncRequests() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, request := range s.scheduler.ExpireRequests(s.blockchain.clock.Now()) {
+			logger.WithFields(logger.Fields{
+				"peer":      request.Peer,
+				"startSlot": request.StartSlot,
+				"endSlot":   request.EndSlot,
+			}).Warn("sync request timed out")
+
+			s.disconnectIfBanned(request.Peer)
+		}
+
+		if s.scheduler.Done() {
+			return
+		}
+
+		s.requestBlocks()
+	}
+}
+
+// disconnectIfBanned disconnects from a peer if it has been banned from syncing.
+func (s SyncManager) disconnectIfBanned(id peer.ID) {
+	if !s.scheduler.IsBanned(id) {
+		return
+	}
+
+	p, found := s.hostNode.FindPeerByID(id)
+	if !found {
+		return
+	}
+
+	logger.WithField("peer", id).Warn("disconnecting from peer that sent invalid blocks")
+
+	err := s.hostNode.DisconnectPeer(p)
+	if err != nil {
+		logger.Error(err)
+	}
 }
 
 // RegisterPostProcessHook registers a hook called after a block has been processed.
@@ -430,42 +587,143 @@ func (s SyncManager) handleReceivedBlock(block *primitives.Block, peerFrom *p2p.
 		return err
 	}
 
-	if s.blockchain.View.Index.GetBlockNodeByHash(block.BlockHeader.ParentRoot) == nil {
-		// if we haven't handshaked with them, give up
-		if peerFrom == nil {
-			return nil
+	var from peer.ID
+	if peerFrom != nil {
+		from = peerFrom.ID
+	}
+
+	parentKnown := s.blockchain.View.Index.GetBlockNodeByHash(block.BlockHeader.ParentRoot) != nil
+
+	currentSlot := s.blockchain.GetCurrentSlot()
+	if block.BlockHeader.SlotNumber > currentSlot {
+		// don't hold on to blocks with invalid signatures until their slot starts
+		if parentKnown && verifySignature {
+			err := s.blockchain.VerifyProposerSignature(block)
+			if err != nil {
+				return err
+			}
 		}
 
-		logger.WithFields(logger.Fields{
-			"hash":       chainhash.Hash(block.BlockHeader.ParentRoot),
-			"slotTrying": block.BlockHeader.SlotNumber,
-		}).Info("requesting parent block")
+		// wait until the slot starts to process the block
+		queued, err := s.futureBlocks.Add(block, from, currentSlot)
+		if err != nil {
+			return err
+		}
 
-		// request all blocks up to this block
-		peerFrom.SendMessage(&pb.GetBlockMessage{
-			LocatorHashes: s.blockchain.View.Chain.GetChainLocator(),
-			HashStop:      blockHash[:],
-		})
+		if !queued {
+			logger.WithFields(logger.Fields{
+				"hash": chainhash.Hash(blockHash),
+				"slot": block.BlockHeader.SlotNumber,
+			}).Debug("dropping block too far in the future")
+		}
 
-	} else {
-		logger.WithField("slot", block.BlockHeader.SlotNumber).Debug("processing")
-		receipts, newState, err := s.blockchain.ProcessBlock(block, true, verifySignature)
+		return nil
+	}
+
+	if !parentKnown {
+		request, requestParent, err := s.orphans.Add(block, from, s.blockchain.clock.Now())
 		if err != nil {
 			return err
 		}
 
-		if s.postProcessHook != nil && newState != nil {
-			s.postProcessHook(block, newState, receipts)
+		// if we already requested the missing blocks recently, wait for them to arrive
+		if requestParent {
+			s.requestMissingBlocks(request)
+		}
+
+		return nil
+	}
+
+	logger.WithField("slot", block.BlockHeader.SlotNumber).Debug("processing")
+	receipts, newState, err := s.blockchain.ProcessBlock(block, true, verifySignature)
+	if err != nil {
+		return err
+	}
+
+	if s.postProcessHook != nil && newState != nil {
+		s.postProcessHook(block, newState, receipts)
+	}
+
+	// process any blocks that were waiting for this block
+	for _, child := range s.orphans.TakeChildren(blockHash) {
+		err := s.handleReceivedBlock(child, peerFrom, true)
+		if err != nil {
+			logger.WithField("slot", child.BlockHeader.SlotNumber).Warnf("could not process orphan block: %s", err)
 		}
 	}
 
 	return nil
 }
 
+// requestMissingBlocks requests the blocks missing before some orphan blocks from the peer that
+// sent the orphans, or any other peer if that peer isn't connected anymore.
+func (s SyncManager) requestMissingBlocks(request ParentRequest) {
+	p := s.hostNode.GetPeerByID(request.Peer)
+	if p == nil {
+		for _, candidate := range s.hostNode.GetPeerList() {
+			if !candidate.Connecting {
+				p = candidate
+				break
+			}
+		}
+	}
+
+	// the request will be retried once it expires
+	if p == nil {
+		return
+	}
+
+	logger.WithFields(logger.Fields{
+		"hash":  request.Missing,
+		"child": request.Child,
+		"peer":  p.ID,
+	}).Info("requesting missing parent block")
+
+	// request all blocks up to the orphan
+	s.requestBlocksByLocator(p, request.Child[:])
+}
+
+// processFutureBlocks processes blocks that were received before their slot started at the
+// start of each slot, and requests the parents of orphan blocks again if they haven't arrived.
+func (s SyncManager) processFutureBlocks() {
+	slotDuration := uint64(s.blockchain.config.SlotDuration)
+
+	for {
+		nextSlot := s.blockchain.GetCurrentSlot() + 1
+		nextSlotTime := time.Unix(int64(nextSlot*slotDuration+s.blockchain.stateManager.GetGenesisTime()), 0)
+
+		<-s.blockchain.clock.After(nextSlotTime.Sub(s.blockchain.clock.Now()))
+
+		for _, b := range s.futureBlocks.TakeReady(s.blockchain.GetCurrentSlot()) {
+			err := s.handleReceivedBlock(b.Block, s.hostNode.GetPeerByID(b.From), true)
+			if err != nil {
+				logger.WithField("slot", b.Block.BlockHeader.SlotNumber).Warnf("could not process block: %s", err)
+			}
+		}
+
+		for _, request := range s.orphans.ExpiredRequests(s.blockchain.clock.Now()) {
+			s.requestMissingBlocks(request)
+		}
+	}
+}
+
 // ListenForBlocks listens for new blocks over the pub-sub network
 // being broadcast as a result of finding them.
 func (s SyncManager) ListenForBlocks() error {
-	_, err := s.hostNode.SubscribeMessage("block", func(data []byte, from peer.ID) {
+	// blocks with unknown parents aren't relayed, but we keep them until the parent arrives
+	s.gossipValidator.SetOrphanHandler(func(block *primitives.Block, from peer.ID) {
+		err := s.handleReceivedBlock(block, s.hostNode.GetPeerByID(from), true)
+		if err != nil {
+			logger.WithField("slot", block.BlockHeader.SlotNumber).Warnf("could not handle orphan block: %s", err)
+		}
+	})
+
+	err := s.hostNode.RegisterTopicValidator("block", s.gossipValidator.ValidateBlock)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.hostNode.SubscribeMessage("block", func(data []byte, from peer.ID) {
 		peerFrom := s.hostNode.GetPeerByID(from)
 
 		if peerFrom == nil {
@@ -486,11 +744,6 @@ func (s SyncManager) ListenForBlocks() error {
 			return
 		}
 
-		// if we're still syncing, ignore
-		if peerFrom.ProcessingRequest {
-			return
-		}
-
 		err = s.handleReceivedBlock(block, peerFrom, true)
 		if err != nil {
 			logger.Error(err)
@@ -506,7 +759,12 @@ func (s SyncManager) ListenForBlocks() error {
 
 // ListenForAttestations listens for new attestations over the pub-sub network.
 func (s SyncManager) ListenForAttestations() error {
-	_, err := s.hostNode.SubscribeMessage("attestation", func(data []byte, from peer.ID) {
+	err := s.hostNode.RegisterTopicValidator("attestation", s.gossipValidator.ValidateAttestation)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.hostNode.SubscribeMessage("attestation", func(data []byte, from peer.ID) {
 		peerFrom := s.hostNode.GetPeerByID(from)
 
 		if peerFrom == nil {
@@ -542,15 +800,128 @@ func (s SyncManager) ListenForAttestations() error {
 	return nil
 }
 
+// ListenForActions listens for new slashings, deposits and exits over the pub-sub network.
+func (s SyncManager) ListenForActions() error {
+	if s.mempool == nil {
+		return nil
+	}
+
+	_, err := s.hostNode.SubscribeMessage("proposerSlashing", func(data []byte, from peer.ID) {
+		slashingProto := new(pb.ProposerSlashing)
+
+		err := proto.Unmarshal(data, slashingProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		slashing, err := primitives.ProposerSlashingFromProto(slashingProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		err = s.mempool.ProcessNewProposerSlashing(*slashing)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.hostNode.SubscribeMessage("casperSlashing", func(data []byte, from peer.ID) {
+		slashingProto := new(pb.CasperSlashing)
+
+		err := proto.Unmarshal(data, slashingProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		slashing, err := primitives.CasperSlashingFromProto(slashingProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		err = s.mempool.ProcessNewCasperSlashing(*slashing)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.hostNode.SubscribeMessage("deposit", func(data []byte, from peer.ID) {
+		depositProto := new(pb.Deposit)
+
+		err := proto.Unmarshal(data, depositProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		deposit, err := primitives.DepositFromProto(depositProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		err = s.mempool.ProcessNewDeposit(*deposit)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.hostNode.SubscribeMessage("exit", func(data []byte, from peer.ID) {
+		exitProto := new(pb.Exit)
+
+		err := proto.Unmarshal(data, exitProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		exit, err := primitives.ExitFromProto(exitProto)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		err = s.mempool.ProcessNewExit(*exit)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Start starts the sync manager by registering message handlers
 func (s SyncManager) Start() {
 	s.hostNode.RegisterMessageHandler("pb.GetBlockMessage", s.onMessageGetBlock)
 
+	s.hostNode.RegisterMessageHandler("pb.GetBlocksBySlotMessage", s.onMessageGetBlocksBySlot)
+
 	s.hostNode.RegisterMessageHandler("pb.BlockMessage", s.onMessageBlock)
 
 	s.hostNode.RegisterMessageHandler("pb.MempoolMessage", s.onMessageMempool)
 
 	s.hostNode.RegisterMessageHandler("pb.GetMempoolMessage", s.onMessageGetMempool)
+
+	go s.processFutureBlocks()
 }
 
 // TryInitialSync tries to select a peer to sync with and
@@ -572,12 +943,14 @@ func (s SyncManager) TryInitialSync() {
 			}
 		}
 
-		getBlockMessage := &pb.GetBlockMessage{
-			LocatorHashes: s.blockchain.View.Chain.GetChainLocator(),
-			HashStop:      zeroHash[:],
-		}
+		s.requestBlocks()
 
-		bestPeer.SendMessage(getBlockMessage)
+		if s.scheduler.Done() {
+			// none of our peers are ahead of us
+			s.requestBlocksByLocator(bestPeer, zeroHash[:])
+		} else {
+			go s.expireSyncRequests()
+		}
 
 		if s.mempool != nil {
 			attestationsMessage := s.mempool.AttestationMempool.GetMempoolSummary()
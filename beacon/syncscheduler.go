@@ -0,0 +1,413 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+const (
+	// maxRequestsPerPeer is the number of requests that can be outstanding with each peer.
+	maxRequestsPerPeer = 2
+
+	// maxSyncPenalty is the number of failed requests after which a peer is banned.
+	maxSyncPenalty = 3
+)
+
+// ErrUnsolicitedBlocks is returned when a peer sends blocks that weren't requested from it.
+var ErrUnsolicitedBlocks = errors.New("received blocks that were not requested")
+
+// SyncRequest is a request for blocks sent to a peer.
+type SyncRequest struct {
+	ID   uint64
+	Peer peer.ID
+
+	// StartSlot and EndSlot are the range of slots requested (inclusive). They are both 0 for
+	// requests using a block locator.
+	StartSlot uint64
+	EndSlot   uint64
+
+	peerSlot uint64
+	sent     time.Time
+}
+
+// IsRange checks if the request is for a range of slots.
+func (r SyncRequest) IsRange() bool {
+	return r.EndSlot != 0
+}
+
+// SyncBatch is the blocks received for a range request.
+type SyncBatch struct {
+	Request SyncRequest
+	Blocks  []*primitives.Block
+
+	// gapRequested is set once the slots between the last processed block and the batch have
+	// been requested again because the batch didn't build on the last processed block.
+	gapRequested bool
+}
+
+type slotRange struct {
+	start uint64
+	end   uint64
+}
+
+// SyncScheduler splits the slots to sync into disjoint ranges that are requested from
+// multiple peers in parallel. It keeps track of outstanding requests so unsolicited blocks
+// can be rejected, retries requests that fail or time out, and penalizes the peers
+// responsible.
+//
+// The slot a peer says its chain is at is only used to decide which ranges to request from
+// it. The target slot is only raised when a peer actually serves blocks, so a peer can't keep
+// the node syncing by claiming a longer chain than it has.
+type SyncScheduler struct {
+	lock *sync.Mutex
+
+	rangeSize uint64
+	timeout   time.Duration
+
+	nextRequestID uint64
+	requests      map[uint64]SyncRequest
+	peerRequests  map[peer.ID]int
+	penalties     map[peer.ID]int
+
+	// slots before nextSlot have been assigned to a request at least once
+	nextSlot uint64
+	retries  []slotRange
+
+	// slots before targetSlot have to be processed for sync to be done
+	targetSlot uint64
+
+	// batches received out of order are kept until every batch before them is processed
+	received      map[uint64]SyncBatch
+	processedSlot uint64
+
+	// lastBlockSlot is the slot of the last block processed from a batch and gapRequests are
+	// the requests for the batches processed since then. The parent of the next batch must be
+	// in one of these batches.
+	lastBlockSlot uint64
+	gapRequests   []SyncRequest
+}
+
+// NewSyncScheduler creates a sync scheduler that starts syncing at a certain slot, requesting
+// rangeSize slots at a time.
+func NewSyncScheduler(startSlot uint64, rangeSize uint64, timeout time.Duration) *SyncScheduler {
+	return &SyncScheduler{
+		lock:          new(sync.Mutex),
+		rangeSize:     rangeSize,
+		timeout:       timeout,
+		nextRequestID: 1,
+		requests:      make(map[uint64]SyncRequest),
+		peerRequests:  make(map[peer.ID]int),
+		penalties:     make(map[peer.ID]int),
+		nextSlot:      startSlot,
+		targetSlot:    startSlot,
+		received:      make(map[uint64]SyncBatch),
+		processedSlot: startSlot,
+		lastBlockSlot: startSlot - 1,
+	}
+}
+
+func (s *SyncScheduler) addRequest(request SyncRequest) SyncRequest {
+	request.ID = s.nextRequestID
+	s.nextRequestID++
+
+	s.requests[request.ID] = request
+	s.peerRequests[request.Peer]++
+
+	return request
+}
+
+func (s *SyncScheduler) removeRequest(request SyncRequest) {
+	delete(s.requests, request.ID)
+	s.peerRequests[request.Peer]--
+	if s.peerRequests[request.Peer] == 0 {
+		delete(s.peerRequests, request.Peer)
+	}
+}
+
+// retry queues a range to be requested again, keeping the lowest slots first.
+func (s *SyncScheduler) retry(r slotRange) {
+	i := sort.Search(len(s.retries), func(i int) bool {
+		return s.retries[i].start > r.start
+	})
+	s.retries = append(s.retries, slotRange{})
+	copy(s.retries[i+1:], s.retries[i:])
+	s.retries[i] = r
+}
+
+// NextRequest assigns the next range of slots to request to a peer with a chain tip at a
+// certain slot. If the peer is banned, already has the maximum number of outstanding requests,
+// or there is nothing left to request from it, this returns false.
+func (s *SyncScheduler) NextRequest(p peer.ID, peerSlot uint64, now time.Time) (SyncRequest, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.penalties[p] >= maxSyncPenalty || s.peerRequests[p] >= maxRequestsPerPeer {
+		return SyncRequest{}, false
+	}
+
+	for i, r := range s.retries {
+		if r.start <= peerSlot {
+			s.retries = append(s.retries[:i], s.retries[i+1:]...)
+
+			return s.addRequest(SyncRequest{
+				Peer:      p,
+				StartSlot: r.start,
+				EndSlot:   r.end,
+				peerSlot:  peerSlot,
+				sent:      now,
+			}), true
+		}
+	}
+
+	if s.nextSlot > peerSlot {
+		return SyncRequest{}, false
+	}
+
+	end := s.nextSlot + s.rangeSize - 1
+	if end > peerSlot {
+		end = peerSlot
+	}
+
+	request := s.addRequest(SyncRequest{
+		Peer:      p,
+		StartSlot: s.nextSlot,
+		EndSlot:   end,
+		peerSlot:  peerSlot,
+		sent:      now,
+	})
+
+	s.nextSlot = end + 1
+
+	return request, true
+}
+
+// TrackLocatorRequest tracks a request for blocks using a block locator and returns the
+// request ID to send with it.
+func (s *SyncScheduler) TrackLocatorRequest(p peer.ID, now time.Time) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.addRequest(SyncRequest{Peer: p, sent: now}).ID
+}
+
+// fail penalizes the peer responsible for a request and queues its range to be requested
+// again.
+func (s *SyncScheduler) fail(request SyncRequest) {
+	s.penalties[request.Peer]++
+
+	if request.IsRange() {
+		s.retry(slotRange{request.StartSlot, request.EndSlot})
+	}
+}
+
+// checkBatch checks that the blocks received for a range request are in the range and each
+// block builds on the previous one. An empty batch is valid because every slot in the range
+// may have been skipped.
+func checkBatch(request SyncRequest, blocks []*primitives.Block) error {
+	var lastHash chainhash.Hash
+	for i, b := range blocks {
+		slot := b.BlockHeader.SlotNumber
+		if slot < request.StartSlot || slot > request.EndSlot {
+			return fmt.Errorf("peer sent block at slot %d for slots %d to %d", slot, request.StartSlot, request.EndSlot)
+		}
+
+		if i > 0 && (slot <= blocks[i-1].BlockHeader.SlotNumber || !b.BlockHeader.ParentRoot.IsEqual(&lastHash)) {
+			return fmt.Errorf("block at slot %d does not build on the previous block", slot)
+		}
+
+		blockHash, err := ssz.HashTreeRoot(b)
+		if err != nil {
+			return err
+		}
+		lastHash = blockHash
+	}
+
+	return nil
+}
+
+// CompleteRequest handles the blocks received from a peer in response to a request. Blocks
+// that weren't requested from the peer are rejected. If the blocks received for a range
+// request are invalid, the peer is penalized and the range is requested again.
+func (s *SyncScheduler) CompleteRequest(p peer.ID, requestID uint64, blocks []*primitives.Block) (SyncRequest, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	request, found := s.requests[requestID]
+	if !found || request.Peer != p {
+		return SyncRequest{}, ErrUnsolicitedBlocks
+	}
+
+	s.removeRequest(request)
+
+	if !request.IsRange() {
+		return request, nil
+	}
+
+	err := checkBatch(request, blocks)
+	if err != nil {
+		s.fail(request)
+		return request, err
+	}
+
+	s.received[request.StartSlot] = SyncBatch{
+		Request: request,
+		Blocks:  blocks,
+	}
+
+	// the peer has served blocks up to this slot, so we need to process them before we're done
+	if len(blocks) > 0 {
+		lastSlot := blocks[len(blocks)-1].BlockHeader.SlotNumber
+		if lastSlot >= s.targetSlot {
+			s.targetSlot = lastSlot + 1
+		}
+	}
+
+	return request, nil
+}
+
+// NextBatch gets the next batch of blocks to process if it has been received.
+func (s *SyncScheduler) NextBatch() (SyncBatch, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch, found := s.received[s.processedSlot]
+	if !found {
+		return SyncBatch{}, false
+	}
+
+	delete(s.received, s.processedSlot)
+	s.processedSlot = batch.Request.EndSlot + 1
+
+	return batch, true
+}
+
+// FinishBatch records that a batch was processed.
+func (s *SyncScheduler) FinishBatch(batch SyncBatch) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(batch.Blocks) > 0 {
+		s.lastBlockSlot = batch.Blocks[len(batch.Blocks)-1].BlockHeader.SlotNumber
+		s.gapRequests = nil
+	}
+	s.gapRequests = append(s.gapRequests, batch.Request)
+}
+
+// MissingParent handles a batch that doesn't build on any block we have. Peers can withhold
+// blocks by sending empty or truncated batches, so the parent of the batch should have been
+// sent for one of the ranges processed since the last block. The peers that sent those ranges
+// are penalized and the slots after the last block are requested again before the batch is
+// processed again. The peer that sent the batch is only penalized if the batch still doesn't
+// build on the last block after that. This returns the peers that were penalized.
+func (s *SyncScheduler) MissingParent(batch SyncBatch) []peer.ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	gapStart := s.lastBlockSlot + 1
+	if batch.gapRequested || batch.Request.StartSlot <= gapStart {
+		s.fail(batch.Request)
+		s.processedSlot = batch.Request.StartSlot
+		return []peer.ID{batch.Request.Peer}
+	}
+
+	var penalized []peer.ID
+	for _, request := range s.gapRequests {
+		if request.EndSlot >= gapStart {
+			s.penalties[request.Peer]++
+			penalized = append(penalized, request.Peer)
+		}
+	}
+	s.gapRequests = nil
+
+	for start := gapStart; start < batch.Request.StartSlot; start += s.rangeSize {
+		end := start + s.rangeSize - 1
+		if end >= batch.Request.StartSlot {
+			end = batch.Request.StartSlot - 1
+		}
+		s.retry(slotRange{start, end})
+	}
+
+	batch.gapRequested = true
+	s.received[batch.Request.StartSlot] = batch
+	s.processedSlot = gapStart
+
+	return penalized
+}
+
+// RetryBatch penalizes the peer that sent a batch that could not be processed and requests
+// the range again.
+func (s *SyncScheduler) RetryBatch(batch SyncBatch) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.fail(batch.Request)
+	s.processedSlot = batch.Request.StartSlot
+}
+
+// ExpireRequests removes requests sent longer than the timeout ago and penalizes the peers
+// they were sent to. Expired range requests are requested again.
+func (s *SyncScheduler) ExpireRequests(now time.Time) []SyncRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var expired []SyncRequest
+	for _, request := range s.requests {
+		if now.Sub(request.sent) > s.timeout {
+			expired = append(expired, request)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].ID < expired[j].ID
+	})
+
+	for _, request := range expired {
+		s.removeRequest(request)
+		s.fail(request)
+	}
+
+	return expired
+}
+
+// Penalize penalizes a peer for misbehaving while syncing.
+func (s *SyncScheduler) Penalize(p peer.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.penalties[p]++
+}
+
+// IsBanned checks if a peer has been penalized too many times to sync from.
+func (s *SyncScheduler) IsBanned(p peer.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.penalties[p] >= maxSyncPenalty
+}
+
+// Done checks if every slot before the target has been processed and there are no ranges
+// waiting to be requested, received or processed.
+func (s *SyncScheduler) Done() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.retries) > 0 || len(s.received) > 0 {
+		return false
+	}
+
+	for _, request := range s.requests {
+		if request.IsRange() {
+			return false
+		}
+	}
+
+	return s.processedSlot >= s.targetSlot
+}
@@ -0,0 +1,393 @@
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// fakeChain creates a chain of empty blocks with one block in each slot from 1 to n.
+func fakeChain(t *testing.T, n uint64) []*primitives.Block {
+	blocks := make([]*primitives.Block, n)
+	var parent [32]byte
+	for i := range blocks {
+		blocks[i] = &primitives.Block{
+			BlockHeader: primitives.BlockHeader{
+				SlotNumber: uint64(i) + 1,
+				ParentRoot: parent,
+			},
+		}
+
+		h, err := ssz.HashTreeRoot(blocks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		parent = h
+	}
+	return blocks
+}
+
+// blocksInRange gets the blocks of a fake chain requested by a range request.
+func blocksInRange(blocks []*primitives.Block, request beacon.SyncRequest) []*primitives.Block {
+	return blocks[request.StartSlot-1 : request.EndSlot]
+}
+
+func TestSyncSchedulerAssignsDisjointRanges(t *testing.T) {
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+	peers := []peer.ID{"a", "b", "c"}
+
+	var requests []beacon.SyncRequest
+	for _, p := range peers {
+		for {
+			request, ok := s.NextRequest(p, 45, now)
+			if !ok {
+				break
+			}
+			requests = append(requests, request)
+		}
+	}
+
+	// each peer gets two requests, but there are only 5 ranges
+	if len(requests) != 5 {
+		t.Fatalf("expected 5 requests, got %d", len(requests))
+	}
+
+	nextSlot := uint64(1)
+	for _, request := range requests {
+		if request.StartSlot != nextSlot {
+			t.Fatalf("expected request to start at slot %d, got %d", nextSlot, request.StartSlot)
+		}
+		nextSlot = request.EndSlot + 1
+	}
+
+	if nextSlot != 46 {
+		t.Fatalf("expected requests to end at the peer's slot, got %d", nextSlot-1)
+	}
+
+	if requests[0].Peer != "a" || requests[1].Peer != "a" || requests[2].Peer != "b" || requests[4].Peer != "c" {
+		t.Fatal("expected each peer to get at most two requests")
+	}
+
+	// peers with shorter chains shouldn't be asked for slots they don't have
+	s = beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	if _, ok := s.NextRequest("a", 0, now); ok {
+		t.Fatal("expected peer without blocks to not get a request")
+	}
+}
+
+func TestSyncSchedulerProcessesBatchesInOrder(t *testing.T) {
+	blocks := fakeChain(t, 30)
+
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+	r1, _ := s.NextRequest("a", 30, now)
+	r2, _ := s.NextRequest("b", 30, now)
+	r3, _ := s.NextRequest("b", 30, now)
+
+	if _, err := s.CompleteRequest("b", r3.ID, blocksInRange(blocks, r3)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := s.NextBatch(); found {
+		t.Fatal("expected batch to wait for the batches before it")
+	}
+
+	if _, err := s.CompleteRequest("a", r1.ID, blocksInRange(blocks, r1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CompleteRequest("b", r2.ID, blocksInRange(blocks, r2)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []beacon.SyncRequest{r1, r2, r3} {
+		batch, found := s.NextBatch()
+		if !found {
+			t.Fatal("expected batch to be ready")
+		}
+		if batch.Request.ID != expected.ID {
+			t.Fatalf("expected batch for slots %d to %d, got slots %d to %d", expected.StartSlot, expected.EndSlot, batch.Request.StartSlot, batch.Request.EndSlot)
+		}
+	}
+
+	if !s.Done() {
+		t.Fatal("expected sync to be done")
+	}
+}
+
+func TestSyncSchedulerRejectsUnsolicitedBlocks(t *testing.T) {
+	blocks := fakeChain(t, 10)
+
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	request, _ := s.NextRequest("a", 10, time.Now())
+
+	if _, err := s.CompleteRequest("a", request.ID+1, blocks); err != beacon.ErrUnsolicitedBlocks {
+		t.Fatal("expected blocks with an unknown request ID to be rejected")
+	}
+
+	if _, err := s.CompleteRequest("b", request.ID, blocks); err != beacon.ErrUnsolicitedBlocks {
+		t.Fatal("expected blocks from a different peer to be rejected")
+	}
+
+	if _, err := s.CompleteRequest("a", request.ID, blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CompleteRequest("a", request.ID, blocks); err != beacon.ErrUnsolicitedBlocks {
+		t.Fatal("expected blocks for a completed request to be rejected")
+	}
+}
+
+func TestSyncSchedulerRetriesInvalidBatches(t *testing.T) {
+	blocks := fakeChain(t, 20)
+
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+
+	// blocks outside of the range, blocks out of order and blocks that don't build on each
+	// other are all rejected
+	invalidBatches := [][]*primitives.Block{
+		{blocks[1], blocks[0]},
+		blocks[5:15],
+		{blocks[0], blocks[2]},
+	}
+
+	for _, invalid := range invalidBatches {
+		request, ok := s.NextRequest("a", 20, now)
+		if !ok {
+			t.Fatal("expected peer to get a request")
+		}
+		if request.StartSlot != 1 {
+			t.Fatalf("expected failed range to be requested again first, got slot %d", request.StartSlot)
+		}
+
+		if _, err := s.CompleteRequest("a", request.ID, invalid); err == nil {
+			t.Fatal("expected invalid batch to be rejected")
+		}
+	}
+
+	if !s.IsBanned("a") {
+		t.Fatal("expected peer to be banned after sending too many invalid batches")
+	}
+
+	if _, ok := s.NextRequest("a", 20, now); ok {
+		t.Fatal("expected banned peer to not get any requests")
+	}
+
+	request, _ := s.NextRequest("b", 20, now)
+	if request.StartSlot != 1 {
+		t.Fatal("expected failed range to be requested from another peer")
+	}
+
+	if _, err := s.CompleteRequest("b", request.ID, blocksInRange(blocks, request)); err != nil {
+		t.Fatal(err)
+	}
+
+	batch, found := s.NextBatch()
+	if !found {
+		t.Fatal("expected batch to be ready")
+	}
+
+	// the batch failed to process, so it should be requested again
+	s.RetryBatch(batch)
+
+	request, _ = s.NextRequest("c", 20, now)
+	if request.StartSlot != 1 {
+		t.Fatal("expected batch that failed to process to be requested again")
+	}
+}
+
+func TestSyncSchedulerExpiresRequests(t *testing.T) {
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+	r1, _ := s.NextRequest("a", 20, now)
+	r2, _ := s.NextRequest("b", 20, now.Add(30*time.Second))
+	locatorRequest := s.TrackLocatorRequest("c", now)
+
+	expired := s.ExpireRequests(now.Add(time.Minute + time.Second))
+	if len(expired) != 2 || expired[0].ID != r1.ID || expired[1].ID != locatorRequest {
+		t.Fatal("expected only requests older than the timeout to expire")
+	}
+
+	if _, err := s.CompleteRequest("a", r1.ID, nil); err != beacon.ErrUnsolicitedBlocks {
+		t.Fatal("expected blocks for an expired request to be rejected")
+	}
+
+	request, _ := s.NextRequest("b", 20, now)
+	if request.StartSlot != r1.StartSlot || request.EndSlot != r1.EndSlot {
+		t.Fatal("expected expired range to be requested again")
+	}
+
+	if request.ID == r2.ID {
+		t.Fatal("expected retried request to get a new ID")
+	}
+}
+
+func TestSyncSchedulerAcceptsEmptyRanges(t *testing.T) {
+	blocks := fakeChain(t, 30)
+
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+	r1, _ := s.NextRequest("a", 30, now)
+	r2, _ := s.NextRequest("a", 30, now)
+
+	// every slot in a range may have been skipped
+	if _, err := s.CompleteRequest("a", r1.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CompleteRequest("a", r2.ID, blocksInRange(blocks, r2)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, found := s.NextBatch(); !found {
+			t.Fatal("expected batch to be ready")
+		}
+	}
+
+	if !s.Done() {
+		t.Fatal("expected sync to be done")
+	}
+
+	if s.IsBanned("a") {
+		t.Fatal("expected peer not to be penalized for an empty range")
+	}
+}
+
+func TestSyncSchedulerTargetOnlyRaisedByServedBlocks(t *testing.T) {
+	blocks := fakeChain(t, 20)
+
+	s := beacon.NewSyncScheduler(0, 10, time.Minute)
+	if !s.Done() {
+		t.Fatal("expected sync to be done before any blocks are requested")
+	}
+
+	now := time.Now()
+
+	// a peer claiming a long chain without serving any blocks doesn't keep sync going
+	request, _ := s.NextRequest("a", 1000000, now)
+	if _, err := s.CompleteRequest("a", request.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := s.NextBatch(); !found {
+		t.Fatal("expected batch to be ready")
+	}
+
+	if !s.Done() {
+		t.Fatal("expected sync to be done after the peer served no blocks")
+	}
+
+	request, _ = s.NextRequest("b", 20, now)
+	if _, err := s.CompleteRequest("b", request.ID, blocks[request.StartSlot-1:request.EndSlot]); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Done() {
+		t.Fatal("expected sync to wait for the blocks served to be processed")
+	}
+
+	if _, found := s.NextBatch(); !found {
+		t.Fatal("expected batch to be ready")
+	}
+
+	if !s.Done() {
+		t.Fatal("expected sync to be done after processing the blocks served")
+	}
+}
+
+func TestSyncSchedulerRequestsWithheldBlocksAgain(t *testing.T) {
+	blocks := fakeChain(t, 30)
+
+	s := beacon.NewSyncScheduler(1, 10, time.Minute)
+
+	now := time.Now()
+	r1, _ := s.NextRequest("a", 30, now)
+	r2, _ := s.NextRequest("b", 30, now)
+	r3, _ := s.NextRequest("c", 30, now)
+
+	// a truncates its batch and b withholds its batch, so the batch from c doesn't build on the
+	// last block received
+	if _, err := s.CompleteRequest("a", r1.ID, blocks[:5]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CompleteRequest("b", r2.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CompleteRequest("c", r3.ID, blocksInRange(blocks, r3)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		batch, found := s.NextBatch()
+		if !found {
+			t.Fatal("expected batch to be ready")
+		}
+		s.FinishBatch(batch)
+	}
+
+	batch, found := s.NextBatch()
+	if !found || batch.Request.ID != r3.ID {
+		t.Fatal("expected batch from c to be ready")
+	}
+
+	penalized := s.MissingParent(batch)
+	if len(penalized) != 2 || penalized[0] != "a" || penalized[1] != "b" {
+		t.Fatalf("expected peers that withheld blocks to be penalized, got %v", penalized)
+	}
+
+	// the slots after the last block are requested again before the batch is processed
+	var gapRequests []beacon.SyncRequest
+	for _, p := range []peer.ID{"d", "e"} {
+		request, ok := s.NextRequest(p, 30, now)
+		if !ok {
+			t.Fatal("expected withheld slots to be requested again")
+		}
+		gapRequests = append(gapRequests, request)
+	}
+
+	if gapRequests[0].StartSlot != 6 || gapRequests[0].EndSlot != 15 || gapRequests[1].StartSlot != 16 || gapRequests[1].EndSlot != 20 {
+		t.Fatalf("expected slots 6 to 20 to be requested again, got %d to %d and %d to %d", gapRequests[0].StartSlot, gapRequests[0].EndSlot, gapRequests[1].StartSlot, gapRequests[1].EndSlot)
+	}
+
+	for i, request := range gapRequests {
+		if _, err := s.CompleteRequest(request.Peer, request.ID, blocksInRange(blocks, request)); err != nil {
+			t.Fatal(err)
+		}
+
+		batch, found := s.NextBatch()
+		if !found || batch.Request.ID != gapRequests[i].ID {
+			t.Fatal("expected withheld slots to be processed first")
+		}
+		s.FinishBatch(batch)
+	}
+
+	batch, found = s.NextBatch()
+	if !found || batch.Request.ID != r3.ID {
+		t.Fatal("expected batch from c to be processed again")
+	}
+
+	// if the batch still doesn't build on the last block, the peer that sent it is responsible
+	penalized = s.MissingParent(batch)
+	if len(penalized) != 1 || penalized[0] != "c" {
+		t.Fatalf("expected peer that sent the batch to be penalized, got %v", penalized)
+	}
+
+	request, _ := s.NextRequest("d", 30, now)
+	if request.StartSlot != r3.StartSlot || request.EndSlot != r3.EndSlot {
+		t.Fatal("expected batch that doesn't build on the last block to be requested again")
+	}
+}
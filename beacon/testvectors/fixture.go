@@ -0,0 +1,280 @@
+// Package testvectors runs state transition test vectors. A test vector is a JSON fixture with a
+// pre-state, a list of steps to apply to it and the expected root of the post-state, so
+// consensus rules can be checked without Go test code and by other implementations.
+package testvectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// Step is a single step of a fixture. Exactly one of the fields must be set.
+type Step struct {
+	// ProcessSlots processes empty slots up to this slot, including any epoch transitions.
+	ProcessSlots uint64 `json:",omitempty"`
+
+	// Block processes slots up to the slot of the block and then processes the block.
+	Block *pb.Block `json:",omitempty"`
+
+	// EpochTransition runs an epoch transition at the current slot.
+	EpochTransition bool `json:",omitempty"`
+}
+
+// Fixture is a state transition test vector. Hashes and roots are hex encoded and the state and
+// blocks use the JSON encoding of their protobuf messages.
+type Fixture struct {
+	Description string
+
+	// Config is the network config the steps are run with.
+	Config config.Config
+
+	// PreState is the state before the first step. PreStateRoot is its hash tree root, which
+	// is checked before running any steps.
+	PreState     *pb.State
+	PreStateRoot string
+
+	// LatestBlockHash is the hash of the latest block processed by the pre-state and
+	// LatestStateRoot is the state root after processing it. The first block of the fixture
+	// must be built on this block.
+	LatestBlockHash string
+	LatestStateRoot string
+
+	// VerifySignatures is whether block signatures are checked.
+	VerifySignatures bool
+
+	Steps []Step
+
+	// PostStateRoot is the expected hash tree root of the state after the last step.
+	PostStateRoot string `json:",omitempty"`
+
+	// ExpectedError is part of the error the last step is expected to fail with if it isn't
+	// empty. Every other step must succeed and the post-state root isn't checked.
+	ExpectedError string `json:",omitempty"`
+}
+
+// ReadFixture reads a fixture from a reader.
+func ReadFixture(r io.Reader) (*Fixture, error) {
+	var f Fixture
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&f)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// LoadFixtures loads every fixture in a directory ending in .json, keyed by file name.
+func LoadFixtures(dir string) (map[string]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make(map[string]*Fixture)
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := ReadFixture(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read fixture %s: %s", path, err)
+		}
+
+		fixtures[filepath.Base(path)] = f
+	}
+
+	return fixtures, nil
+}
+
+// WriteFixture writes a fixture to a writer.
+func WriteFixture(w io.Writer, f *Fixture) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(f)
+}
+
+// fixtureView is the block view used to run a fixture. Blocks only need the latest block hash and
+// state root, so the view doesn't keep older blocks.
+type fixtureView struct {
+	latestBlockHash chainhash.Hash
+	latestStateRoot chainhash.Hash
+}
+
+func (v *fixtureView) GetHashBySlot(slot uint64) (chainhash.Hash, error) {
+	return chainhash.Hash{}, errors.New("fixtures can't get blocks by slot")
+}
+
+func (v *fixtureView) Tip() (chainhash.Hash, error) {
+	return v.latestBlockHash, nil
+}
+
+func (v *fixtureView) SetTipSlot(slot uint64) {}
+
+func (v *fixtureView) GetLastStateRoot() (chainhash.Hash, error) {
+	return v.latestStateRoot, nil
+}
+
+var _ primitives.BlockView = (*fixtureView)(nil)
+
+// Run runs the steps of a fixture and checks the result.
+func (f *Fixture) Run() error {
+	if f.PreState == nil {
+		return errors.New("fixture has no pre-state")
+	}
+
+	err := f.Config.Validate(len(f.PreState.ValidatorRegistry))
+	if err != nil {
+		return err
+	}
+
+	state, err := primitives.StateFromProto(f.PreState)
+	if err != nil {
+		return err
+	}
+
+	err = checkRoot("pre-state", *state, f.PreStateRoot)
+	if err != nil {
+		return err
+	}
+
+	view := new(fixtureView)
+	view.latestBlockHash, err = decodeHash(f.LatestBlockHash)
+	if err != nil {
+		return err
+	}
+
+	view.latestStateRoot, err = decodeHash(f.LatestStateRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Steps) == 0 {
+		return errors.New("fixture has no steps")
+	}
+
+	for i, step := range f.Steps {
+		err := runStep(state, view, step, &f.Config, f.VerifySignatures)
+		if err != nil {
+			if f.ExpectedError != "" && i == len(f.Steps)-1 {
+				if !strings.Contains(err.Error(), f.ExpectedError) {
+					return fmt.Errorf("expected last step to fail with %q, got: %s", f.ExpectedError, err)
+				}
+				return nil
+			}
+			return fmt.Errorf("step %d failed: %s", i, err)
+		}
+	}
+
+	if f.ExpectedError != "" {
+		return fmt.Errorf("expected last step to fail with %q", f.ExpectedError)
+	}
+
+	return checkRoot("post-state", *state, f.PostStateRoot)
+}
+
+// runStep applies a step to the state.
+func runStep(state *primitives.State, view *fixtureView, step Step, c *config.Config, verifySignatures bool) error {
+	actions := 0
+	if step.ProcessSlots != 0 {
+		actions++
+	}
+	if step.Block != nil {
+		actions++
+	}
+	if step.EpochTransition {
+		actions++
+	}
+	if actions != 1 {
+		return fmt.Errorf("step must have exactly one action, got %d", actions)
+	}
+
+	switch {
+	case step.ProcessSlots != 0:
+		if step.ProcessSlots < state.Slot {
+			return fmt.Errorf("can't process slots up to slot %d from slot %d", step.ProcessSlots, state.Slot)
+		}
+
+		_, err := state.ProcessSlots(step.ProcessSlots, view, c)
+		return err
+	case step.Block != nil:
+		block, err := primitives.BlockFromProto(step.Block)
+		if err != nil {
+			return err
+		}
+
+		_, err = state.ProcessSlots(block.BlockHeader.SlotNumber, view, c)
+		if err != nil {
+			return err
+		}
+
+		err = state.ProcessBlock(block, c, view, verifySignatures)
+		if err != nil {
+			return err
+		}
+
+		view.latestBlockHash, err = ssz.HashTreeRoot(block)
+		if err != nil {
+			return err
+		}
+
+		view.latestStateRoot, err = state.HashTreeRoot()
+		return err
+	default:
+		_, err := state.ProcessEpochTransition(c)
+		return err
+	}
+}
+
+// checkRoot checks that the hash tree root of the state matches an expected hex encoded root.
+func checkRoot(name string, state primitives.State, expected string) error {
+	expectedRoot, err := decodeHash(expected)
+	if err != nil {
+		return fmt.Errorf("invalid %s root: %s", name, err)
+	}
+
+	root, err := ssz.HashTreeRoot(state)
+	if err != nil {
+		return err
+	}
+
+	if root != expectedRoot {
+		return fmt.Errorf("expected %s root %x, got %x", name, expectedRoot, root)
+	}
+
+	return nil
+}
+
+// decodeHash decodes a hex encoded hash.
+func decodeHash(s string) (chainhash.Hash, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	var h chainhash.Hash
+	err = h.SetBytes(b)
+	return h, err
+}
+
+// encodeHash hex encodes a hash.
+func encodeHash(h chainhash.Hash) string {
+	return hex.EncodeToString(h[:])
+}
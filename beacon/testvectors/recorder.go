@@ -0,0 +1,113 @@
+package testvectors
+
+import (
+	"errors"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/validator"
+)
+
+// Recorder records a fixture by mining blocks on a blockchain. The pre-state of the fixture is
+// the state of the blockchain when the recorder is created.
+type Recorder struct {
+	blockchain *beacon.Blockchain
+	keystore   validator.Keystore
+	fixture    Fixture
+
+	// processedSlots is set once empty slots are recorded. Blocks are mined on the tip of the
+	// blockchain, so no more blocks can be recorded after that.
+	processedSlots bool
+}
+
+// NewRecorder creates a recorder for a blockchain. The keystore must have the keys of the
+// validators of the blockchain.
+func NewRecorder(description string, b *beacon.Blockchain, keystore validator.Keystore) (*Recorder, error) {
+	tip := b.View.Chain.Tip()
+	state := b.GetState()
+
+	stateRoot, err := state.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		blockchain: b,
+		keystore:   keystore,
+		fixture: Fixture{
+			Description:      description,
+			Config:           *b.GetConfig(),
+			PreState:         state.ToProto(),
+			PreStateRoot:     encodeHash(stateRoot),
+			LatestBlockHash:  encodeHash(tip.Hash),
+			LatestStateRoot:  encodeHash(tip.StateRoot),
+			VerifySignatures: true,
+		},
+	}, nil
+}
+
+// MineBlock mines a block with attestations from every validator using
+// util.MineBlockWithFullAttestations and records it.
+func (r *Recorder) MineBlock() error {
+	if r.processedSlots {
+		return errors.New("can't record blocks after empty slots")
+	}
+
+	slot := r.blockchain.View.Chain.Tip().Slot
+	state, err := r.blockchain.GetUpdatedState(slot + 1)
+	if err != nil {
+		return err
+	}
+
+	proposerIndex, err := state.GetBeaconProposerIndex(slot, r.blockchain.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	block, err := util.MineBlockWithFullAttestations(r.blockchain, r.keystore, proposerIndex)
+	if err != nil {
+		return err
+	}
+
+	r.fixture.Steps = append(r.fixture.Steps, Step{Block: block.ToProto()})
+	r.fixture.PostStateRoot = encodeHash(r.blockchain.View.Chain.Tip().StateRoot)
+
+	return nil
+}
+
+// MineBlocks mines and records a certain number of blocks.
+func (r *Recorder) MineBlocks(n uint64) error {
+	for i := uint64(0); i < n; i++ {
+		err := r.MineBlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProcessSlots records processing empty slots up to a certain slot.
+func (r *Recorder) ProcessSlots(upTo uint64) error {
+	state, err := r.blockchain.GetUpdatedState(upTo)
+	if err != nil {
+		return err
+	}
+
+	stateRoot, err := state.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	r.processedSlots = true
+	r.fixture.Steps = append(r.fixture.Steps, Step{ProcessSlots: upTo})
+	r.fixture.PostStateRoot = encodeHash(stateRoot)
+
+	return nil
+}
+
+// Fixture gets the recorded fixture.
+func (r *Recorder) Fixture() *Fixture {
+	f := r.fixture
+	f.Steps = append([]Step(nil), r.fixture.Steps...)
+	return &f
+}
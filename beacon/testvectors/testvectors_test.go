@@ -0,0 +1,195 @@
+package testvectors_test
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/testvectors"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/bls"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/validator"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+var update = flag.Bool("update", false, "regenerate the fixtures in testdata")
+
+func testConfig() config.Config {
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+	c.LatestBlockRootsLength = 64
+	return c
+}
+
+// generateFixtures records the fixtures that are checked into testdata.
+func generateFixtures(t *testing.T) map[string]*testvectors.Fixture {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	c := testConfig()
+
+	b, keys, err := util.SetupBlockchainWithTime(c.ShardCount*c.TargetCommitteeSize*2+5, &c, time.Unix(1560000000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, err := testvectors.NewRecorder("blocks with attestations from every validator finalizing the chain", b, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = blocks.MineBlocks(4 * c.EpochLength)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptySlots, err := testvectors.NewRecorder("an epoch without blocks after a block", b, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = emptySlots.MineBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = emptySlots.ProcessSlots(b.View.Chain.Tip().Slot + c.EpochLength + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a block with a randao reveal from another slot is rejected. The block is signed again
+	// after changing the randao reveal, so the randao reveal is the only thing wrong with it.
+	invalidRandao := blocks.Fixture()
+	invalidRandao.Description = "block with an invalid randao reveal"
+	invalidRandao.Steps = invalidRandao.Steps[:3]
+	lastBlock, err := primitives.BlockFromProto(invalidRandao.Steps[2].Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(lastBlock.BlockHeader.RandaoReveal[:], invalidRandao.Steps[1].Block.Header.RandaoReveal)
+	err = signBlock(b, keys, lastBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalidRandao.Steps[2].Block = lastBlock.ToProto()
+	invalidRandao.PostStateRoot = ""
+	invalidRandao.ExpectedError = "invalid randao signature"
+
+	return map[string]*testvectors.Fixture{
+		"blocks.json":        blocks.Fixture(),
+		"emptyslots.json":    emptySlots.Fixture(),
+		"invalidrandao.json": invalidRandao,
+	}
+}
+
+// signBlock signs a block of the main chain again as its proposer.
+func signBlock(b *beacon.Blockchain, keys validator.Keystore, block *primitives.Block) error {
+	slot := block.BlockHeader.SlotNumber
+
+	state, err := b.GetStateAtSlot(slot)
+	if err != nil {
+		return err
+	}
+
+	proposerIndex, err := state.GetBeaconProposerIndex(slot-1, b.GetConfig())
+	if err != nil {
+		return err
+	}
+
+	block.BlockHeader.Signature = bls.EmptySignature.Serialize()
+	blockHash, err := ssz.HashTreeRoot(block)
+	if err != nil {
+		return err
+	}
+
+	proposalRoot, err := ssz.HashTreeRoot(primitives.ProposalSignedData{
+		Slot:      slot,
+		Shard:     b.GetConfig().BeaconShardNumber,
+		BlockHash: blockHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	sig, err := bls.Sign(keys.GetKeyForValidator(proposerIndex), proposalRoot[:], primitives.GetDomain(state.ForkData, slot, bls.DomainProposal))
+	if err != nil {
+		return err
+	}
+	block.BlockHeader.Signature = sig.Serialize()
+
+	return nil
+}
+
+func TestRecordedFixtures(t *testing.T) {
+	fixtures := generateFixtures(t)
+
+	for name, f := range fixtures {
+		buf := new(bytes.Buffer)
+		err := testvectors.WriteFixture(buf, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *update {
+			err := ioutil.WriteFile(filepath.Join("testdata", name), buf.Bytes(), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		decoded, err := testvectors.ReadFixture(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoded.Run()
+		if err != nil {
+			t.Fatalf("recorded fixture %s failed: %s", name, err)
+		}
+	}
+
+	wrongRoot := fixtures["blocks.json"]
+	wrongRoot.PostStateRoot = wrongRoot.PreStateRoot
+	if wrongRoot.Run() == nil {
+		t.Fatal("expected fixture with wrong post-state root to fail")
+	}
+
+	noError := fixtures["blocks.json"]
+	noError.ExpectedError = "invalid randao signature"
+	if noError.Run() == nil {
+		t.Fatal("expected fixture expecting an error to fail when every step succeeds")
+	}
+
+	wrongError := fixtures["invalidrandao.json"]
+	wrongError.ExpectedError = "invalid signature"
+	if wrongError.Run() == nil {
+		t.Fatal("expected fixture expecting a different error to fail")
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	fixtures, err := testvectors.LoadFixtures("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fixtures) == 0 {
+		t.Fatal("expected fixtures in testdata")
+	}
+
+	for name, f := range fixtures {
+		err := f.Run()
+		if err != nil {
+			t.Fatalf("fixture %s (%s) failed: %s", name, f.Description, err)
+		}
+	}
+}
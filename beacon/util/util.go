@@ -26,6 +26,22 @@ func SetupBlockchain(initialValidators int, c *config.Config) (*beacon.Blockchai
 func SetupBlockchainWithTime(initialValidators int, c *config.Config, genesisTime time.Time) (*beacon.Blockchain, validator.Keystore, error) {
 	keystore := validator.NewFakeKeyStore()
 
+	validators, err := InitialValidators(initialValidators, &keystore, c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), c, validators, true, uint64(genesisTime.Unix()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, &keystore, nil
+}
+
+// InitialValidators generates the initial validator entries for a certain number of validators
+// using keys from the keystore.
+func InitialValidators(initialValidators int, keystore validator.Keystore, c *config.Config) ([]primitives.InitialValidatorEntry, error) {
 	var validators []primitives.InitialValidatorEntry
 
 	for i := 0; i <= initialValidators; i++ {
@@ -33,11 +49,11 @@ func SetupBlockchainWithTime(initialValidators int, c *config.Config, genesisTim
 		pub := key.DerivePublicKey()
 		hashPub, err := ssz.HashTreeRoot(pub.Serialize())
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		proofOfPossession, err := bls.Sign(key, hashPub[:], bls.DomainDeposit)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		validators = append(validators, primitives.InitialValidatorEntry{
 			PubKey:                pub.Serialize(),
@@ -48,27 +64,38 @@ func SetupBlockchainWithTime(initialValidators int, c *config.Config, genesisTim
 		})
 	}
 
-	b, err := beacon.NewBlockchainWithInitialValidators(db.NewInMemoryDB(), c, validators, true, uint64(genesisTime.Unix()))
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return b, &keystore, nil
+	return validators, nil
 }
 
 // MineBlockWithSpecialsAndAttestations mines a block with the given specials and attestations.
 func MineBlockWithSpecialsAndAttestations(b *beacon.Blockchain, attestations []primitives.Attestation, proposerSlashings []primitives.ProposerSlashing, casperSlashings []primitives.CasperSlashing, deposits []primitives.Deposit, exits []primitives.Exit, k validator.Keystore, proposerIndex uint32) (*primitives.Block, error) {
+	return MineBlockWithBody(b, primitives.BlockBody{
+		Attestations:      attestations,
+		ProposerSlashings: proposerSlashings,
+		CasperSlashings:   casperSlashings,
+		Deposits:          deposits,
+		Exits:             exits,
+	}, k, proposerIndex)
+}
+
+// MineBlockWithBody mines a block with the given body.
+func MineBlockWithBody(b *beacon.Blockchain, body primitives.BlockBody, k validator.Keystore, proposerIndex uint32) (*primitives.Block, error) {
 	parentRoot := b.View.Chain.Tip().Hash
 
 	stateRoot := b.View.Chain.Tip().StateRoot
 
 	slotNumber := b.View.Chain.Tip().Slot + 1
 
+	state, err := b.GetUpdatedState(slotNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	var slotsBytes [8]byte
 	binary.BigEndian.PutUint64(slotsBytes[:], slotNumber)
 	slotBytesHash := chainhash.HashH(slotsBytes[:])
 
-	randaoSig, err := bls.Sign(k.GetKeyForValidator(proposerIndex), slotBytesHash[:], bls.DomainRandao)
+	randaoSig, err := bls.Sign(k.GetKeyForValidator(proposerIndex), slotBytesHash[:], primitives.GetDomain(state.ForkData, slotNumber, bls.DomainRandao))
 	if err != nil {
 		return nil, err
 	}
@@ -81,13 +108,7 @@ func MineBlockWithSpecialsAndAttestations(b *beacon.Blockchain, attestations []p
 			RandaoReveal: randaoSig.Serialize(),
 			Signature:    bls.EmptySignature.Serialize(),
 		},
-		BlockBody: primitives.BlockBody{
-			Attestations:      attestations,
-			ProposerSlashings: proposerSlashings,
-			CasperSlashings:   casperSlashings,
-			Deposits:          deposits,
-			Exits:             exits,
-		},
+		BlockBody: body,
 	}
 
 	blockHash, err := ssz.HashTreeRoot(block1)
@@ -106,7 +127,7 @@ func MineBlockWithSpecialsAndAttestations(b *beacon.Blockchain, attestations []p
 		return nil, err
 	}
 
-	sig, err := bls.Sign(k.GetKeyForValidator(proposerIndex), psdHash[:], bls.DomainProposal)
+	sig, err := bls.Sign(k.GetKeyForValidator(proposerIndex), psdHash[:], primitives.GetDomain(state.ForkData, slotNumber, bls.DomainProposal))
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +215,7 @@ func GenerateFakeAttestations(s *primitives.State, b *beacon.Blockchain, keys va
 		for i, n := range assignment.Committee {
 			attesterBitfield, _ = SetBit(attesterBitfield, uint32(i))
 			key := keys.GetKeyForValidator(n)
-			sig, err := bls.Sign(key, dataRoot[:], bls.DomainAttestation)
+			sig, err := bls.Sign(key, dataRoot[:], primitives.GetDomain(s.ForkData, lastSlot, bls.DomainAttestation))
 			if err != nil {
 				return nil, err
 			}
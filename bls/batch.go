@@ -0,0 +1,125 @@
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	bls12 "github.com/phoreproject/bls"
+	bls "github.com/phoreproject/bls/g2pubs"
+)
+
+// SignatureSet is a set of signatures that can be verified together using a single
+// aggregate signature check.
+type SignatureSet struct {
+	pubkeys []*PublicKey
+
+	// msgs include the domain they were signed for
+	msgs       [][]byte
+	signatures []*Signature
+}
+
+// NewSignatureSet creates an empty signature set.
+func NewSignatureSet() *SignatureSet {
+	return &SignatureSet{}
+}
+
+// Add adds a signature of a message by a public key to the set.
+func (s *SignatureSet) Add(pub *PublicKey, msg []byte, sig *Signature, domain uint64) {
+	pubCopy := pub.Copy()
+	s.pubkeys = append(s.pubkeys, &pubCopy)
+	s.msgs = append(s.msgs, messageWithDomain(msg, domain))
+	s.signatures = append(s.signatures, sig)
+}
+
+// Merge adds all of the signatures in another set to this set.
+func (s *SignatureSet) Merge(other *SignatureSet) {
+	s.pubkeys = append(s.pubkeys, other.pubkeys...)
+	s.msgs = append(s.msgs, other.msgs...)
+	s.signatures = append(s.signatures, other.signatures...)
+}
+
+// Len gets the number of signatures in the set.
+func (s *SignatureSet) Len() int {
+	return len(s.signatures)
+}
+
+// Verify checks that every signature in the set is valid. Each signature and its public key are
+// multiplied by a random coefficient before they're aggregated, so invalid signatures can't
+// cancel each other out.
+func (s *SignatureSet) Verify() bool {
+	if len(s.signatures) == 0 {
+		return true
+	}
+
+	aggregateSig := NewAggregateSignature()
+
+	// signatures of the same message are combined by aggregating the public keys
+	var pubkeys []*PublicKey
+	var msgs [][]byte
+	msgIndex := make(map[string]int)
+
+	for i, sig := range s.signatures {
+		r, err := randomCoefficient()
+		if err != nil {
+			return false
+		}
+
+		weightedSig, err := sig.mul(r)
+		if err != nil {
+			return false
+		}
+		aggregateSig.AggregateSig(weightedSig)
+
+		weightedPub, err := s.pubkeys[i].mul(r)
+		if err != nil {
+			return false
+		}
+
+		msg := s.msgs[i]
+		if idx, found := msgIndex[string(msg)]; found {
+			pubkeys[idx].AggregatePubKey(weightedPub)
+		} else {
+			msgIndex[string(msg)] = len(msgs)
+			pubkeys = append(pubkeys, weightedPub)
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return verifyAggregate(pubkeys, msgs, aggregateSig)
+}
+
+// randomCoefficient gets a random nonzero 64-bit coefficient for a signature in a batch.
+func randomCoefficient() (uint64, error) {
+	var b [8]byte
+	for {
+		_, err := rand.Read(b[:])
+		if err != nil {
+			return 0, err
+		}
+
+		r := binary.BigEndian.Uint64(b[:])
+		if r != 0 {
+			return r, nil
+		}
+	}
+}
+
+// mul multiplies the signature by a scalar.
+func (s *Signature) mul(r uint64) (*Signature, error) {
+	g1, err := bls12.DecompressG1Unchecked(s.s.Serialize())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signature{s: *bls.NewSignatureFromG1(g1.MulFR(bls12.NewFRRepr(r)).ToAffine())}, nil
+}
+
+// mul multiplies the public key by a scalar.
+func (p *PublicKey) mul(r uint64) (*PublicKey, error) {
+	g2, err := bls12.DecompressG2Unchecked(p.p.Serialize())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{p: *bls.NewPublicKeyFromG2(g2.MulFR(bls12.NewFRRepr(r)).ToAffine())}, nil
+}
@@ -0,0 +1,91 @@
+package bls_test
+
+import (
+	"testing"
+
+	"github.com/phoreproject/synapse/bls"
+)
+
+func TestSignatureSet(t *testing.T) {
+	r := NewXORShift(1)
+
+	s0, _ := bls.RandSecretKey(r)
+	s1, _ := bls.RandSecretKey(r)
+	s2, _ := bls.RandSecretKey(r)
+
+	msg0 := []byte("test!")
+	msg1 := []byte("test! 1")
+
+	sig0, err := bls.Sign(s0, msg0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig1, err := bls.Sign(s1, msg0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2, err := bls.Sign(s2, msg1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := bls.NewSignatureSet()
+	set.Add(s0.DerivePublicKey(), msg0, sig0, 0)
+
+	other := bls.NewSignatureSet()
+	other.Add(s1.DerivePublicKey(), msg0, sig1, 0)
+	other.Add(s2.DerivePublicKey(), msg1, sig2, 0)
+	set.Merge(other)
+
+	if set.Len() != 3 {
+		t.Fatalf("expected 3 signatures in set, got %d", set.Len())
+	}
+
+	if !set.Verify() {
+		t.Fatal("signature set was not valid")
+	}
+
+	if !other.Verify() {
+		t.Fatal("merging should not modify the other signature set")
+	}
+
+	// sig0 does not sign msg1
+	set.Add(s2.DerivePublicKey(), msg1, sig0, 0)
+	if set.Verify() {
+		t.Fatal("signature set with an invalid signature was valid")
+	}
+}
+
+func TestSignatureSetCancellingSignatures(t *testing.T) {
+	r := NewXORShift(2)
+
+	s0, _ := bls.RandSecretKey(r)
+	s1, _ := bls.RandSecretKey(r)
+
+	msg0 := []byte("test!")
+	msg1 := []byte("test! 1")
+
+	sig0, err := bls.Sign(s0, msg0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig1, err := bls.Sign(s1, msg1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// moving sig1 into sig0 leaves the sum of the signatures the same, but neither signature
+	// is valid on its own
+	forged0, err := bls.AggregateSigs([]*bls.Signature{sig0, sig1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged1 := bls.NewAggregateSignature()
+
+	set := bls.NewSignatureSet()
+	set.Add(s0.DerivePublicKey(), msg0, forged0, 0)
+	set.Add(s1.DerivePublicKey(), msg1, forged1, 0)
+	if set.Verify() {
+		t.Fatal("signature set with invalid signatures that cancel out was valid")
+	}
+}
@@ -1,6 +1,7 @@
 package bls
 
 import (
+	"encoding/binary"
 	"io"
 
 	bls "github.com/phoreproject/bls/g2pubs"
@@ -126,16 +127,30 @@ func (p PublicKey) Copy() PublicKey {
 	return p
 }
 
+// messageWithDomain appends the domain to a message, so a signature is only valid for the domain
+// it was made for. Domains before the first fork (fork version 0 in the upper 32 bits) aren't
+// appended, so signatures made before forks were activated still verify.
+func messageWithDomain(msg []byte, domain uint64) []byte {
+	if domain>>32 == 0 {
+		return msg
+	}
+
+	out := make([]byte, len(msg)+8)
+	copy(out, msg)
+	binary.BigEndian.PutUint64(out[len(msg):], domain)
+	return out
+}
+
 // Sign a message using a secret key - in a beacon/validator client,
 // this key will come from and be unlocked from the account keystore.
 func Sign(sec *SecretKey, msg []byte, domain uint64) (*Signature, error) {
-	s := bls.Sign(msg, &sec.s)
+	s := bls.Sign(messageWithDomain(msg, domain), &sec.s)
 	return &Signature{s: *s}, nil
 }
 
 // VerifySig against a public key.
 func VerifySig(pub *PublicKey, msg []byte, sig *Signature, domain uint64) (bool, error) {
-	return bls.Verify(msg, &pub.p, &sig.s), nil
+	return bls.Verify(messageWithDomain(msg, domain), &pub.p, &sig.s), nil
 }
 
 // AggregateSigs puts multiple signatures into one using the underlying
@@ -151,6 +166,16 @@ func AggregateSigs(sigs []*Signature) (*Signature, error) {
 
 // VerifyAggregate verifies a signature over many messages.
 func VerifyAggregate(pubkeys []*PublicKey, msgs [][]byte, signature *Signature, domain uint64) bool {
+	msgsWithDomain := make([][]byte, len(msgs))
+	for i := range msgs {
+		msgsWithDomain[i] = messageWithDomain(msgs[i], domain)
+	}
+
+	return verifyAggregate(pubkeys, msgsWithDomain, signature)
+}
+
+// verifyAggregate verifies a signature over many messages that already include their domain.
+func verifyAggregate(pubkeys []*PublicKey, msgs [][]byte, signature *Signature) bool {
 	if len(pubkeys) != len(msgs) {
 		return false
 	}
@@ -170,7 +195,7 @@ func VerifyAggregateCommon(pubkeys []*PublicKey, msg []byte, signature *Signatur
 		blsPubs[i] = &pubkeys[i].p
 	}
 
-	return signature.s.VerifyAggregateCommon(blsPubs, msg)
+	return signature.s.VerifyAggregateCommon(blsPubs, messageWithDomain(msg, domain))
 }
 
 // AggregatePubKeys aggregates some public keys into one.
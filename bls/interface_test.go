@@ -32,6 +32,74 @@ func TestBasicSignature(t *testing.T) {
 	}
 }
 
+func TestSignatureDomain(t *testing.T) {
+	r := NewXORShift(1)
+
+	s, _ := bls.RandSecretKey(r)
+
+	p := s.DerivePublicKey()
+
+	msg := []byte("test!")
+
+	// domains are only bound to signatures after the first fork
+	forkVersion := uint64(1) << 32
+
+	sig, err := bls.Sign(s, msg, forkVersion+bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := bls.VerifySig(p, msg, sig, forkVersion+bls.DomainAttestation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if valid {
+		t.Fatal("signature is valid for a different domain and shouldn't be")
+	}
+
+	set := bls.NewSignatureSet()
+	set.Add(p, msg, sig, forkVersion+bls.DomainAttestation)
+	if set.Verify() {
+		t.Fatal("signature set is valid for a different domain and shouldn't be")
+	}
+}
+
+func TestSignatureBeforeFork(t *testing.T) {
+	r := NewXORShift(2)
+
+	s, _ := bls.RandSecretKey(r)
+
+	p := s.DerivePublicKey()
+
+	msg := []byte("test!")
+
+	sig, err := bls.Sign(s, msg, bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := bls.VerifySig(p, msg, sig, bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !valid {
+		t.Fatal("signature made before the first fork is not valid and should be")
+	}
+
+	forkVersion := uint64(1) << 32
+
+	valid, err = bls.VerifySig(p, msg, sig, forkVersion+bls.DomainProposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if valid {
+		t.Fatal("signature made before the first fork is valid after it and shouldn't be")
+	}
+}
+
 type XORShift struct {
 	state uint64
 }
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/phoreproject/synapse/beacon"
+	"github.com/phoreproject/synapse/beacon/app"
+	"github.com/phoreproject/synapse/beacon/db"
+	logger "github.com/sirupsen/logrus"
+)
+
+const archiveUsage = "usage: synapsebeacon archive export|import -datadir <dir> -file <archive file> [-chainconfig <chain file>]"
+
+// loadBlockchainForImport loads the blockchain in a data directory using the genesis
+// validators in a chain file.
+func loadBlockchainForImport(database db.Database, chainconfig string) (*beacon.Blockchain, error) {
+	f, err := os.Open(chainconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	appConfig, err := app.ReadChainFileToConfig(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTime, err := database.GetGenesisTime()
+	if err != nil {
+		genesisTime = appConfig.GenesisTime
+		err := database.SetGenesisTime(genesisTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return beacon.NewBlockchainWithInitialValidators(database, appConfig.NetworkConfig, appConfig.InitialValidatorList, true, genesisTime)
+}
+
+// runArchiveCommand exports the main chain of a data directory to a block archive or
+// imports a block archive into a data directory.
+func runArchiveCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New(archiveUsage)
+	}
+
+	flags := flag.NewFlagSet("archive "+args[0], flag.ExitOnError)
+	datadir := flags.String("datadir", "", "location of the blockchain data")
+	file := flags.String("file", "blocks.dat", "block archive to export to or import from")
+	chainconfig := flags.String("chainconfig", "testnet.json", "chain config file (only used for import)")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "export":
+		database, err := openDataDirectory(*datadir)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+
+		exported, err := beacon.ExportBlockArchive(database, f)
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		logger.WithFields(logger.Fields{
+			"file":   *file,
+			"blocks": exported,
+		}).Info("exported block archive")
+
+		return f.Close()
+	case "import":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		database, err := openDataDirectory(*datadir)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		blockchain, err := loadBlockchainForImport(database, *chainconfig)
+		if err != nil {
+			return err
+		}
+
+		imported, err := blockchain.ImportBlockArchive(f)
+		if err != nil {
+			return err
+		}
+
+		logger.WithFields(logger.Fields{
+			"file":   *file,
+			"blocks": imported,
+			"tip":    blockchain.View.Chain.Tip().Hash,
+		}).Info("imported block archive")
+
+		return nil
+	default:
+		return errors.New(archiveUsage)
+	}
+}
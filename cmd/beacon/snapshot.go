@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"os"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/db"
+	logger "github.com/sirupsen/logrus"
+)
+
+const snapshotUsage = "usage: synapsebeacon snapshot export|import -datadir <dir> -file <snapshot file>"
+
+func openDataDirectory(datadir string) (*db.BadgerDB, error) {
+	dir := datadir
+	if dir == "" {
+		dataDir, err := config.GetBaseDirectory(true)
+		if err != nil {
+			return nil, err
+		}
+		dir = dataDir
+	} else {
+		d, err := homedir.Expand(datadir)
+		if err != nil {
+			return nil, err
+		}
+		dir = d
+	}
+
+	err := os.MkdirAll(dir, 0777)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.NewBadgerDB(dir), nil
+}
+
+// runSnapshotCommand exports the state of a data directory to a snapshot file or imports
+// a snapshot file into an empty data directory.
+func runSnapshotCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New(snapshotUsage)
+	}
+
+	flags := flag.NewFlagSet("snapshot "+args[0], flag.ExitOnError)
+	datadir := flags.String("datadir", "", "location of the blockchain data")
+	file := flags.String("file", "snapshot.dat", "snapshot file to export to or import from")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "export":
+		database, err := openDataDirectory(*datadir)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+
+		w := bufio.NewWriter(f)
+
+		err = db.ExportSnapshot(database, w)
+		if err == nil {
+			err = w.Flush()
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		logger.WithField("file", *file).Info("exported snapshot")
+
+		return f.Close()
+	case "import":
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		database, err := openDataDirectory(*datadir)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+
+		err = db.ImportSnapshot(database, bufio.NewReader(f))
+		if err != nil {
+			return err
+		}
+
+		logger.WithField("file", *file).Info("imported snapshot")
+
+		return nil
+	default:
+		return errors.New(snapshotUsage)
+	}
+}
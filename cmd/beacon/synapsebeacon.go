@@ -16,10 +16,28 @@ import (
 const clientVersion = "0.2.6"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		err := runSnapshotCommand(os.Args[2:])
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "archive" {
+		err := runArchiveCommand(os.Args[2:])
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	rpcConnect := flag.String("rpclisten", "127.0.0.1:11782", "host and port for RPC server to listen on")
 	chainconfig := flag.String("chainconfig", "testnet.json", "chain config file")
 	resync := flag.Bool("resync", false, "resyncs the blockchain if this is set")
 	datadir := flag.String("datadir", "", "location to store blockchain data")
+	checkpoint := flag.String("checkpoint", "", "file containing a trusted finalized block and state to start syncing from")
+	stateRetention := flag.Uint64("stateretention", 0, "number of epochs of state checkpoints to keep before the finalized epoch (0 keeps all)")
 
 	// P2P
 	initialConnections := flag.String("connect", "", "comma separated multiaddrs")
@@ -29,7 +47,8 @@ func main() {
 	level := flag.String("level", "info", "log level")
 	flag.Parse()
 
-	utils.CheckNTP()
+	clock := utils.NewRealClock()
+	clock.CheckNTP()
 
 	lvl, err := logrus.ParseLevel(*level)
 	if err != nil {
@@ -63,10 +82,13 @@ func main() {
 	appConfig.RPCAddress = *rpcConnect
 	appConfig.DiscoveryOptions.PeerAddresses = append(appConfig.DiscoveryOptions.PeerAddresses, initialPeers...)
 	appConfig.DataDirectory = *datadir
+	appConfig.CheckpointFile = *checkpoint
+	appConfig.StateRetention = *stateRetention
 
 	appConfig.Resync = *resync
+	appConfig.Clock = clock
 	if appConfig.GenesisTime == 0 {
-		appConfig.GenesisTime = uint64(utils.Now().Unix())
+		appConfig.GenesisTime = uint64(clock.Now().Unix())
 	}
 
 	changed, newLimit, err := utils.ManageFdLimit()
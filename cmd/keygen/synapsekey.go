@@ -10,6 +10,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strconv"
@@ -116,7 +117,98 @@ func (b ByID) Less(i, j int) bool {
 	return b[i].ID < b[j].ID
 }
 
+// generateGenesis creates a chain file from validator files and prints the genesis state root
+// and genesis hash of the chain.
+func generateGenesis(args []string) {
+	fs := flag.NewFlagSet("genesis", flag.ExitOnError)
+	inputFiles := fs.String("input", "", "space separated list of validator files")
+	genesisTime := fs.Uint64("genesistime", 0, "genesis time of the chain as a unix timestamp")
+	bootstrapPeers := fs.String("bootstrap", "", "comma separated list of bootstrap peer multiaddrs")
+	networkID := fs.String("networkid", "regtest", "network ID of the chain")
+	networkConfigFile := fs.String("networkconfig", "", "JSON file overriding fields of the network config")
+	outfile := fs.String("outfile", "chain.json", "chain file to write")
+	err := fs.Parse(args)
+	if err != nil {
+		panic(err)
+	}
+
+	if *inputFiles == "" {
+		panic("expected validator files")
+	}
+
+	var validatorLists []app.InitialValidatorList
+	for _, inputFile := range strings.Split(*inputFiles, " ") {
+		f, err := os.Open(inputFile)
+		if err != nil {
+			panic(err)
+		}
+
+		var ivList app.InitialValidatorList
+		err = json.NewDecoder(f).Decode(&ivList)
+		if err != nil {
+			panic(fmt.Sprintf("could not read validator file %s: %s", inputFile, err))
+		}
+		f.Close()
+
+		validatorLists = append(validatorLists, ivList)
+	}
+
+	var bootstrap []string
+	if *bootstrapPeers != "" {
+		bootstrap = strings.Split(*bootstrapPeers, ",")
+	}
+
+	var networkConfig json.RawMessage
+	if *networkConfigFile != "" {
+		networkConfig, err = ioutil.ReadFile(*networkConfigFile)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	chainConfig, err := app.GenerateChainConfig(validatorLists, *genesisTime, bootstrap, *networkID, networkConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	genesisBlock, err := chainConfig.GetGenesisBlock()
+	if err != nil {
+		panic(err)
+	}
+
+	genesisHash, err := ssz.HashTreeRoot(*genesisBlock)
+	if err != nil {
+		panic(err)
+	}
+
+	f, err := os.Create(*outfile)
+	if err != nil {
+		panic(err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(chainConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("wrote chain file with %d validators to %s\n", chainConfig.InitialValidators.NumValidators, *outfile)
+	fmt.Printf("genesis state root: %s\n", genesisBlock.BlockHeader.StateRoot)
+	fmt.Printf("genesis hash: %s\n", chainhash.Hash(genesisHash))
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "genesis" {
+		generateGenesis(os.Args[2:])
+		return
+	}
+
 	generate := flag.Bool("generate", false, "generate validator key files")
 	rootkey := flag.String("rootkey", "", "this key derives all other keys")
 	validators := flag.String("validators", "", "validator assignment")
@@ -127,7 +219,7 @@ func main() {
 	flag.Parse()
 
 	if !*combine && !*generate {
-		panic("Expected either -combine or -generate")
+		panic("Expected either -combine, -generate or the genesis command")
 	}
 
 	var filesToCombine []string
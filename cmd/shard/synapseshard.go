@@ -29,7 +29,8 @@ func main() {
 	level := flag.String("level", "info", "log level")
 	flag.Parse()
 
-	utils.CheckNTP()
+	clock := utils.NewRealClock()
+	clock.CheckNTP()
 
 	lvl, err := logrus.ParseLevel(*level)
 	if err != nil {
@@ -65,8 +66,9 @@ func main() {
 	appConfig.DataDirectory = *datadir
 
 	appConfig.Resync = *resync
+	appConfig.Clock = clock
 	if appConfig.GenesisTime == 0 {
-		appConfig.GenesisTime = uint64(utils.Now().Unix())
+		appConfig.GenesisTime = uint64(clock.Now().Unix())
 	}
 
 	changed, newLimit, err := utils.ManageFdLimit()
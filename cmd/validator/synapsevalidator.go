@@ -3,7 +3,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
+	beaconapp "github.com/phoreproject/synapse/beacon/app"
 	"github.com/phoreproject/synapse/beacon/config"
 	"github.com/phoreproject/synapse/utils"
 	"github.com/phoreproject/synapse/validator/app"
@@ -20,10 +22,12 @@ func main() {
 	beaconHost := flag.String("beaconhost", ":11782", "the address to connect to the beacon node")
 	validators := flag.String("validators", "", "validators to manage (id separated by commas) (ex. \"1,2,3\")")
 	networkID := flag.String("networkid", "testnet", "networkID to use when starting network")
+	chainconfig := flag.String("chainconfig", "", "chain config file to read the network config from instead of using the network ID")
 	rootkey := flag.String("rootkey", "testnet", "root key to run validators")
 	flag.Parse()
 
-	utils.CheckNTP()
+	clock := utils.NewRealClock()
+	clock.CheckNTP()
 
 	logrus.WithField("validators", *validators).Debug("running with validators")
 
@@ -34,15 +38,40 @@ func main() {
 		panic(err)
 	}
 
-	networkConfig, found := config.NetworkIDs[*networkID]
-	if !found {
-		panic(fmt.Errorf("could not find network config %s", *networkID))
+	var networkConfig *config.Config
+	if *chainconfig != "" {
+		f, err := os.Open(*chainconfig)
+		if err != nil {
+			panic(err)
+		}
+
+		chainConfig, err := beaconapp.ReadChainFile(f)
+		if err != nil {
+			panic(err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			panic(err)
+		}
+
+		networkConfig, err = chainConfig.GetNetworkConfig()
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		c, found := config.NetworkIDs[*networkID]
+		if !found {
+			panic(fmt.Errorf("could not find network config %s", *networkID))
+		}
+		networkConfig = &c
 	}
 
 	c := app.ValidatorConfig{
 		BlockchainConn: blockchainConn,
 		RootKey:        *rootkey,
-		NetworkConfig:  &networkConfig,
+		NetworkConfig:  networkConfig,
+		Clock:          clock,
 	}
 	c.ParseValidatorIndices(*validators)
 
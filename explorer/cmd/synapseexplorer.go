@@ -25,7 +25,8 @@ func main() {
 	level := flag.String("level", "info", "log level")
 	flag.Parse()
 
-	utils.CheckNTP()
+	clock := utils.NewRealClock()
+	clock.CheckNTP()
 
 	changed, newLimit, err := utils.ManageFdLimit()
 	if err != nil {
@@ -73,6 +74,7 @@ func main() {
 	explorerConfig.DataDirectory = *datadir
 	explorerConfig.Resync = *resync
 	explorerConfig.ListeningAddress = *listen
+	explorerConfig.Clock = clock
 
 	ex, err := explorer.NewExplorer(*explorerConfig, db)
 	if err != nil {
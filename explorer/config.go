@@ -3,7 +3,6 @@ package explorer
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"io"
 
 	"github.com/phoreproject/synapse/primitives"
@@ -12,6 +11,7 @@ import (
 	multiaddr "github.com/multiformats/go-multiaddr"
 	"github.com/phoreproject/synapse/beacon/config"
 	"github.com/phoreproject/synapse/p2p"
+	"github.com/phoreproject/synapse/utils"
 
 	beaconapp "github.com/phoreproject/synapse/beacon/app"
 )
@@ -25,6 +25,7 @@ type Config struct {
 	Resync               bool
 	ListeningAddress     string
 	DiscoveryOptions     p2p.DiscoveryOptions
+	Clock                utils.Clock
 }
 
 // GenerateConfigFromChainConfig generates a new config from the passed in network config
@@ -32,9 +33,10 @@ type Config struct {
 func GenerateConfigFromChainConfig(chainConfig beaconapp.ChainConfig) (*Config, error) {
 	c := Config{
 		GenesisTime: chainConfig.GenesisTime,
+		Clock:       utils.NewRealClock(),
 	}
 
-	c.InitialValidatorList = make([]primitives.InitialValidatorEntry, chainConfig.InitialValidators.NumValidators)
+	c.InitialValidatorList = make([]primitives.InitialValidatorEntry, len(chainConfig.InitialValidators.Validators))
 	for i := range c.InitialValidatorList {
 		validator := chainConfig.InitialValidators.Validators[i]
 
@@ -74,11 +76,11 @@ func GenerateConfigFromChainConfig(chainConfig beaconapp.ChainConfig) (*Config,
 
 	c.DiscoveryOptions.PeerAddresses = make([]peerstore.PeerInfo, len(chainConfig.BootstrapPeers))
 
-	networkConfig, found := config.NetworkIDs[chainConfig.NetworkID]
-	if !found {
-		return nil, fmt.Errorf("error getting network config for ID: %s", chainConfig.NetworkID)
+	networkConfig, err := chainConfig.GetNetworkConfig()
+	if err != nil {
+		return nil, err
 	}
-	c.NetworkConfig = &networkConfig
+	c.NetworkConfig = networkConfig
 
 	for i := range c.DiscoveryOptions.PeerAddresses {
 		a, err := multiaddr.NewMultiaddr(chainConfig.BootstrapPeers[i])
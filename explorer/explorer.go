@@ -138,6 +138,8 @@ func (ex *Explorer) loadBlockchain() error {
 		panic(err)
 	}
 
+	blockchain.SetClock(ex.config.Clock)
+
 	ex.blockchain = blockchain
 
 	return nil
@@ -26,8 +26,10 @@ require (
 	github.com/libp2p/go-libp2p-peerstore v0.1.3
 	github.com/libp2p/go-libp2p-protocol v0.1.0
 	github.com/libp2p/go-libp2p-pubsub v0.1.0
+	github.com/libp2p/go-libp2p-transport-upgrader v0.1.1
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/multiformats/go-multiaddr v0.0.4
+	github.com/multiformats/go-multiaddr-net v0.0.1
 	github.com/phoreproject/bls v0.0.0-20190621015719-e008a268030e
 	github.com/phoreproject/go-phore-connmgr v0.1.1-0.20190729173651-7739b05fbd21
 	github.com/pkg/errors v0.8.1
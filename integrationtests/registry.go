@@ -12,4 +12,10 @@ var EntryList = []testframework.Entry{
 			return &ValidateTest{}
 		},
 	},
+	{
+		Name: "Multi-node Simulation",
+		Creator: func() testframework.IntegrationTest {
+			return &SimulationTest{}
+		},
+	},
 }
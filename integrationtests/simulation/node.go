@@ -0,0 +1,155 @@
+package simulation
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/phoreproject/synapse/beacon"
+	beaconapp "github.com/phoreproject/synapse/beacon/app"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/p2p"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/phoreproject/synapse/utils"
+	validatorapp "github.com/phoreproject/synapse/validator/app"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// rpcBufferSize is the size of the in-memory connection buffer between validators and beacon
+// nodes.
+const rpcBufferSize = 1024 * 1024
+
+// BeaconNode is a simulated beacon node. It runs a beacon app with its own database that
+// connects to the other beacon nodes through an in-memory network and serves RPC to
+// validators over an in-memory connection.
+type BeaconNode struct {
+	App        *beaconapp.BeaconApp
+	Blockchain *beacon.Blockchain
+	Mempool    *beacon.Mempool
+
+	listener *bufconn.Listener
+}
+
+// newBeaconNode starts a beacon node connecting to some peers on the network.
+func newBeaconNode(network *p2p.MemoryNetwork, c *config.Config, validators []primitives.InitialValidatorEntry, genesisTime uint64, clock utils.Clock, dataDirectory string, peers []peerstore.PeerInfo, minPeers int) (*BeaconNode, error) {
+	listeningAddress, err := network.NewAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	listener := bufconn.Listen(rpcBufferSize)
+
+	appConfig := beaconapp.NewConfig()
+	appConfig.NetworkConfig = c
+	appConfig.GenesisTime = genesisTime
+	appConfig.InitialValidatorList = validators
+	appConfig.Clock = clock
+	appConfig.DataDirectory = dataDirectory
+	appConfig.IsIntegrationTest = true
+	appConfig.ListeningAddress = listeningAddress.String()
+	appConfig.HostOptions = []libp2p.Option{network.Transport()}
+	appConfig.RPCListener = listener
+	appConfig.MinPeerCountToWait = minPeers
+	appConfig.DiscoveryOptions.PeerAddresses = peers
+
+	app := beaconapp.NewBeaconApp(appConfig)
+
+	err = app.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BeaconNode{
+		App:        app,
+		Blockchain: app.GetBlockchain(),
+		Mempool:    app.GetMempool(),
+		listener:   listener,
+	}, nil
+}
+
+// peerInfo gets the ID and addresses other nodes use to connect to the node.
+func (n *BeaconNode) peerInfo() peerstore.PeerInfo {
+	h := n.App.GetHostNode().GetHost()
+
+	return peerstore.PeerInfo{
+		ID:    h.ID(),
+		Addrs: h.Addrs(),
+	}
+}
+
+// dial connects to the RPC server of the node.
+func (n *BeaconNode) dial() (*grpc.ClientConn, error) {
+	return grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return n.listener.Dial()
+	}))
+}
+
+// getRejectedMessageCount gets the number of gossip messages rejected by the node.
+func (n *BeaconNode) getRejectedMessageCount() uint64 {
+	gossipValidator := n.App.GetSyncManager().GetGossipValidator()
+
+	rejected := uint64(0)
+	for _, topic := range []string{"block", "attestation"} {
+		for _, count := range gossipValidator.GetRejectedMessageCounts(topic) {
+			rejected += count
+		}
+	}
+	return rejected
+}
+
+// stop stops the beacon app.
+func (n *BeaconNode) stop() {
+	n.App.Stop()
+}
+
+// ValidatorNode is a simulated validator client running a validator app connected to a beacon
+// node.
+type ValidatorNode struct {
+	App *validatorapp.ValidatorApp
+
+	conn *grpc.ClientConn
+}
+
+// newValidatorNode starts a validator node managing some validators through a beacon node.
+// Errors from the validator app are sent on the error channel.
+func newValidatorNode(beaconNode *BeaconNode, validators []uint32, rootKey string, c *config.Config, clock utils.Clock, errs chan<- error) (*ValidatorNode, error) {
+	conn, err := beaconNode.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	app := validatorapp.NewValidatorApp(validatorapp.ValidatorConfig{
+		BlockchainConn:   conn,
+		NetworkConfig:    c,
+		ValidatorIndices: validators,
+		RootKey:          rootKey,
+		Clock:            clock,
+	})
+
+	go func() {
+		err := app.Run()
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return &ValidatorNode{
+		App:  app,
+		conn: conn,
+	}, nil
+}
+
+// stop stops the validator app and disconnects it from its beacon node.
+func (v *ValidatorNode) stop() {
+	v.App.Exit()
+	_ = v.conn.Close()
+}
+
+// nodeDataDirectory gets the data directory of a beacon node.
+func nodeDataDirectory(base string, node int) string {
+	return filepath.Join(base, fmt.Sprintf("beacon-%d", node))
+}
@@ -0,0 +1,385 @@
+// Package simulation runs multiple beacon apps and validator apps in a single process on a
+// simulated clock. Beacon nodes are connected by an in-memory p2p network and the simulation is
+// advanced one slot at a time, waiting for every node to see the block and attestations of a
+// slot before moving on, so the same simulation always produces the same chain.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/bits"
+	"os"
+	"time"
+
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/beacon/util"
+	"github.com/phoreproject/synapse/p2p"
+	"github.com/phoreproject/synapse/pb"
+	"github.com/phoreproject/synapse/utils"
+	"github.com/phoreproject/synapse/validator"
+	"google.golang.org/grpc"
+)
+
+const (
+	// rootKey is the root key the validator keys are derived from.
+	rootKey = "simulation"
+
+	// waitTimeout is how long to wait for the nodes to agree before giving up. This is real
+	// time, not simulated time.
+	waitTimeout = 30 * time.Second
+
+	// waitInterval is how often to check if the nodes agree.
+	waitInterval = 5 * time.Millisecond
+)
+
+// Config is the config of a simulation.
+type Config struct {
+	// NetworkConfig is the chain config used by every node.
+	NetworkConfig *config.Config
+
+	// BeaconNodes is the number of beacon nodes to run.
+	BeaconNodes int
+
+	// ValidatorNodes is the number of validator clients to run. Validator clients connect to
+	// the beacon nodes in turn and the validators are split evenly between them.
+	ValidatorNodes int
+
+	// Validators is the number of validators in the genesis state.
+	Validators int
+
+	// GenesisTime is the genesis time of the chain. The simulated clock starts at genesis.
+	GenesisTime time.Time
+}
+
+// Simulation is a set of beacon nodes and validator clients running on a simulated clock.
+type Simulation struct {
+	BeaconNodes    []*BeaconNode
+	ValidatorNodes []*ValidatorNode
+
+	config        Config
+	network       *p2p.MemoryNetwork
+	clock         *utils.ManualClock
+	slot          uint64
+	validators    []uint32
+	dataDirectory string
+	errs          chan error
+
+	// conn is used to request the duties of the validators from the first beacon node
+	conn *grpc.ClientConn
+}
+
+// NewSimulation starts the nodes of a simulation and waits for the beacon nodes to connect to
+// each other. The simulation starts at slot 0 and must be stopped once it's no longer needed.
+func NewSimulation(c Config) (*Simulation, error) {
+	if c.BeaconNodes < 1 || c.ValidatorNodes < 1 {
+		return nil, errors.New("simulation needs at least one beacon node and one validator node")
+	}
+
+	keystore := validator.NewRootKeyStore(rootKey)
+
+	validators, err := util.InitialValidators(c.Validators, keystore, c.NetworkConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dataDirectory, err := ioutil.TempDir("", "simulation")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulation{
+		config:        c,
+		network:       p2p.NewMemoryNetwork(),
+		clock:         utils.NewManualClock(c.GenesisTime),
+		dataDirectory: dataDirectory,
+		errs:          make(chan error, c.ValidatorNodes),
+	}
+
+	// each beacon node connects to the beacon nodes started before it
+	var peers []peerstore.PeerInfo
+	genesisTime := uint64(c.GenesisTime.Unix())
+	for i := 0; i < c.BeaconNodes; i++ {
+		node, err := newBeaconNode(s.network, c.NetworkConfig, validators, genesisTime, s.clock, nodeDataDirectory(dataDirectory, i), peers, c.BeaconNodes-1)
+		if err != nil {
+			s.Stop()
+			return nil, err
+		}
+		s.BeaconNodes = append(s.BeaconNodes, node)
+		peers = append(peers, node.peerInfo())
+	}
+
+	err = s.wait(s.checkConnected)
+	if err != nil {
+		s.Stop()
+		return nil, err
+	}
+
+	s.conn, err = s.BeaconNodes[0].dial()
+	if err != nil {
+		s.Stop()
+		return nil, err
+	}
+
+	validatorIndices := make([][]uint32, c.ValidatorNodes)
+	for i := range validators {
+		validatorIndices[i%c.ValidatorNodes] = append(validatorIndices[i%c.ValidatorNodes], uint32(i))
+		s.validators = append(s.validators, uint32(i))
+	}
+
+	for i := 0; i < c.ValidatorNodes; i++ {
+		beaconNode := s.BeaconNodes[i%c.BeaconNodes]
+		node, err := newValidatorNode(beaconNode, validatorIndices[i], rootKey, c.NetworkConfig, s.clock, s.errs)
+		if err != nil {
+			s.Stop()
+			return nil, err
+		}
+		s.ValidatorNodes = append(s.ValidatorNodes, node)
+	}
+
+	return s, nil
+}
+
+// Slot gets the current slot of the simulation.
+func (s *Simulation) Slot() uint64 {
+	return s.slot
+}
+
+// GetRejectedMessageCount gets the number of gossip messages rejected by the beacon nodes.
+func (s *Simulation) GetRejectedMessageCount() uint64 {
+	rejected := uint64(0)
+	for _, node := range s.BeaconNodes {
+		rejected += node.getRejectedMessageCount()
+	}
+	return rejected
+}
+
+// wait waits until a check passes on every node, one of the validator apps fails or the nodes
+// take too long to agree.
+func (s *Simulation) wait(check func() error) error {
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		select {
+		case err := <-s.errs:
+			return fmt.Errorf("validator app failed: %s", err)
+		default:
+		}
+
+		err := check()
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return err
+		}
+
+		time.Sleep(waitInterval)
+	}
+}
+
+// checkConnected checks that every beacon node is connected to every other beacon node and
+// knows that they're listening for blocks and attestations.
+func (s *Simulation) checkConnected() error {
+	for i, node := range s.BeaconNodes {
+		hostNode := node.App.GetHostNode()
+
+		peers := hostNode.PeersConnected()
+		if peers < len(s.BeaconNodes)-1 {
+			return fmt.Errorf("beacon node %d is connected to %d peers, expected %d", i, peers, len(s.BeaconNodes)-1)
+		}
+
+		for _, topic := range []string{"block", "attestation"} {
+			topicPeers := len(hostNode.GetTopicPeers(topic))
+			if topicPeers < len(s.BeaconNodes)-1 {
+				return fmt.Errorf("beacon node %d has %d peers listening for %s messages, expected %d", i, topicPeers, topic, len(s.BeaconNodes)-1)
+			}
+		}
+	}
+	return nil
+}
+
+// checkBlock checks that every beacon node has processed a block at the current slot.
+func (s *Simulation) checkBlock() error {
+	for i, node := range s.BeaconNodes {
+		tip := node.Blockchain.View.Chain.Tip()
+		if tip.Slot != s.slot {
+			return fmt.Errorf("beacon node %d has head at slot %d, expected a block at slot %d", i, tip.Slot, s.slot)
+		}
+	}
+	return s.CheckHeadAgreement()
+}
+
+// checkAttestations checks that every beacon node would include the attestations of every
+// validator assigned to attest in the current slot in the next block.
+func (s *Simulation) checkAttestations(expected int) error {
+	for i, node := range s.BeaconNodes {
+		tip := node.Blockchain.View.Chain.Tip()
+
+		attestations, err := node.Mempool.GetAttestationsToInclude(s.slot+1, tip.Hash, node.Blockchain.GetConfig())
+		if err != nil {
+			return err
+		}
+
+		attesters := 0
+		for _, att := range attestations {
+			if att.Data.Slot != s.slot {
+				continue
+			}
+			for _, b := range att.ParticipationBitfield {
+				attesters += bits.OnesCount8(b)
+			}
+		}
+
+		if attesters != expected {
+			return fmt.Errorf("beacon node %d has attestations from %d validators for slot %d, expected %d", i, attesters, s.slot, expected)
+		}
+	}
+	return nil
+}
+
+// countAttesters counts the validators assigned to attest in a slot.
+func (s *Simulation) countAttesters(slot uint64) (int, error) {
+	// duties for the first slot of an epoch are part of the previous epoch
+	duties, err := pb.NewBlockchainRPCClient(s.conn).GetValidatorDuties(context.Background(), &pb.GetValidatorDutiesRequest{
+		Epoch:      (slot - 1) / s.config.NetworkConfig.EpochLength,
+		Validators: s.validators,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	attesters := 0
+	for _, d := range duties.Duties {
+		for _, a := range d.AttesterDuties {
+			if a.Slot == slot {
+				attesters++
+			}
+		}
+	}
+	return attesters, nil
+}
+
+// slotTime gets the time validators act at a certain number of seconds into a slot, which is
+// half a second after the second starts.
+func (s *Simulation) slotTime(slot uint64, seconds uint64) time.Time {
+	genesisTime := uint64(s.config.GenesisTime.Unix())
+	slotDuration := uint64(s.config.NetworkConfig.SlotDuration)
+
+	return time.Unix(int64(genesisTime+slot*slotDuration+seconds), 5e8)
+}
+
+// AdvanceSlot advances the simulation to the next slot. The clock is moved to the start of the
+// slot and the simulation waits for the block from the proposer to reach every beacon node.
+// Then the clock is moved to the middle of the slot and the simulation waits for the
+// attestations from the validators assigned to the slot to reach every beacon node.
+func (s *Simulation) AdvanceSlot() error {
+	s.slot++
+
+	s.clock.Set(s.slotTime(s.slot, 0))
+
+	err := s.wait(s.checkBlock)
+	if err != nil {
+		return fmt.Errorf("block for slot %d did not reach every beacon node: %s", s.slot, err)
+	}
+
+	expected, err := s.countAttesters(s.slot)
+	if err != nil {
+		return err
+	}
+
+	s.clock.Set(s.slotTime(s.slot, uint64(s.config.NetworkConfig.SlotDuration+1)/2))
+
+	err = s.wait(func() error {
+		return s.checkAttestations(expected)
+	})
+	if err != nil {
+		return fmt.Errorf("attestations for slot %d did not reach every beacon node: %s", s.slot, err)
+	}
+
+	return nil
+}
+
+// AdvanceSlots advances the simulation a certain number of slots.
+func (s *Simulation) AdvanceSlots(n uint64) error {
+	for i := uint64(0); i < n; i++ {
+		err := s.AdvanceSlot()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckHeadAgreement checks that every beacon node has the same head.
+func (s *Simulation) CheckHeadAgreement() error {
+	head := s.BeaconNodes[0].Blockchain.View.Chain.Tip()
+	for i, node := range s.BeaconNodes[1:] {
+		nodeHead := node.Blockchain.View.Chain.Tip()
+		if !nodeHead.Hash.IsEqual(&head.Hash) {
+			return fmt.Errorf("beacon node %d has head %s at slot %d, but beacon node 0 has head %s at slot %d", i+1, nodeHead.Hash, nodeHead.Slot, head.Hash, head.Slot)
+		}
+	}
+	return nil
+}
+
+// CheckFinalized checks that every beacon node finalized at least a certain epoch and that
+// they finalized the same block.
+func (s *Simulation) CheckFinalized(epoch uint64) error {
+	finalizedNode, _ := s.BeaconNodes[0].Blockchain.View.GetFinalizedHead()
+	for i, node := range s.BeaconNodes {
+		finalizedEpoch := node.Blockchain.GetState().FinalizedEpoch
+		if finalizedEpoch < epoch {
+			return fmt.Errorf("beacon node %d finalized epoch %d, expected at least epoch %d", i, finalizedEpoch, epoch)
+		}
+
+		nodeFinalized, _ := node.Blockchain.View.GetFinalizedHead()
+		if !nodeFinalized.Hash.IsEqual(&finalizedNode.Hash) {
+			return fmt.Errorf("beacon node %d finalized block %s, but beacon node 0 finalized block %s", i, nodeFinalized.Hash, finalizedNode.Hash)
+		}
+	}
+	return nil
+}
+
+// CheckBalances checks the balance of every validator in the head state of each beacon node
+// and that the beacon nodes agree on the balances.
+func (s *Simulation) CheckBalances(check func(validator uint32, balance uint64) error) error {
+	balances := s.BeaconNodes[0].Blockchain.GetState().ValidatorBalances
+	for i, node := range s.BeaconNodes {
+		nodeBalances := node.Blockchain.GetState().ValidatorBalances
+		if len(nodeBalances) != len(balances) {
+			return fmt.Errorf("beacon node %d has %d validators, but beacon node 0 has %d validators", i, len(nodeBalances), len(balances))
+		}
+
+		for v, balance := range nodeBalances {
+			if balance != balances[v] {
+				return fmt.Errorf("beacon node %d has balance %d for validator %d, but beacon node 0 has balance %d", i, balance, v, balances[v])
+			}
+
+			err := check(uint32(v), balance)
+			if err != nil {
+				return fmt.Errorf("beacon node %d: %s", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Stop stops every node in the simulation and removes their databases.
+func (s *Simulation) Stop() {
+	for _, v := range s.ValidatorNodes {
+		v.stop()
+	}
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+
+	for _, b := range s.BeaconNodes {
+		b.stop()
+	}
+
+	_ = os.RemoveAll(s.dataDirectory)
+}
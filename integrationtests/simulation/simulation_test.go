@@ -0,0 +1,98 @@
+package simulation_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/integrationtests/simulation"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestSimulation(t *testing.T) *simulation.Simulation {
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.MaxAttestations = 16
+
+	s, err := simulation.NewSimulation(simulation.Config{
+		NetworkConfig:  &c,
+		BeaconNodes:    3,
+		ValidatorNodes: 2,
+		Validators:     c.ShardCount*c.TargetCommitteeSize*2 + 5,
+		GenesisTime:    time.Unix(1560000000, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestSimulationFinalizes(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	s := newTestSimulation(t)
+	defer s.Stop()
+
+	c := s.BeaconNodes[0].Blockchain.GetConfig()
+
+	for epoch := uint64(1); epoch <= 5; epoch++ {
+		err := s.AdvanceSlots(c.EpochLength)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = s.CheckHeadAgreement()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s.Slot() != 5*c.EpochLength {
+		t.Fatalf("expected simulation to be at slot %d, got %d", 5*c.EpochLength, s.Slot())
+	}
+
+	if s.GetRejectedMessageCount() != 0 {
+		t.Fatalf("expected every gossip message to be accepted, but %d were rejected", s.GetRejectedMessageCount())
+	}
+
+	err := s.CheckFinalized(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every validator is online, so none of them should be penalized
+	err = s.CheckBalances(func(validator uint32, balance uint64) error {
+		if balance < c.MaxDeposit {
+			return fmt.Errorf("validator %d lost balance: %d", validator, balance)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	var heads []string
+	for i := 0; i < 2; i++ {
+		s := newTestSimulation(t)
+
+		err := s.AdvanceSlots(8)
+		if err != nil {
+			s.Stop()
+			t.Fatal(err)
+		}
+
+		heads = append(heads, s.BeaconNodes[0].Blockchain.View.Chain.Tip().Hash.String())
+		s.Stop()
+	}
+
+	if heads[0] != heads[1] {
+		t.Fatalf("expected simulations to produce the same head, got %s and %s", heads[0], heads[1])
+	}
+}
@@ -0,0 +1,42 @@
+package testcase
+
+import (
+	"time"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	testframework "github.com/phoreproject/synapse/integrationtests/framework"
+	"github.com/phoreproject/synapse/integrationtests/simulation"
+)
+
+// SimulationTest runs multiple beacon nodes and validators on a simulated clock until the
+// chain finalizes.
+type SimulationTest struct{}
+
+// Execute implements IntegrationTest
+func (test SimulationTest) Execute(service *testframework.TestService) error {
+	c := config.LocalnetConfig
+
+	s, err := simulation.NewSimulation(simulation.Config{
+		NetworkConfig:  &c,
+		BeaconNodes:    3,
+		ValidatorNodes: 4,
+		Validators:     c.ShardCount*c.TargetCommitteeSize*2 + 5,
+		GenesisTime:    time.Unix(1560000000, 0),
+	})
+	if err != nil {
+		return err
+	}
+	defer s.Stop()
+
+	err = s.AdvanceSlots(c.EpochLength * 5)
+	if err != nil {
+		return err
+	}
+
+	err = s.CheckHeadAgreement()
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFinalized(2)
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/phoreproject/synapse/beacon/config"
 
 	testframework "github.com/phoreproject/synapse/integrationtests/framework"
-	"github.com/phoreproject/synapse/utils"
 	validatorapp "github.com/phoreproject/synapse/validator/app"
 	"google.golang.org/grpc"
 )
@@ -51,7 +50,7 @@ func (test *ValidateTest) setup() error {
 
 	beaconConfig.RPCProto = "unix"
 	beaconConfig.RPCAddress = "/tmp/beacon.sock"
-	beaconConfig.GenesisTime = uint64(utils.Now().Unix())
+	beaconConfig.GenesisTime = uint64(beaconConfig.Clock.Now().Unix())
 	beaconConfig.Resync = true
 	beaconConfig.DataDirectory = test.dataDir
 	beaconConfig.NetworkConfig = &config.LocalnetConfig
@@ -82,6 +81,7 @@ func (test *ValidateTest) setup() error {
 		ValidatorIndices: validatorIndices,
 		RootKey:          "testnet",
 		NetworkConfig:    &config.LocalnetConfig,
+		Clock:            beaconConfig.Clock,
 	}
 
 	test.validator = validatorapp.NewValidatorApp(validatorConfig)
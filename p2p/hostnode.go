@@ -79,19 +79,22 @@ type HostNode struct {
 
 var protocolID = protocol.ID("/grpc/phore/0.0.1")
 
-// NewHostNode creates a host node
-func NewHostNode(listenAddress multiaddr.Multiaddr, publicKey crypto.PubKey, privateKey crypto.PrivKey, options DiscoveryOptions, timeoutInterval time.Duration, maxPeers int, heartbeatInterval time.Duration, chainProvider ChainProvider) (*HostNode, error) {
+// NewHostNode creates a host node. Any host options are passed to libp2p after the default
+// options, like a transport to use instead of TCP.
+func NewHostNode(listenAddress multiaddr.Multiaddr, publicKey crypto.PubKey, privateKey crypto.PrivKey, options DiscoveryOptions, timeoutInterval time.Duration, maxPeers int, heartbeatInterval time.Duration, chainProvider ChainProvider, hostOptions ...libp2p.Option) (*HostNode, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	ps := pstoremem.NewPeerstore()
 
 	h, err := libp2p.New(
 		ctx,
-		libp2p.ListenAddrs(listenAddress),
-		libp2p.Identity(privateKey),
-		libp2p.EnableRelay(),
-		libp2p.Peerstore(ps),
-		libp2p.ConnectionManager(connmgr.NewConnManager(maxPeers, maxPeers, time.Second*5, ps, map[protocol2.ID]int{})),
+		append([]libp2p.Option{
+			libp2p.ListenAddrs(listenAddress),
+			libp2p.Identity(privateKey),
+			libp2p.EnableRelay(),
+			libp2p.Peerstore(ps),
+			libp2p.ConnectionManager(connmgr.NewConnManager(maxPeers, maxPeers, time.Second*5, ps, map[protocol2.ID]int{})),
+		}, hostOptions...)...,
 	)
 
 	if err != nil {
@@ -377,7 +380,9 @@ func (node *HostNode) IsPeerConnected(peerID peer.ID) bool {
 // ChainProvider is the interface from the blockchain to the host node packages.
 type ChainProvider interface {
 	Height() uint64
+	TipSlot() uint64
 	GenesisHash() chainhash.Hash
+	ForkVersion() uint64
 }
 
 // Run runs the main loop of the host node
@@ -412,6 +417,8 @@ func (node *HostNode) setupPeerNode(stream inet.Stream, outbound bool) (*Peer, e
 		PeerInfo:    peerInfoBytes,
 		Height:      node.chainProvider.Height(),
 		GenesisHash: genesisHash[:],
+		TipSlot:     node.chainProvider.TipSlot(),
+		ForkVersion: node.chainProvider.ForkVersion(),
 	})
 
 	return peerNode, nil
@@ -448,8 +455,9 @@ func (node *HostNode) SubscribeMessage(topic string, handler func([]byte, peer.I
 		for {
 			msg, err := subscription.Next(node.ctx)
 			if err != nil {
-				logger.WithField("error", err).Warn("error when getting next topic message")
-				continue
+				// this only fails once the subscription is cancelled or the host node is closed
+				logger.WithField("error", err).Debug("stopped listening for topic messages")
+				return
 			}
 
 			handler(msg.Data, msg.GetFrom())
@@ -459,6 +467,19 @@ func (node *HostNode) SubscribeMessage(topic string, handler func([]byte, peer.I
 	return subscription, nil
 }
 
+// RegisterTopicValidator registers a function that checks messages on a network topic before
+// they're handled or relayed to other peers. Messages are dropped if it returns false.
+func (node *HostNode) RegisterTopicValidator(topic string, validator func([]byte, peer.ID) bool) error {
+	return node.gossipSub.RegisterTopicValidator(topic, func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+		return validator(msg.Data, msg.GetFrom())
+	})
+}
+
+// GetTopicPeers gets the peers known to be subscribed to a network topic.
+func (node *HostNode) GetTopicPeers(topic string) []peer.ID {
+	return node.gossipSub.ListPeers(topic)
+}
+
 // UnsubscribeMessage cancels a subscription to a topic.
 func (node *HostNode) UnsubscribeMessage(subscription *pubsub.Subscription) {
 	subscription.Cancel()
@@ -559,6 +580,17 @@ func (node *HostNode) GetPeerByID(id peer.ID) *Peer {
 	return nil
 }
 
+// Close disconnects from every peer and stops the host node.
+func (node *HostNode) Close() error {
+	for _, p := range node.GetPeerList() {
+		p.Disconnect()
+	}
+
+	node.cancel()
+
+	return node.host.Close()
+}
+
 // StartDiscovery starts the host node discovering peers.
 func (node *HostNode) StartDiscovery() error {
 	return node.discovery.StartDiscovery()
@@ -0,0 +1,269 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/transport"
+	tptu "github.com/libp2p/go-libp2p-transport-upgrader"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr-net"
+)
+
+var errMemoryListenerClosed = errors.New("memory listener closed")
+
+// MemoryNetwork connects host nodes in the same process without opening any sockets. Host
+// nodes using the transport of a memory network can only connect to other host nodes listening
+// on the same network.
+type MemoryNetwork struct {
+	lock      *sync.Mutex
+	listeners map[string]*memoryListener
+	lastPort  int
+}
+
+// NewMemoryNetwork creates an empty memory network.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		lock:      new(sync.Mutex),
+		listeners: make(map[string]*memoryListener),
+	}
+}
+
+// NewAddress gets an address that isn't used on the network yet. Addresses look like TCP
+// addresses, but they only exist in the network.
+func (n *MemoryNetwork) NewAddress() (multiaddr.Multiaddr, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.lastPort++
+
+	return multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", n.lastPort))
+}
+
+// Transport gets a host node option that makes the host node connect through the network
+// instead of TCP.
+func (n *MemoryNetwork) Transport() libp2p.Option {
+	return libp2p.Transport(func(upgrader *tptu.Upgrader) transport.Transport {
+		return &memoryTransport{
+			network:  n,
+			upgrader: upgrader,
+			lock:     new(sync.Mutex),
+		}
+	})
+}
+
+func (n *MemoryNetwork) listen(addr multiaddr.Multiaddr) (*memoryListener, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, found := n.listeners[addr.String()]; found {
+		return nil, fmt.Errorf("address %s is already in use", addr)
+	}
+
+	l := &memoryListener{
+		network:   n,
+		addr:      addr,
+		conns:     make(chan manet.Conn),
+		closed:    make(chan struct{}),
+		closeOnce: new(sync.Once),
+	}
+	n.listeners[addr.String()] = l
+
+	return l, nil
+}
+
+func (n *MemoryNetwork) getListener(addr multiaddr.Multiaddr) (*memoryListener, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	l, found := n.listeners[addr.String()]
+	return l, found
+}
+
+func (n *MemoryNetwork) removeListener(addr multiaddr.Multiaddr) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.listeners, addr.String())
+}
+
+// memoryTransport is a libp2p transport connecting to listeners on a memory network. The raw
+// connections are upgraded with the same security and stream multiplexers as TCP connections.
+type memoryTransport struct {
+	network  *MemoryNetwork
+	upgrader *tptu.Upgrader
+
+	lock       *sync.Mutex
+	listenAddr multiaddr.Multiaddr
+}
+
+// Dial implements transport.Transport.
+func (t *memoryTransport) Dial(ctx context.Context, raddr multiaddr.Multiaddr, p peer.ID) (transport.CapableConn, error) {
+	l, found := t.network.getListener(raddr)
+	if !found {
+		return nil, fmt.Errorf("nothing is listening on %s", raddr)
+	}
+
+	laddr, err := t.localAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	local, remote := newMemoryConnPair(laddr, raddr)
+
+	err = l.connect(ctx, remote)
+	if err != nil {
+		_ = local.Close()
+		return nil, err
+	}
+
+	return t.upgrader.UpgradeOutbound(ctx, t, local, p)
+}
+
+// localAddress gets the address outgoing connections are from. This is the address the
+// transport listens on or a new address if it isn't listening.
+func (t *memoryTransport) localAddress() (multiaddr.Multiaddr, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.listenAddr != nil {
+		return t.listenAddr, nil
+	}
+
+	return t.network.NewAddress()
+}
+
+// CanDial implements transport.Transport.
+func (t *memoryTransport) CanDial(addr multiaddr.Multiaddr) bool {
+	_, found := t.network.getListener(addr)
+	return found
+}
+
+// Listen implements transport.Transport.
+func (t *memoryTransport) Listen(laddr multiaddr.Multiaddr) (transport.Listener, error) {
+	l, err := t.network.listen(laddr)
+	if err != nil {
+		return nil, err
+	}
+
+	t.lock.Lock()
+	t.listenAddr = laddr
+	t.lock.Unlock()
+
+	return t.upgrader.UpgradeListener(t, l), nil
+}
+
+// Protocols implements transport.Transport.
+func (t *memoryTransport) Protocols() []int {
+	return []int{multiaddr.P_TCP}
+}
+
+// Proxy implements transport.Transport.
+func (t *memoryTransport) Proxy() bool {
+	return false
+}
+
+// memoryListener accepts connections dialed on a memory network.
+type memoryListener struct {
+	network   *MemoryNetwork
+	addr      multiaddr.Multiaddr
+	conns     chan manet.Conn
+	closed    chan struct{}
+	closeOnce *sync.Once
+}
+
+// connect passes the listening end of a new connection to the listener.
+func (l *memoryListener) connect(ctx context.Context, conn manet.Conn) error {
+	select {
+	case l.conns <- conn:
+		return nil
+	case <-l.closed:
+		return errMemoryListenerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Accept implements manet.Listener.
+func (l *memoryListener) Accept() (manet.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errMemoryListenerClosed
+	}
+}
+
+// Close implements manet.Listener.
+func (l *memoryListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.network.removeListener(l.addr)
+	})
+	return nil
+}
+
+// Multiaddr implements manet.Listener.
+func (l *memoryListener) Multiaddr() multiaddr.Multiaddr {
+	return l.addr
+}
+
+// Addr implements manet.Listener.
+func (l *memoryListener) Addr() net.Addr {
+	return memoryAddr{l.addr}
+}
+
+// memoryAddr is the address of one end of an in-memory connection.
+type memoryAddr struct {
+	addr multiaddr.Multiaddr
+}
+
+// Network implements net.Addr.
+func (a memoryAddr) Network() string {
+	return "memory"
+}
+
+// String implements net.Addr.
+func (a memoryAddr) String() string {
+	return a.addr.String()
+}
+
+// memoryConn is one end of an in-memory connection.
+type memoryConn struct {
+	net.Conn
+
+	laddr multiaddr.Multiaddr
+	raddr multiaddr.Multiaddr
+}
+
+// newMemoryConnPair creates both ends of an in-memory connection between two addresses.
+func newMemoryConnPair(addr1 multiaddr.Multiaddr, addr2 multiaddr.Multiaddr) (*memoryConn, *memoryConn) {
+	conn1, conn2 := net.Pipe()
+
+	return &memoryConn{conn1, addr1, addr2}, &memoryConn{conn2, addr2, addr1}
+}
+
+// LocalAddr implements net.Conn.
+func (c *memoryConn) LocalAddr() net.Addr {
+	return memoryAddr{c.laddr}
+}
+
+// RemoteAddr implements net.Conn.
+func (c *memoryConn) RemoteAddr() net.Addr {
+	return memoryAddr{c.raddr}
+}
+
+// LocalMultiaddr implements manet.Conn.
+func (c *memoryConn) LocalMultiaddr() multiaddr.Multiaddr {
+	return c.laddr
+}
+
+// RemoteMultiaddr implements manet.Conn.
+func (c *memoryConn) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.raddr
+}
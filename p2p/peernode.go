@@ -38,10 +38,9 @@ type Peer struct {
 	Outbound   bool
 	Connecting bool
 	// The last nonce we sent them
-	LastPingNonce     uint64
-	LastMessageTime   time.Time
-	Version           uint64
-	ProcessingRequest bool
+	LastPingNonce   uint64
+	LastMessageTime time.Time
+	Version         uint64
 
 	keyedNetGroup uint64
 	connectedTime int64
@@ -53,6 +52,7 @@ type Peer struct {
 	outgoingMessages chan proto.Message
 	closeStream      func()
 	startBlock       uint64
+	startSlot        uint64
 
 	handlerLock *sync.RWMutex
 
@@ -69,11 +69,10 @@ func newPeer(outbound bool, id peer.ID, host *HostNode, timeoutInterval time.Dur
 		host:            host,
 		timeoutInterval: timeoutInterval,
 
-		Outbound:          outbound,
-		LastPingNonce:     0,
-		LastMessageTime:   time.Unix(0, 0),
-		Connecting:        true,
-		ProcessingRequest: false,
+		Outbound:        outbound,
+		LastPingNonce:   0,
+		LastMessageTime: time.Unix(0, 0),
+		Connecting:      true,
 
 		keyedNetGroup: binary.LittleEndian.Uint64(chainhash.HashB([]byte(id))),
 		connectedTime: time.Now().Unix(),
@@ -226,6 +225,16 @@ func (node *Peer) Reject(message string) error {
 	return nil
 }
 
+// StartHeight gets the height of the peer's chain when it connected.
+func (node *Peer) StartHeight() uint64 {
+	return node.startBlock
+}
+
+// StartSlot gets the slot of the peer's chain tip when it connected.
+func (node *Peer) StartSlot() uint64 {
+	return node.startSlot
+}
+
 // IsConnected checks if the peers is considered connected.
 func (node *Peer) IsConnected() bool {
 	return node.peerInfo != nil && time.Since(node.LastMessageTime) <= node.timeoutInterval
@@ -246,6 +255,14 @@ func (node *Peer) HandleVersionMessage(message *pb.VersionMessage) error {
 		return nil
 	}
 
+	// peers that didn't upgrade before a scheduled fork are on a different chain
+	forkVersion := node.host.chainProvider.ForkVersion()
+	if forkVersion != message.ForkVersion {
+		logger.WithField("peerID", node.ID).WithField("myForkVersion", forkVersion).WithField("receivedForkVersion", message.ForkVersion).Info("connected to peer with wrong fork version. disconnecting...")
+		node.Disconnect()
+		return nil
+	}
+
 	peerInfo := peerstore.PeerInfo{}
 	if peerInfo.UnmarshalJSON(message.PeerInfo) == nil {
 		node.peerInfo = &peerInfo
@@ -253,6 +270,7 @@ func (node *Peer) HandleVersionMessage(message *pb.VersionMessage) error {
 
 	node.Connecting = false
 	node.startBlock = message.Height
+	node.startSlot = message.TipSlot
 
 	return nil
 }
@@ -31,7 +31,7 @@ func (m *ProposalSignedData) Reset()         { *m = ProposalSignedData{} }
 func (m *ProposalSignedData) String() string { return proto.CompactTextString(m) }
 func (*ProposalSignedData) ProtoMessage()    {}
 func (*ProposalSignedData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{0}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{0}
 }
 func (m *ProposalSignedData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalSignedData.Unmarshal(m, b)
@@ -87,7 +87,7 @@ func (m *ProposerSlashing) Reset()         { *m = ProposerSlashing{} }
 func (m *ProposerSlashing) String() string { return proto.CompactTextString(m) }
 func (*ProposerSlashing) ProtoMessage()    {}
 func (*ProposerSlashing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{1}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{1}
 }
 func (m *ProposerSlashing) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposerSlashing.Unmarshal(m, b)
@@ -156,7 +156,7 @@ func (m *SlashableVoteData) Reset()         { *m = SlashableVoteData{} }
 func (m *SlashableVoteData) String() string { return proto.CompactTextString(m) }
 func (*SlashableVoteData) ProtoMessage()    {}
 func (*SlashableVoteData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{2}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{2}
 }
 func (m *SlashableVoteData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SlashableVoteData.Unmarshal(m, b)
@@ -216,7 +216,7 @@ func (m *CasperSlashing) Reset()         { *m = CasperSlashing{} }
 func (m *CasperSlashing) String() string { return proto.CompactTextString(m) }
 func (*CasperSlashing) ProtoMessage()    {}
 func (*CasperSlashing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{3}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{3}
 }
 func (m *CasperSlashing) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CasperSlashing.Unmarshal(m, b)
@@ -271,7 +271,7 @@ func (m *AttestationData) Reset()         { *m = AttestationData{} }
 func (m *AttestationData) String() string { return proto.CompactTextString(m) }
 func (*AttestationData) ProtoMessage()    {}
 func (*AttestationData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{4}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{4}
 }
 func (m *AttestationData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationData.Unmarshal(m, b)
@@ -366,7 +366,7 @@ func (m *AttestationDataAndCustodyBit) Reset()         { *m = AttestationDataAnd
 func (m *AttestationDataAndCustodyBit) String() string { return proto.CompactTextString(m) }
 func (*AttestationDataAndCustodyBit) ProtoMessage()    {}
 func (*AttestationDataAndCustodyBit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{5}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{5}
 }
 func (m *AttestationDataAndCustodyBit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationDataAndCustodyBit.Unmarshal(m, b)
@@ -414,7 +414,7 @@ func (m *Attestation) Reset()         { *m = Attestation{} }
 func (m *Attestation) String() string { return proto.CompactTextString(m) }
 func (*Attestation) ProtoMessage()    {}
 func (*Attestation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{6}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{6}
 }
 func (m *Attestation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Attestation.Unmarshal(m, b)
@@ -475,7 +475,7 @@ func (m *DepositParameters) Reset()         { *m = DepositParameters{} }
 func (m *DepositParameters) String() string { return proto.CompactTextString(m) }
 func (*DepositParameters) ProtoMessage()    {}
 func (*DepositParameters) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{7}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{7}
 }
 func (m *DepositParameters) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DepositParameters.Unmarshal(m, b)
@@ -518,6 +518,9 @@ func (m *DepositParameters) GetWithdrawalCredentials() []byte {
 
 type Deposit struct {
 	Parameters           *DepositParameters `protobuf:"bytes,1,opt,name=Parameters,proto3" json:"Parameters,omitempty"`
+	Amount               uint64             `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Index                uint64             `protobuf:"varint,3,opt,name=Index,proto3" json:"Index,omitempty"`
+	Proof                [][]byte           `protobuf:"bytes,4,rep,name=Proof,proto3" json:"Proof,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -527,7 +530,7 @@ func (m *Deposit) Reset()         { *m = Deposit{} }
 func (m *Deposit) String() string { return proto.CompactTextString(m) }
 func (*Deposit) ProtoMessage()    {}
 func (*Deposit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{8}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{8}
 }
 func (m *Deposit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Deposit.Unmarshal(m, b)
@@ -554,6 +557,73 @@ func (m *Deposit) GetParameters() *DepositParameters {
 	return nil
 }
 
+func (m *Deposit) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Deposit) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Deposit) GetProof() [][]byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+type DepositRootVote struct {
+	DepositRoot          []byte   `protobuf:"bytes,1,opt,name=DepositRoot,proto3" json:"DepositRoot,omitempty"`
+	VoteCount            uint64   `protobuf:"varint,2,opt,name=VoteCount,proto3" json:"VoteCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DepositRootVote) Reset()         { *m = DepositRootVote{} }
+func (m *DepositRootVote) String() string { return proto.CompactTextString(m) }
+func (*DepositRootVote) ProtoMessage()    {}
+func (*DepositRootVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{9}
+}
+func (m *DepositRootVote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepositRootVote.Unmarshal(m, b)
+}
+func (m *DepositRootVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepositRootVote.Marshal(b, m, deterministic)
+}
+func (dst *DepositRootVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepositRootVote.Merge(dst, src)
+}
+func (m *DepositRootVote) XXX_Size() int {
+	return xxx_messageInfo_DepositRootVote.Size(m)
+}
+func (m *DepositRootVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepositRootVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepositRootVote proto.InternalMessageInfo
+
+func (m *DepositRootVote) GetDepositRoot() []byte {
+	if m != nil {
+		return m.DepositRoot
+	}
+	return nil
+}
+
+func (m *DepositRootVote) GetVoteCount() uint64 {
+	if m != nil {
+		return m.VoteCount
+	}
+	return 0
+}
+
 type Exit struct {
 	Slot                 uint64   `protobuf:"varint,1,opt,name=Slot,proto3" json:"Slot,omitempty"`
 	ValidatorIndex       uint64   `protobuf:"varint,2,opt,name=ValidatorIndex,proto3" json:"ValidatorIndex,omitempty"`
@@ -567,7 +637,7 @@ func (m *Exit) Reset()         { *m = Exit{} }
 func (m *Exit) String() string { return proto.CompactTextString(m) }
 func (*Exit) ProtoMessage()    {}
 func (*Exit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{9}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{10}
 }
 func (m *Exit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Exit.Unmarshal(m, b)
@@ -620,7 +690,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{10}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{11}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -669,7 +739,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{11}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{12}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -731,6 +801,7 @@ type BlockBody struct {
 	Deposits             []*Deposit          `protobuf:"bytes,4,rep,name=Deposits,proto3" json:"Deposits,omitempty"`
 	Exits                []*Exit             `protobuf:"bytes,5,rep,name=Exits,proto3" json:"Exits,omitempty"`
 	Votes                []*AggregatedVote   `protobuf:"bytes,6,rep,name=Votes,proto3" json:"Votes,omitempty"`
+	DepositRoot          []byte              `protobuf:"bytes,7,opt,name=DepositRoot,proto3" json:"DepositRoot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -740,7 +811,7 @@ func (m *BlockBody) Reset()         { *m = BlockBody{} }
 func (m *BlockBody) String() string { return proto.CompactTextString(m) }
 func (*BlockBody) ProtoMessage()    {}
 func (*BlockBody) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{12}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{13}
 }
 func (m *BlockBody) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockBody.Unmarshal(m, b)
@@ -802,6 +873,13 @@ func (m *BlockBody) GetVotes() []*AggregatedVote {
 	return nil
 }
 
+func (m *BlockBody) GetDepositRoot() []byte {
+	if m != nil {
+		return m.DepositRoot
+	}
+	return nil
+}
+
 type ForkData struct {
 	PreForkVersion       uint64   `protobuf:"varint,1,opt,name=PreForkVersion,proto3" json:"PreForkVersion,omitempty"`
 	PostForkVersion      uint64   `protobuf:"varint,2,opt,name=PostForkVersion,proto3" json:"PostForkVersion,omitempty"`
@@ -815,7 +893,7 @@ func (m *ForkData) Reset()         { *m = ForkData{} }
 func (m *ForkData) String() string { return proto.CompactTextString(m) }
 func (*ForkData) ProtoMessage()    {}
 func (*ForkData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{13}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{14}
 }
 func (m *ForkData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ForkData.Unmarshal(m, b)
@@ -873,7 +951,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{14}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{15}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Validator.Unmarshal(m, b)
@@ -955,7 +1033,7 @@ func (m *ShardCommittee) Reset()         { *m = ShardCommittee{} }
 func (m *ShardCommittee) String() string { return proto.CompactTextString(m) }
 func (*ShardCommittee) ProtoMessage()    {}
 func (*ShardCommittee) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{15}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{16}
 }
 func (m *ShardCommittee) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShardCommittee.Unmarshal(m, b)
@@ -1007,7 +1085,7 @@ func (m *ShardCommitteesForSlot) Reset()         { *m = ShardCommitteesForSlot{}
 func (m *ShardCommitteesForSlot) String() string { return proto.CompactTextString(m) }
 func (*ShardCommitteesForSlot) ProtoMessage()    {}
 func (*ShardCommitteesForSlot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{16}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{17}
 }
 func (m *ShardCommitteesForSlot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ShardCommitteesForSlot.Unmarshal(m, b)
@@ -1045,7 +1123,7 @@ func (m *PersistentCommitteesForSlot) Reset()         { *m = PersistentCommittee
 func (m *PersistentCommitteesForSlot) String() string { return proto.CompactTextString(m) }
 func (*PersistentCommitteesForSlot) ProtoMessage()    {}
 func (*PersistentCommitteesForSlot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{17}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{18}
 }
 func (m *PersistentCommitteesForSlot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PersistentCommitteesForSlot.Unmarshal(m, b)
@@ -1084,7 +1162,7 @@ func (m *Crosslink) Reset()         { *m = Crosslink{} }
 func (m *Crosslink) String() string { return proto.CompactTextString(m) }
 func (*Crosslink) ProtoMessage()    {}
 func (*Crosslink) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{18}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{19}
 }
 func (m *Crosslink) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Crosslink.Unmarshal(m, b)
@@ -1133,7 +1211,7 @@ func (m *PendingAttestation) Reset()         { *m = PendingAttestation{} }
 func (m *PendingAttestation) String() string { return proto.CompactTextString(m) }
 func (*PendingAttestation) ProtoMessage()    {}
 func (*PendingAttestation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{19}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{20}
 }
 func (m *PendingAttestation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PendingAttestation.Unmarshal(m, b)
@@ -1213,6 +1291,9 @@ type State struct {
 	BatchedBlockRoots                  [][]byte                  `protobuf:"bytes,23,rep,name=BatchedBlockRoots,proto3" json:"BatchedBlockRoots,omitempty"`
 	Proposals                          []*ActiveProposal         `protobuf:"bytes,24,rep,name=Proposals,proto3" json:"Proposals,omitempty"`
 	PendingVotes                       []*AggregatedVote         `protobuf:"bytes,25,rep,name=PendingVotes,proto3" json:"PendingVotes,omitempty"`
+	LatestDepositRoot                  []byte                    `protobuf:"bytes,26,opt,name=LatestDepositRoot,proto3" json:"LatestDepositRoot,omitempty"`
+	DepositIndex                       uint64                    `protobuf:"varint,27,opt,name=DepositIndex,proto3" json:"DepositIndex,omitempty"`
+	DepositRootVotes                   []*DepositRootVote        `protobuf:"bytes,28,rep,name=DepositRootVotes,proto3" json:"DepositRootVotes,omitempty"`
 	XXX_NoUnkeyedLiteral               struct{}                  `json:"-"`
 	XXX_unrecognized                   []byte                    `json:"-"`
 	XXX_sizecache                      int32                     `json:"-"`
@@ -1222,7 +1303,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{20}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{21}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_State.Unmarshal(m, b)
@@ -1410,6 +1491,27 @@ func (m *State) GetPendingVotes() []*AggregatedVote {
 	return nil
 }
 
+func (m *State) GetLatestDepositRoot() []byte {
+	if m != nil {
+		return m.LatestDepositRoot
+	}
+	return nil
+}
+
+func (m *State) GetDepositIndex() uint64 {
+	if m != nil {
+		return m.DepositIndex
+	}
+	return 0
+}
+
+func (m *State) GetDepositRootVotes() []*DepositRootVote {
+	if m != nil {
+		return m.DepositRootVotes
+	}
+	return nil
+}
+
 type ValidatorRegistryDeltaBlock struct {
 	LatestRegistryDeltaRoot []byte   `protobuf:"bytes,1,opt,name=LatestRegistryDeltaRoot,proto3" json:"LatestRegistryDeltaRoot,omitempty"`
 	ValidatorIndex          uint32   `protobuf:"varint,2,opt,name=ValidatorIndex,proto3" json:"ValidatorIndex,omitempty"`
@@ -1424,7 +1526,7 @@ func (m *ValidatorRegistryDeltaBlock) Reset()         { *m = ValidatorRegistryDe
 func (m *ValidatorRegistryDeltaBlock) String() string { return proto.CompactTextString(m) }
 func (*ValidatorRegistryDeltaBlock) ProtoMessage()    {}
 func (*ValidatorRegistryDeltaBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{21}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{22}
 }
 func (m *ValidatorRegistryDeltaBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ValidatorRegistryDeltaBlock.Unmarshal(m, b)
@@ -1484,7 +1586,7 @@ func (m *AttestationRequest) Reset()         { *m = AttestationRequest{} }
 func (m *AttestationRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestationRequest) ProtoMessage()    {}
 func (*AttestationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{22}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{23}
 }
 func (m *AttestationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationRequest.Unmarshal(m, b)
@@ -1525,7 +1627,7 @@ func (m *VoteData) Reset()         { *m = VoteData{} }
 func (m *VoteData) String() string { return proto.CompactTextString(m) }
 func (*VoteData) ProtoMessage()    {}
 func (*VoteData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{23}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{24}
 }
 func (m *VoteData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VoteData.Unmarshal(m, b)
@@ -1586,7 +1688,7 @@ func (m *AggregatedVote) Reset()         { *m = AggregatedVote{} }
 func (m *AggregatedVote) String() string { return proto.CompactTextString(m) }
 func (*AggregatedVote) ProtoMessage()    {}
 func (*AggregatedVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{24}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{25}
 }
 func (m *AggregatedVote) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AggregatedVote.Unmarshal(m, b)
@@ -1641,7 +1743,7 @@ func (m *ActiveProposal) Reset()         { *m = ActiveProposal{} }
 func (m *ActiveProposal) String() string { return proto.CompactTextString(m) }
 func (*ActiveProposal) ProtoMessage()    {}
 func (*ActiveProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_f21b02def6f10ce7, []int{25}
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{26}
 }
 func (m *ActiveProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActiveProposal.Unmarshal(m, b)
@@ -1689,6 +1791,286 @@ func (m *ActiveProposal) GetQueued() bool {
 	return false
 }
 
+type Checkpoint struct {
+	Block                *Block   `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	State                *State   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Checkpoint) Reset()         { *m = Checkpoint{} }
+func (m *Checkpoint) String() string { return proto.CompactTextString(m) }
+func (*Checkpoint) ProtoMessage()    {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{27}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Checkpoint.Unmarshal(m, b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+}
+func (dst *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(dst, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return xxx_messageInfo_Checkpoint.Size(m)
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *Checkpoint) GetState() *State {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type SnapshotBlockNode struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=Height,proto3" json:"Height,omitempty"`
+	Slot                 uint64   `protobuf:"varint,3,opt,name=Slot,proto3" json:"Slot,omitempty"`
+	Parent               []byte   `protobuf:"bytes,4,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	StateRoot            []byte   `protobuf:"bytes,5,opt,name=StateRoot,proto3" json:"StateRoot,omitempty"`
+	Children             [][]byte `protobuf:"bytes,6,rep,name=Children,proto3" json:"Children,omitempty"`
+	Block                *Block   `protobuf:"bytes,7,opt,name=Block,proto3" json:"Block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotBlockNode) Reset()         { *m = SnapshotBlockNode{} }
+func (m *SnapshotBlockNode) String() string { return proto.CompactTextString(m) }
+func (*SnapshotBlockNode) ProtoMessage()    {}
+func (*SnapshotBlockNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{28}
+}
+func (m *SnapshotBlockNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotBlockNode.Unmarshal(m, b)
+}
+func (m *SnapshotBlockNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotBlockNode.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotBlockNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotBlockNode.Merge(dst, src)
+}
+func (m *SnapshotBlockNode) XXX_Size() int {
+	return xxx_messageInfo_SnapshotBlockNode.Size(m)
+}
+func (m *SnapshotBlockNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotBlockNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotBlockNode proto.InternalMessageInfo
+
+func (m *SnapshotBlockNode) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *SnapshotBlockNode) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SnapshotBlockNode) GetSlot() uint64 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *SnapshotBlockNode) GetParent() []byte {
+	if m != nil {
+		return m.Parent
+	}
+	return nil
+}
+
+func (m *SnapshotBlockNode) GetStateRoot() []byte {
+	if m != nil {
+		return m.StateRoot
+	}
+	return nil
+}
+
+func (m *SnapshotBlockNode) GetChildren() [][]byte {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+func (m *SnapshotBlockNode) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type SnapshotLatestAttestation struct {
+	Validator            uint32       `protobuf:"varint,1,opt,name=Validator,proto3" json:"Validator,omitempty"`
+	Attestation          *Attestation `protobuf:"bytes,2,opt,name=Attestation,proto3" json:"Attestation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SnapshotLatestAttestation) Reset()         { *m = SnapshotLatestAttestation{} }
+func (m *SnapshotLatestAttestation) String() string { return proto.CompactTextString(m) }
+func (*SnapshotLatestAttestation) ProtoMessage()    {}
+func (*SnapshotLatestAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{29}
+}
+func (m *SnapshotLatestAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotLatestAttestation.Unmarshal(m, b)
+}
+func (m *SnapshotLatestAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotLatestAttestation.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotLatestAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotLatestAttestation.Merge(dst, src)
+}
+func (m *SnapshotLatestAttestation) XXX_Size() int {
+	return xxx_messageInfo_SnapshotLatestAttestation.Size(m)
+}
+func (m *SnapshotLatestAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotLatestAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotLatestAttestation proto.InternalMessageInfo
+
+func (m *SnapshotLatestAttestation) GetValidator() uint32 {
+	if m != nil {
+		return m.Validator
+	}
+	return 0
+}
+
+func (m *SnapshotLatestAttestation) GetAttestation() *Attestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+type SnapshotHeader struct {
+	GenesisTime          uint64                       `protobuf:"varint,1,opt,name=GenesisTime,proto3" json:"GenesisTime,omitempty"`
+	HeadBlock            []byte                       `protobuf:"bytes,2,opt,name=HeadBlock,proto3" json:"HeadBlock,omitempty"`
+	FinalizedHead        []byte                       `protobuf:"bytes,3,opt,name=FinalizedHead,proto3" json:"FinalizedHead,omitempty"`
+	FinalizedState       *State                       `protobuf:"bytes,4,opt,name=FinalizedState,proto3" json:"FinalizedState,omitempty"`
+	JustifiedHead        []byte                       `protobuf:"bytes,5,opt,name=JustifiedHead,proto3" json:"JustifiedHead,omitempty"`
+	JustifiedState       *State                       `protobuf:"bytes,6,opt,name=JustifiedState,proto3" json:"JustifiedState,omitempty"`
+	BlockIndexRoot       []byte                       `protobuf:"bytes,7,opt,name=BlockIndexRoot,proto3" json:"BlockIndexRoot,omitempty"`
+	NumBlockNodes        uint64                       `protobuf:"varint,8,opt,name=NumBlockNodes,proto3" json:"NumBlockNodes,omitempty"`
+	LatestAttestations   []*SnapshotLatestAttestation `protobuf:"bytes,9,rep,name=LatestAttestations,proto3" json:"LatestAttestations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *SnapshotHeader) Reset()         { *m = SnapshotHeader{} }
+func (m *SnapshotHeader) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHeader) ProtoMessage()    {}
+func (*SnapshotHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b23aaa8a0aafb40a, []int{30}
+}
+func (m *SnapshotHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotHeader.Unmarshal(m, b)
+}
+func (m *SnapshotHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotHeader.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHeader.Merge(dst, src)
+}
+func (m *SnapshotHeader) XXX_Size() int {
+	return xxx_messageInfo_SnapshotHeader.Size(m)
+}
+func (m *SnapshotHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotHeader proto.InternalMessageInfo
+
+func (m *SnapshotHeader) GetGenesisTime() uint64 {
+	if m != nil {
+		return m.GenesisTime
+	}
+	return 0
+}
+
+func (m *SnapshotHeader) GetHeadBlock() []byte {
+	if m != nil {
+		return m.HeadBlock
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetFinalizedHead() []byte {
+	if m != nil {
+		return m.FinalizedHead
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetFinalizedState() *State {
+	if m != nil {
+		return m.FinalizedState
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetJustifiedHead() []byte {
+	if m != nil {
+		return m.JustifiedHead
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetJustifiedState() *State {
+	if m != nil {
+		return m.JustifiedState
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetBlockIndexRoot() []byte {
+	if m != nil {
+		return m.BlockIndexRoot
+	}
+	return nil
+}
+
+func (m *SnapshotHeader) GetNumBlockNodes() uint64 {
+	if m != nil {
+		return m.NumBlockNodes
+	}
+	return 0
+}
+
+func (m *SnapshotHeader) GetLatestAttestations() []*SnapshotLatestAttestation {
+	if m != nil {
+		return m.LatestAttestations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ProposalSignedData)(nil), "pb.ProposalSignedData")
 	proto.RegisterType((*ProposerSlashing)(nil), "pb.ProposerSlashing")
@@ -1699,6 +2081,7 @@ func init() {
 	proto.RegisterType((*Attestation)(nil), "pb.Attestation")
 	proto.RegisterType((*DepositParameters)(nil), "pb.DepositParameters")
 	proto.RegisterType((*Deposit)(nil), "pb.Deposit")
+	proto.RegisterType((*DepositRootVote)(nil), "pb.DepositRootVote")
 	proto.RegisterType((*Exit)(nil), "pb.Exit")
 	proto.RegisterType((*Block)(nil), "pb.Block")
 	proto.RegisterType((*BlockHeader)(nil), "pb.BlockHeader")
@@ -1716,115 +2099,141 @@ func init() {
 	proto.RegisterType((*VoteData)(nil), "pb.VoteData")
 	proto.RegisterType((*AggregatedVote)(nil), "pb.AggregatedVote")
 	proto.RegisterType((*ActiveProposal)(nil), "pb.ActiveProposal")
-}
-
-func init() { proto.RegisterFile("common.proto", fileDescriptor_common_f21b02def6f10ce7) }
-
-var fileDescriptor_common_f21b02def6f10ce7 = []byte{
-	// 1677 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0x1c, 0x45,
-	0x10, 0xd6, 0xae, 0x77, 0x1d, 0xbb, 0x76, 0x6d, 0xc7, 0xed, 0xd8, 0x99, 0x3c, 0xb0, 0xcc, 0x08,
-	0x39, 0x96, 0x40, 0x96, 0xbd, 0x09, 0x51, 0x10, 0x01, 0x91, 0x5d, 0xc7, 0x79, 0x10, 0x92, 0xa1,
-	0x6d, 0x85, 0x0b, 0x12, 0xb4, 0x77, 0xda, 0xeb, 0x26, 0xe3, 0xe9, 0x65, 0xba, 0xc7, 0xd8, 0xfc,
-	0x0a, 0x0e, 0xf0, 0x2b, 0x10, 0xe2, 0xc4, 0x85, 0x2b, 0x3f, 0x87, 0x3f, 0xc0, 0x81, 0x03, 0xea,
-	0xc7, 0xbc, 0x67, 0x37, 0x3e, 0x72, 0x9b, 0xfe, 0xea, 0xeb, 0xea, 0xea, 0x9a, 0x7a, 0xcd, 0x40,
-	0x77, 0xc8, 0x4f, 0x4f, 0x79, 0xb8, 0x3d, 0x8e, 0xb8, 0xe4, 0xa8, 0x39, 0x3e, 0x72, 0xbf, 0x06,
-	0xe4, 0x45, 0x7c, 0xcc, 0x05, 0x09, 0x0e, 0xd8, 0x28, 0xa4, 0xfe, 0x1e, 0x91, 0x04, 0x21, 0x68,
-	0x1d, 0x04, 0x5c, 0x3a, 0x8d, 0x8d, 0xc6, 0x56, 0x0b, 0xeb, 0x67, 0x74, 0x0d, 0xda, 0x07, 0x27,
-	0x24, 0xf2, 0x9d, 0xa6, 0x06, 0xcd, 0x02, 0xdd, 0x86, 0xf9, 0x7e, 0xc0, 0x87, 0x6f, 0x9e, 0x12,
-	0x71, 0xe2, 0xcc, 0x6c, 0x34, 0xb6, 0xba, 0x38, 0x03, 0xdc, 0x9f, 0x9b, 0x70, 0xd5, 0xa8, 0xa7,
-	0xd1, 0x41, 0x40, 0xc4, 0x09, 0x0b, 0x47, 0xe8, 0x3d, 0x58, 0x48, 0xb0, 0x67, 0xa1, 0x4f, 0xcf,
-	0xf5, 0x29, 0x0b, 0xb8, 0x08, 0xa2, 0x87, 0x09, 0x8b, 0x04, 0xca, 0xa4, 0x5d, 0x7d, 0x6c, 0xa7,
-	0xb7, 0xb6, 0x3d, 0x3e, 0xda, 0xae, 0x5a, 0x8c, 0x8b, 0x64, 0xb4, 0x5d, 0xbc, 0x16, 0x91, 0x71,
-	0x44, 0x77, 0xad, 0x7d, 0x35, 0x92, 0xf2, 0x69, 0x3d, 0xa7, 0x75, 0xf9, 0xd3, 0x7a, 0xb5, 0xa7,
-	0xf5, 0x9c, 0xf6, 0x84, 0xd3, 0x7a, 0xee, 0x3f, 0x0d, 0x58, 0xd6, 0xee, 0x20, 0x47, 0x01, 0x7d,
-	0xcd, 0x25, 0xd5, 0x4e, 0xdf, 0x83, 0x77, 0x1e, 0x8d, 0x46, 0x11, 0x1d, 0x11, 0x49, 0x53, 0xb2,
-	0xc7, 0x07, 0x3b, 0xcf, 0x42, 0x9f, 0x0d, 0xa9, 0x70, 0x1a, 0x1b, 0x33, 0x5b, 0x0b, 0x78, 0x3a,
-	0x69, 0xa2, 0x96, 0xdd, 0x44, 0x4b, 0x73, 0x8a, 0x96, 0x84, 0x84, 0xee, 0x40, 0x4b, 0xd9, 0xa4,
-	0x3d, 0xd6, 0xe9, 0xad, 0x28, 0x37, 0x3c, 0x92, 0x92, 0x0a, 0x49, 0x24, 0xe3, 0xa1, 0xf6, 0x81,
-	0x26, 0xa8, 0xab, 0x57, 0x35, 0x69, 0xef, 0x75, 0x71, 0x8d, 0xc4, 0xfd, 0x0e, 0x16, 0x07, 0x44,
-	0x8c, 0x73, 0xe1, 0xf0, 0x3e, 0xb4, 0x95, 0x0b, 0x76, 0x74, 0x18, 0x74, 0x7a, 0xab, 0xea, 0xac,
-	0x8a, 0x73, 0xb0, 0xe1, 0x24, 0xe4, 0x24, 0x1a, 0xa6, 0x91, 0x77, 0xdd, 0xbf, 0x9a, 0xb0, 0x54,
-	0xb2, 0xba, 0x36, 0xb2, 0xb7, 0x60, 0xa9, 0x4f, 0xc9, 0x90, 0x87, 0x59, 0x24, 0x37, 0xf5, 0x05,
-	0xca, 0x30, 0xda, 0x80, 0xce, 0x21, 0x89, 0x46, 0x54, 0x3e, 0x1e, 0xf3, 0xa1, 0x89, 0xf7, 0x16,
-	0xce, 0x43, 0x68, 0x1d, 0xc0, 0x2c, 0xb5, 0x1a, 0xe3, 0x87, 0x1c, 0xa2, 0x34, 0x1c, 0xf0, 0x38,
-	0x1a, 0x52, 0xa3, 0xa1, 0x6d, 0x34, 0xe4, 0x20, 0xa5, 0xc1, 0x2c, 0xb5, 0x86, 0x59, 0xa3, 0x21,
-	0x43, 0xd0, 0x26, 0x2c, 0xea, 0xd4, 0xcb, 0x8c, 0xbd, 0xa2, 0x39, 0x25, 0x34, 0xcb, 0xd7, 0xb9,
-	0x7c, 0xbe, 0xee, 0xc0, 0xca, 0x0b, 0xa2, 0x5c, 0x32, 0x88, 0xb8, 0x10, 0x01, 0x0b, 0x8d, 0x8a,
-	0x79, 0xad, 0xa2, 0x4e, 0xe4, 0x7e, 0x03, 0xb7, 0x4b, 0x4e, 0x7c, 0x14, 0xfa, 0x83, 0x58, 0x48,
-	0xee, 0x5f, 0xf4, 0x99, 0x4c, 0x43, 0xa5, 0xf1, 0xb6, 0x50, 0x59, 0x83, 0x59, 0x8f, 0x0f, 0xfa,
-	0x4c, 0x6a, 0xef, 0xce, 0x61, 0xbb, 0x72, 0xff, 0x6c, 0x40, 0x27, 0xb7, 0xe3, 0xf2, 0x0a, 0xef,
-	0xc1, 0xaa, 0x47, 0x22, 0xc9, 0x86, 0x6c, 0xac, 0x45, 0x7d, 0x26, 0x8f, 0x19, 0x0d, 0x7c, 0xfb,
-	0xf6, 0xea, 0x85, 0xea, 0x6d, 0x67, 0xd6, 0x1b, 0xbe, 0xa9, 0x0b, 0x65, 0x18, 0xb9, 0xd0, 0xcd,
-	0x47, 0xb0, 0x7d, 0x9b, 0x05, 0xcc, 0xfd, 0xa5, 0x01, 0xcb, 0x7b, 0x74, 0xcc, 0x05, 0x93, 0x1e,
-	0x89, 0xc8, 0x29, 0x95, 0x34, 0x12, 0xaa, 0x2a, 0x7a, 0xf1, 0x51, 0xc0, 0x86, 0x9f, 0xd3, 0x0b,
-	0x7d, 0x8f, 0x2e, 0xce, 0x00, 0xf4, 0x01, 0x2c, 0x7b, 0x11, 0xe7, 0xc7, 0xaf, 0x8e, 0x3d, 0x2e,
-	0x04, 0x15, 0x82, 0xf1, 0xd0, 0xda, 0x5c, 0x15, 0xa8, 0x5b, 0x7e, 0xc5, 0xe4, 0x89, 0x1f, 0x91,
-	0x1f, 0x48, 0x30, 0x88, 0xa8, 0x4f, 0x43, 0xc9, 0x48, 0x20, 0xac, 0xd5, 0xf5, 0x42, 0xf7, 0x33,
-	0xb8, 0x62, 0xcd, 0x42, 0x1f, 0x02, 0x64, 0xa6, 0xe5, 0xb3, 0xac, 0x62, 0x37, 0xce, 0x11, 0xdd,
-	0x6f, 0xa1, 0xf5, 0xf8, 0x9c, 0xc9, 0xda, 0x8c, 0xd9, 0x84, 0xc5, 0xd7, 0x24, 0x60, 0x3e, 0x91,
-	0xdc, 0xd6, 0x70, 0xd3, 0x14, 0x4a, 0xa8, 0xf2, 0x43, 0x56, 0x14, 0x6c, 0x77, 0xc8, 0x6a, 0xc1,
-	0x01, 0xb4, 0x75, 0xb8, 0xa2, 0x3b, 0x30, 0xfb, 0x94, 0x12, 0x9f, 0x46, 0xd6, 0xba, 0x25, 0x65,
-	0x9d, 0x89, 0x64, 0x0d, 0x63, 0x2b, 0x46, 0xef, 0x42, 0xab, 0xcf, 0xfd, 0x0b, 0x9b, 0xfd, 0x0b,
-	0x29, 0x4d, 0x81, 0x58, 0x8b, 0xdc, 0xdf, 0x1a, 0xd0, 0xc9, 0x6d, 0xd5, 0xe9, 0x14, 0x70, 0xf9,
-	0x32, 0x3e, 0x3d, 0xb2, 0xfa, 0x5b, 0x38, 0x87, 0x28, 0xb9, 0x47, 0x22, 0x1a, 0x4a, 0xcc, 0xb9,
-	0xb4, 0x6f, 0x21, 0x87, 0xe8, 0x2b, 0x48, 0x22, 0xa9, 0x16, 0x27, 0x57, 0x48, 0x00, 0x15, 0x22,
-	0x98, 0x84, 0x3e, 0xe1, 0x98, 0x9e, 0x51, 0x12, 0x24, 0x21, 0x92, 0xc7, 0x8a, 0x4e, 0x68, 0x97,
-	0x9d, 0xf0, 0x47, 0xd3, 0x76, 0x50, 0x65, 0x3d, 0xba, 0x0b, 0xdd, 0x5c, 0xac, 0x9b, 0x92, 0x6f,
-	0xfd, 0x91, 0xc3, 0x71, 0x81, 0x84, 0xfa, 0x3a, 0x9e, 0x0a, 0x4d, 0xd6, 0x94, 0xf9, 0x4e, 0xef,
-	0x5a, 0xd6, 0xc0, 0x32, 0x21, 0xae, 0xd2, 0xd1, 0x43, 0x58, 0x2a, 0xd6, 0x65, 0x15, 0x5f, 0x4a,
-	0x03, 0x52, 0x1a, 0x8a, 0x22, 0x5c, 0xa6, 0xa2, 0x3b, 0x30, 0x67, 0x83, 0x49, 0x38, 0x2d, 0xbd,
-	0xad, 0x93, 0x0b, 0x30, 0x9c, 0x0a, 0xd1, 0x3a, 0xb4, 0x55, 0x50, 0x09, 0xa7, 0xad, 0x59, 0x73,
-	0x8a, 0xa5, 0x00, 0x6c, 0x60, 0xb4, 0x65, 0xea, 0xbb, 0x70, 0x66, 0xb3, 0xc3, 0xd3, 0x7c, 0xf3,
-	0x95, 0xc8, 0x14, 0x77, 0xe1, 0x9e, 0xc3, 0xdc, 0x3e, 0x8f, 0xde, 0xe8, 0x42, 0xb0, 0x09, 0x8b,
-	0x5e, 0x44, 0xd5, 0xf2, 0x35, 0x8d, 0x74, 0x36, 0x99, 0xf7, 0x5c, 0x42, 0x55, 0xea, 0x7b, 0x5c,
-	0xc8, 0x3c, 0xd1, 0xc4, 0x6d, 0x19, 0x46, 0x37, 0x8d, 0x76, 0x1d, 0xf8, 0xa6, 0xca, 0xa7, 0x6b,
-	0xf7, 0xf7, 0x26, 0xcc, 0xa7, 0x71, 0xae, 0xab, 0x5a, 0x7c, 0xf4, 0x26, 0xcd, 0x73, 0xbb, 0x9a,
-	0x9c, 0xb6, 0xcd, 0x29, 0x69, 0xab, 0xb4, 0xa9, 0xe0, 0x8a, 0x85, 0x8e, 0xa4, 0x16, 0xb6, 0x2b,
-	0x74, 0x1f, 0xd6, 0x4c, 0x6d, 0x36, 0xeb, 0xc1, 0x09, 0x09, 0x47, 0x54, 0x5b, 0x67, 0x3a, 0xc8,
-	0x04, 0xa9, 0x8a, 0x3d, 0xe5, 0xd8, 0x01, 0x8f, 0x43, 0xa9, 0x7b, 0x49, 0x0b, 0x67, 0x80, 0x2a,
-	0x44, 0x2f, 0x88, 0x90, 0x1e, 0x1f, 0xe4, 0x14, 0x5e, 0xd1, 0xac, 0xaa, 0x00, 0x3d, 0x80, 0xeb,
-	0x07, 0x74, 0xc8, 0x43, 0xbf, 0xba, 0xc7, 0xb4, 0x98, 0x49, 0x62, 0xf7, 0xcc, 0xb6, 0xac, 0x01,
-	0x3f, 0x3d, 0x65, 0x52, 0x52, 0x9a, 0x35, 0xa7, 0x46, 0x69, 0x98, 0x4c, 0x29, 0x76, 0x4e, 0xc9,
-	0x00, 0xd5, 0xba, 0x0e, 0xb9, 0x24, 0x41, 0xea, 0x7b, 0x73, 0x2b, 0xf3, 0x7a, 0xea, 0x44, 0xee,
-	0x0b, 0x58, 0x2b, 0x9e, 0x2b, 0xf6, 0x79, 0xa4, 0xef, 0xd2, 0x03, 0xc8, 0x40, 0x9b, 0x65, 0x3a,
-	0xd8, 0x8a, 0x7c, 0x9c, 0x63, 0xb9, 0xaf, 0xe0, 0x96, 0xa7, 0xc2, 0x43, 0x48, 0x1a, 0xca, 0xaa,
-	0xca, 0x1d, 0x58, 0xa9, 0x11, 0xdb, 0xa1, 0xad, 0x4e, 0xe4, 0x3e, 0x81, 0xf9, 0xb4, 0xd5, 0x4e,
-	0x2a, 0xb3, 0xa5, 0x56, 0xdf, 0xac, 0x6b, 0xf5, 0xee, 0xdf, 0x0d, 0x40, 0x1e, 0x0d, 0x7d, 0x16,
-	0x8e, 0xfe, 0x97, 0x8d, 0x74, 0x13, 0x16, 0x9f, 0x85, 0xc3, 0x20, 0x56, 0xa9, 0xb5, 0x47, 0x03,
-	0x72, 0x61, 0xa3, 0xbb, 0x84, 0x56, 0xbf, 0x0c, 0xda, 0x35, 0x5f, 0x06, 0xee, 0xbf, 0xf3, 0xd0,
-	0xd6, 0x15, 0xb8, 0xd6, 0x67, 0xeb, 0x00, 0x7a, 0x8e, 0xca, 0xb7, 0xa5, 0x1c, 0xa2, 0x06, 0xb0,
-	0x27, 0x34, 0xa4, 0x82, 0x89, 0x43, 0x76, 0x4a, 0x93, 0x11, 0x2e, 0x07, 0xa1, 0xad, 0xac, 0xb2,
-	0xd8, 0xcf, 0x80, 0xae, 0x72, 0x5d, 0x82, 0xe1, 0xac, 0xee, 0x7c, 0x0c, 0xcb, 0x69, 0xc4, 0x61,
-	0x3a, 0x62, 0x42, 0x46, 0x17, 0xb6, 0xb2, 0xe9, 0xde, 0x94, 0x09, 0xab, 0x3c, 0x95, 0x7c, 0x29,
-	0xd8, 0x27, 0x01, 0x09, 0x87, 0xb6, 0xec, 0xb5, 0x70, 0x55, 0x80, 0x5e, 0x82, 0x5b, 0x51, 0x61,
-	0xa7, 0x35, 0x9d, 0x67, 0x66, 0x9c, 0x34, 0xb9, 0x7b, 0x09, 0x26, 0xfa, 0x14, 0x6e, 0x56, 0x58,
-	0x59, 0xa5, 0x30, 0xf9, 0x3c, 0x85, 0x81, 0xf6, 0x61, 0xbd, 0x22, 0xdd, 0xa3, 0x81, 0x24, 0x83,
-	0x13, 0xc2, 0xc2, 0x43, 0x36, 0xb6, 0x23, 0xe5, 0x5b, 0x58, 0x2a, 0xe5, 0x4d, 0xb3, 0xfc, 0x82,
-	0x9d, 0x3b, 0x60, 0x9a, 0x63, 0x0a, 0xa0, 0x3d, 0x58, 0x2a, 0x25, 0xb0, 0xd3, 0xd5, 0xee, 0xbd,
-	0x59, 0xcd, 0xd5, 0x24, 0x11, 0x71, 0x79, 0x8b, 0x2a, 0x9e, 0x5e, 0x44, 0xcf, 0x18, 0x8f, 0xc5,
-	0xf3, 0x58, 0x48, 0x76, 0xcc, 0xa8, 0x6f, 0xfc, 0xb5, 0x60, 0x8a, 0x67, 0xbd, 0x54, 0x85, 0x6d,
-	0x89, 0xbf, 0x68, 0xc2, 0xb6, 0xc4, 0xbb, 0x07, 0xab, 0x16, 0x19, 0x26, 0x19, 0xb2, 0xaf, 0xd3,
-	0x61, 0x49, 0xd3, 0xeb, 0x85, 0x4a, 0xfb, 0x3e, 0x0b, 0x49, 0xc0, 0x7e, 0x4c, 0xb4, 0x5f, 0x35,
-	0xda, 0x8b, 0x28, 0xfa, 0x08, 0xae, 0x96, 0xc6, 0x72, 0xe1, 0x2c, 0x67, 0x31, 0x96, 0xa2, 0xb8,
-	0x42, 0x43, 0x9f, 0xa8, 0xef, 0x52, 0x73, 0xb5, 0xdc, 0x66, 0x54, 0xb7, 0xb9, 0x86, 0xa8, 0xd2,
-	0x51, 0xbb, 0x32, 0x0d, 0xed, 0x95, 0x8d, 0x99, 0xad, 0x2e, 0x2e, 0x82, 0xa6, 0x89, 0xa8, 0x83,
-	0xd3, 0x7a, 0x44, 0x85, 0x73, 0x4d, 0x33, 0xab, 0x02, 0x84, 0xc1, 0x19, 0xc4, 0x91, 0x9a, 0xae,
-	0xf4, 0xed, 0x0a, 0xc3, 0xce, 0xaa, 0x36, 0xcc, 0x7c, 0x73, 0x57, 0xaa, 0x19, 0x9e, 0xb8, 0x0f,
-	0x1d, 0xc2, 0x8d, 0xc4, 0xfa, 0xaa, 0xd2, 0xb5, 0xa9, 0x4a, 0x27, 0x6f, 0x54, 0xf7, 0xea, 0x13,
-	0x39, 0x3c, 0xa1, 0xa6, 0xd0, 0xaa, 0x71, 0x4f, 0x38, 0xd7, 0xcd, 0xbd, 0x2a, 0x02, 0xb4, 0x03,
-	0xf3, 0xc9, 0x87, 0xbe, 0x70, 0x9c, 0xdc, 0xf0, 0x32, 0x94, 0xec, 0x8c, 0x26, 0x22, 0x9c, 0x91,
-	0xd0, 0x7d, 0xe8, 0x5a, 0x83, 0xcc, 0xc4, 0x73, 0x63, 0xe2, 0xc4, 0x53, 0xe0, 0xb9, 0xbf, 0x36,
-	0xe0, 0x56, 0x7d, 0x52, 0x99, 0x61, 0xfa, 0x01, 0x5c, 0x37, 0x6e, 0x2f, 0xc8, 0xf4, 0xf8, 0x6a,
-	0x26, 0x94, 0x49, 0xe2, 0x09, 0x53, 0xfd, 0x42, 0x65, 0xaa, 0xcf, 0x46, 0x9e, 0x99, 0xc2, 0xc8,
-	0x83, 0xa0, 0xb5, 0x1f, 0x90, 0x91, 0x2d, 0xee, 0xfa, 0xd9, 0x7d, 0x0e, 0x28, 0xef, 0x6f, 0xfa,
-	0x7d, 0x4c, 0x85, 0x9c, 0xdc, 0x70, 0x1a, 0x53, 0x1a, 0x8e, 0x1b, 0xc1, 0x5c, 0xfa, 0xb3, 0x04,
-	0x41, 0xeb, 0xf0, 0x62, 0x4c, 0xed, 0xbf, 0x23, 0xfd, 0xac, 0x87, 0x27, 0x15, 0x9a, 0xc9, 0x3f,
-	0x0e, 0xbb, 0x52, 0x2d, 0x41, 0xbd, 0x06, 0x1e, 0xe6, 0x7e, 0x52, 0xe5, 0x10, 0x35, 0xec, 0x25,
-	0x1d, 0x46, 0xdb, 0xbe, 0x80, 0xd3, 0xb5, 0x1a, 0x5d, 0x8a, 0x6f, 0x03, 0x6d, 0x14, 0xba, 0xaa,
-	0x6e, 0x0d, 0xe9, 0x8f, 0x07, 0xd3, 0x4e, 0x0b, 0x03, 0x7f, 0xb3, 0x34, 0xf0, 0xeb, 0x26, 0x97,
-	0xbf, 0x9e, 0x35, 0xa8, 0x08, 0xba, 0x3f, 0x35, 0x60, 0xb1, 0x18, 0x3b, 0x97, 0x38, 0xb8, 0xa2,
-	0xba, 0x59, 0xa3, 0x5a, 0x7f, 0x11, 0x49, 0x12, 0x15, 0xfe, 0x61, 0xe4, 0x10, 0xe5, 0xc6, 0x2f,
-	0x63, 0x1a, 0x53, 0x5f, 0x3b, 0x63, 0x0e, 0xdb, 0xd5, 0xd1, 0xac, 0xfe, 0x6b, 0x78, 0xf7, 0xbf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xe0, 0x7b, 0x24, 0x5b, 0x45, 0x14, 0x00, 0x00,
+	proto.RegisterType((*Checkpoint)(nil), "pb.Checkpoint")
+	proto.RegisterType((*SnapshotBlockNode)(nil), "pb.SnapshotBlockNode")
+	proto.RegisterType((*SnapshotLatestAttestation)(nil), "pb.SnapshotLatestAttestation")
+	proto.RegisterType((*SnapshotHeader)(nil), "pb.SnapshotHeader")
+}
+
+func init() { proto.RegisterFile("common.proto", fileDescriptor_common_b23aaa8a0aafb40a) }
+
+var fileDescriptor_common_b23aaa8a0aafb40a = []byte{
+	// 2020 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0x4f, 0x73, 0x1b, 0x49,
+	0x15, 0x2f, 0xc9, 0x92, 0x63, 0x3f, 0xc9, 0x76, 0xdc, 0x49, 0x9c, 0x89, 0xe3, 0xcd, 0x9a, 0x29,
+	0x2a, 0x71, 0x15, 0x54, 0xca, 0xd6, 0x2e, 0x5b, 0x4b, 0xb1, 0x40, 0x45, 0xf2, 0x7a, 0x93, 0x25,
+	0xeb, 0xd5, 0xb6, 0x5d, 0xe1, 0x42, 0x15, 0xb4, 0x35, 0x1d, 0xa9, 0xc9, 0x68, 0x5a, 0x4c, 0xb7,
+	0x42, 0xcc, 0x99, 0x3b, 0x1c, 0xe0, 0xcc, 0x07, 0xa0, 0x28, 0xee, 0x5c, 0xa9, 0xe2, 0x2b, 0xf0,
+	0x21, 0xf8, 0x02, 0x1c, 0xa9, 0xd7, 0xdd, 0x33, 0xd3, 0xf3, 0x47, 0xde, 0x1c, 0xb9, 0xa9, 0x7f,
+	0xef, 0xcd, 0xeb, 0xd7, 0x6f, 0x7e, 0xef, 0x4f, 0x8f, 0xa0, 0x3f, 0x91, 0xf3, 0xb9, 0x4c, 0x9e,
+	0x2e, 0x52, 0xa9, 0x25, 0x69, 0x2f, 0xae, 0xc2, 0x5f, 0x00, 0x19, 0xa7, 0x72, 0x21, 0x15, 0x8b,
+	0x2f, 0xc4, 0x34, 0xe1, 0xd1, 0x29, 0xd3, 0x8c, 0x10, 0xe8, 0x5c, 0xc4, 0x52, 0x07, 0xad, 0xc3,
+	0xd6, 0x51, 0x87, 0x9a, 0xdf, 0xe4, 0x2e, 0x74, 0x2f, 0x66, 0x2c, 0x8d, 0x82, 0xb6, 0x01, 0xed,
+	0x82, 0x1c, 0xc0, 0xe6, 0x30, 0x96, 0x93, 0x37, 0xcf, 0x99, 0x9a, 0x05, 0x6b, 0x87, 0xad, 0xa3,
+	0x3e, 0x2d, 0x80, 0xf0, 0x4f, 0x6d, 0xb8, 0x6d, 0xcd, 0xf3, 0xf4, 0x22, 0x66, 0x6a, 0x26, 0x92,
+	0x29, 0xf9, 0x2e, 0x6c, 0x65, 0xd8, 0x8b, 0x24, 0xe2, 0xef, 0xcc, 0x2e, 0x5b, 0xb4, 0x0c, 0x92,
+	0xcf, 0x32, 0x2d, 0x16, 0xa3, 0x4b, 0x27, 0x66, 0xdb, 0xde, 0x60, 0xef, 0xe9, 0xe2, 0xea, 0x69,
+	0xdd, 0x63, 0x5a, 0x56, 0x26, 0x4f, 0xcb, 0xc7, 0x62, 0x7a, 0x99, 0xf2, 0x13, 0xe7, 0x5f, 0x83,
+	0xa4, 0xba, 0xdb, 0x20, 0xe8, 0xbc, 0xff, 0x6e, 0x83, 0xc6, 0xdd, 0x06, 0x41, 0x77, 0xc5, 0x6e,
+	0x83, 0xf0, 0xbf, 0x2d, 0xd8, 0x35, 0xe1, 0x60, 0x57, 0x31, 0x7f, 0x25, 0x35, 0x37, 0x41, 0x3f,
+	0x85, 0x0f, 0x9e, 0x4d, 0xa7, 0x29, 0x9f, 0x32, 0xcd, 0x73, 0xe5, 0xb1, 0x1c, 0x1d, 0xbf, 0x48,
+	0x22, 0x31, 0xe1, 0x2a, 0x68, 0x1d, 0xae, 0x1d, 0x6d, 0xd1, 0x9b, 0x95, 0x56, 0x5a, 0x39, 0xc9,
+	0xac, 0xb4, 0x6f, 0xb0, 0x92, 0x29, 0x91, 0x27, 0xd0, 0x41, 0x9f, 0x4c, 0xc4, 0x7a, 0x83, 0x3b,
+	0x18, 0x86, 0x67, 0x5a, 0x73, 0xa5, 0x99, 0x16, 0x32, 0x31, 0x31, 0x30, 0x0a, 0x78, 0xf4, 0xba,
+	0x25, 0x13, 0xbd, 0x3e, 0x6d, 0x90, 0x84, 0xbf, 0x86, 0xed, 0x11, 0x53, 0x0b, 0x8f, 0x0e, 0xdf,
+	0x83, 0x2e, 0x86, 0xe0, 0xd8, 0xd0, 0xa0, 0x37, 0xb8, 0x87, 0x7b, 0xd5, 0x82, 0x43, 0xad, 0x4e,
+	0xa6, 0x9c, 0xb1, 0xe1, 0x26, 0xe5, 0x93, 0xf0, 0x9f, 0x6d, 0xd8, 0xa9, 0x78, 0xdd, 0xc8, 0xec,
+	0x23, 0xd8, 0x19, 0x72, 0x36, 0x91, 0x49, 0xc1, 0xe4, 0xb6, 0x39, 0x40, 0x15, 0x26, 0x87, 0xd0,
+	0xbb, 0x64, 0xe9, 0x94, 0xeb, 0xcf, 0x17, 0x72, 0x62, 0xf9, 0xde, 0xa1, 0x3e, 0x44, 0x1e, 0x01,
+	0xd8, 0xa5, 0x31, 0x63, 0xe3, 0xe0, 0x21, 0x68, 0xe1, 0x42, 0x2e, 0xd3, 0x09, 0xb7, 0x16, 0xba,
+	0xd6, 0x82, 0x07, 0xa1, 0x05, 0xbb, 0x34, 0x16, 0xd6, 0xad, 0x85, 0x02, 0x21, 0x8f, 0x61, 0xdb,
+	0xa4, 0x5e, 0xe1, 0xec, 0x2d, 0xa3, 0x53, 0x41, 0x8b, 0x7c, 0xdd, 0xf0, 0xf3, 0xf5, 0x18, 0xee,
+	0xbc, 0x64, 0x18, 0x92, 0x51, 0x2a, 0x95, 0x8a, 0x45, 0x62, 0x4d, 0x6c, 0x1a, 0x13, 0x4d, 0xa2,
+	0xf0, 0x97, 0x70, 0x50, 0x09, 0xe2, 0xb3, 0x24, 0x1a, 0x2d, 0x95, 0x96, 0xd1, 0xf5, 0x50, 0xe8,
+	0x9c, 0x2a, 0xad, 0x6f, 0xa3, 0xca, 0x1e, 0xac, 0x8f, 0xe5, 0x68, 0x28, 0xb4, 0x89, 0xee, 0x06,
+	0x75, 0xab, 0xf0, 0x1f, 0x2d, 0xe8, 0x79, 0x4f, 0xbc, 0xbf, 0xc1, 0x8f, 0xe1, 0xde, 0x98, 0xa5,
+	0x5a, 0x4c, 0xc4, 0xc2, 0x88, 0x86, 0x42, 0xbf, 0x16, 0x3c, 0x8e, 0xdc, 0xdb, 0x6b, 0x16, 0xe2,
+	0xdb, 0x2e, 0xbc, 0xb7, 0xfa, 0xb6, 0x2e, 0x54, 0x61, 0x12, 0x42, 0xdf, 0x67, 0xb0, 0x7b, 0x9b,
+	0x25, 0x2c, 0xfc, 0x73, 0x0b, 0x76, 0x4f, 0xf9, 0x42, 0x2a, 0xa1, 0xc7, 0x2c, 0x65, 0x73, 0xae,
+	0x79, 0xaa, 0xb0, 0x2a, 0x8e, 0x97, 0x57, 0xb1, 0x98, 0xfc, 0x8c, 0x5f, 0x9b, 0x73, 0xf4, 0x69,
+	0x01, 0x90, 0xef, 0xc3, 0xee, 0x38, 0x95, 0xf2, 0xf5, 0xd7, 0xaf, 0xc7, 0x52, 0x29, 0xae, 0x94,
+	0x90, 0x89, 0xf3, 0xb9, 0x2e, 0xc0, 0x53, 0xfe, 0x5c, 0xe8, 0x59, 0x94, 0xb2, 0xdf, 0xb2, 0x78,
+	0x94, 0xf2, 0x88, 0x27, 0x5a, 0xb0, 0x58, 0x39, 0xaf, 0x9b, 0x85, 0xe1, 0xef, 0x5b, 0x70, 0xcb,
+	0xf9, 0x45, 0x7e, 0x00, 0x50, 0xf8, 0xe6, 0xa7, 0x59, 0xcd, 0x71, 0xea, 0x29, 0xe2, 0xfb, 0x7a,
+	0x36, 0x97, 0xcb, 0x44, 0xbb, 0x8a, 0xef, 0x56, 0x48, 0x2c, 0x5b, 0xb7, 0x2d, 0xfd, 0xed, 0x02,
+	0x51, 0xe3, 0x7b, 0xd0, 0x39, 0x5c, 0x3b, 0xea, 0x53, 0xbb, 0x08, 0xbf, 0x81, 0x1d, 0xb7, 0x09,
+	0x95, 0x52, 0x63, 0x5a, 0x62, 0x06, 0x78, 0x90, 0x8b, 0x8e, 0x0f, 0x61, 0xf4, 0x50, 0x73, 0xe4,
+	0xed, 0x5d, 0x00, 0xe1, 0xaf, 0xa0, 0xf3, 0xf9, 0x3b, 0xa1, 0x1b, 0x33, 0xf9, 0x31, 0x6c, 0xbf,
+	0x62, 0xb1, 0x88, 0x98, 0x96, 0xae, 0xb7, 0xd8, 0xc7, 0x2b, 0x28, 0xee, 0x50, 0x14, 0x2b, 0xd7,
+	0xb5, 0x8a, 0x1a, 0x75, 0x01, 0x5d, 0x93, 0x46, 0xe4, 0x09, 0xac, 0x3f, 0xe7, 0x2c, 0xe2, 0xa9,
+	0x0b, 0xda, 0x0e, 0x06, 0xcd, 0x66, 0x98, 0x81, 0xa9, 0x13, 0x93, 0xef, 0x40, 0x67, 0x28, 0xa3,
+	0x6b, 0x57, 0x95, 0xb6, 0x72, 0x35, 0x04, 0xa9, 0x11, 0x85, 0x7f, 0x6b, 0x41, 0xcf, 0x7b, 0xd4,
+	0xa4, 0x79, 0x2c, 0xf5, 0xf9, 0x72, 0x7e, 0xe5, 0xec, 0x77, 0xa8, 0x87, 0xa0, 0x7c, 0xcc, 0x52,
+	0x9e, 0xd8, 0x28, 0x59, 0x76, 0x78, 0x88, 0x39, 0x82, 0x66, 0x9a, 0x1b, 0x71, 0x76, 0x84, 0x0c,
+	0x40, 0xea, 0x52, 0x96, 0x44, 0x4c, 0x52, 0xfe, 0x96, 0xb3, 0x38, 0xa3, 0xae, 0x8f, 0x95, 0x83,
+	0xd0, 0xad, 0x06, 0xe1, 0xdf, 0x6d, 0xd7, 0xd9, 0xd1, 0x7b, 0xf2, 0x11, 0xf4, 0xbd, 0x1c, 0xb4,
+	0xad, 0xc8, 0xc5, 0xc3, 0xc3, 0x69, 0x49, 0x89, 0x0c, 0x61, 0x37, 0xeb, 0xe9, 0x59, 0xb5, 0xb7,
+	0xed, 0xa7, 0x37, 0xb8, 0x5b, 0x34, 0xd6, 0x42, 0x48, 0xeb, 0xea, 0xe4, 0x33, 0xd8, 0x29, 0xf7,
+	0x0b, 0xe4, 0x3d, 0x5a, 0x20, 0x68, 0xa1, 0x2c, 0xa2, 0x55, 0x55, 0xf2, 0x04, 0x36, 0x1c, 0xb1,
+	0x94, 0xe1, 0x65, 0x6f, 0xd0, 0xf3, 0x78, 0x4f, 0x73, 0x21, 0x79, 0x04, 0x5d, 0x24, 0x95, 0x0a,
+	0xba, 0x46, 0x6b, 0x03, 0xb5, 0x10, 0xa0, 0x16, 0x26, 0x47, 0xb6, 0xef, 0xa8, 0x60, 0xbd, 0xd8,
+	0x3c, 0xaf, 0x03, 0x11, 0x8a, 0x6c, 0xd3, 0x51, 0x55, 0x7a, 0xdf, 0xaa, 0xd1, 0x3b, 0x7c, 0x07,
+	0x1b, 0x67, 0x32, 0x7d, 0x63, 0x4a, 0xd8, 0x63, 0xd8, 0x1e, 0xa7, 0x1c, 0x97, 0xaf, 0x78, 0x6a,
+	0xea, 0x80, 0x65, 0x42, 0x05, 0xc5, 0xa2, 0x35, 0x96, 0x4a, 0xfb, 0x8a, 0x96, 0xd9, 0x55, 0x98,
+	0xec, 0x5b, 0xeb, 0x17, 0xb1, 0xa3, 0x45, 0x87, 0xe6, 0xeb, 0xf0, 0xef, 0x6d, 0xd8, 0xcc, 0x33,
+	0xc1, 0xd4, 0xe3, 0xe5, 0xd5, 0x9b, 0xbc, 0x42, 0xb9, 0xd5, 0xea, 0x82, 0xd3, 0xbe, 0xa1, 0xe0,
+	0xa0, 0x35, 0xa4, 0xdf, 0x52, 0x19, 0xae, 0x75, 0xa8, 0x5b, 0x91, 0x4f, 0x60, 0xcf, 0x76, 0x15,
+	0xbb, 0x1e, 0xcd, 0x58, 0x32, 0xe5, 0xc6, 0x3b, 0xdb, 0xfb, 0x56, 0x48, 0x91, 0x9d, 0x18, 0x7a,
+	0x5b, 0x04, 0xd6, 0x6d, 0x11, 0xc8, 0x01, 0x2c, 0xa1, 0x2f, 0x99, 0xd2, 0x63, 0x39, 0xf2, 0x0c,
+	0xde, 0x32, 0x5a, 0x75, 0x01, 0xf9, 0x14, 0xee, 0x5f, 0xf0, 0x89, 0x4c, 0xa2, 0xfa, 0x33, 0xb6,
+	0x39, 0xae, 0x12, 0x87, 0x6f, 0x5d, 0xb3, 0x1d, 0xc9, 0xf9, 0x5c, 0x68, 0xcd, 0x79, 0xd1, 0x56,
+	0x5b, 0x95, 0x31, 0x38, 0x57, 0x71, 0x13, 0x56, 0x01, 0x60, 0xd3, 0xbd, 0x94, 0x9a, 0xc5, 0x79,
+	0xec, 0xed, 0xa9, 0xec, 0xeb, 0x69, 0x12, 0x85, 0x2f, 0x61, 0xaf, 0xbc, 0xaf, 0x3a, 0x93, 0xa9,
+	0x39, 0xcb, 0x00, 0xa0, 0x00, 0x83, 0x56, 0x41, 0xc7, 0xb2, 0x3e, 0xf5, 0xb4, 0xc2, 0xaf, 0xe1,
+	0xe1, 0x18, 0xe9, 0xa1, 0x34, 0x4f, 0x74, 0xdd, 0xe4, 0x31, 0xdc, 0x69, 0x10, 0xbb, 0x71, 0xb3,
+	0x49, 0x14, 0x7e, 0x01, 0x9b, 0xf9, 0x90, 0xb0, 0xaa, 0x10, 0x57, 0x86, 0x94, 0x76, 0xd3, 0x90,
+	0x12, 0xfe, 0xa7, 0x05, 0x64, 0xcc, 0x93, 0x48, 0x24, 0xd3, 0xff, 0xcb, 0x11, 0xe0, 0x31, 0x6c,
+	0xbf, 0x48, 0x26, 0xf1, 0x12, 0x53, 0xeb, 0x94, 0xc7, 0xec, 0xda, 0xb1, 0xbb, 0x82, 0xd6, 0xef,
+	0x34, 0xdd, 0x86, 0x3b, 0x4d, 0xf8, 0x87, 0x1e, 0x74, 0x4d, 0x8d, 0x6e, 0x8c, 0xd9, 0x23, 0x00,
+	0x33, 0x01, 0xfa, 0x8d, 0xcb, 0x43, 0xb0, 0xb2, 0x7c, 0xc1, 0x13, 0xae, 0x84, 0xba, 0x14, 0x73,
+	0x9e, 0x0d, 0x9f, 0x1e, 0x44, 0x8e, 0x8a, 0xca, 0xe2, 0x2e, 0x30, 0x7d, 0x0c, 0x5d, 0x86, 0xd1,
+	0x5c, 0x4a, 0x7e, 0x04, 0xbb, 0x39, 0xe3, 0x28, 0x9f, 0x0a, 0xa5, 0xd3, 0x6b, 0x57, 0xfb, 0x4c,
+	0xf7, 0x2a, 0x84, 0x75, 0x3d, 0x4c, 0xbe, 0x1c, 0x1c, 0xb2, 0x98, 0x25, 0x13, 0x57, 0x18, 0x3b,
+	0xb4, 0x2e, 0x20, 0xe7, 0x10, 0xd6, 0x4c, 0xb8, 0x39, 0xd3, 0xe4, 0x99, 0x1d, 0x84, 0x6d, 0xee,
+	0xbe, 0x87, 0x26, 0xf9, 0x09, 0xec, 0xd7, 0xb4, 0x8a, 0x4a, 0x61, 0xf3, 0xf9, 0x06, 0x0d, 0x72,
+	0x06, 0x8f, 0x6a, 0xd2, 0x53, 0x1e, 0x6b, 0x36, 0x9a, 0x31, 0x91, 0x5c, 0x8a, 0x85, 0x1b, 0x86,
+	0xbf, 0x45, 0x0b, 0x53, 0xde, 0xb6, 0xd3, 0xaf, 0xc4, 0xbb, 0x00, 0x6c, 0xfb, 0xcc, 0x01, 0x72,
+	0x0a, 0x3b, 0x95, 0x04, 0x0e, 0xfa, 0x26, 0xbc, 0xfb, 0xf5, 0x5c, 0xcd, 0x12, 0x91, 0x56, 0x1f,
+	0xc1, 0xe2, 0x39, 0x4e, 0xf9, 0x5b, 0x21, 0x97, 0xea, 0xcb, 0xa5, 0xd2, 0xe2, 0xb5, 0xe0, 0x91,
+	0x8d, 0xd7, 0x96, 0x2d, 0x9e, 0xcd, 0x52, 0xa4, 0x6d, 0x45, 0x7f, 0xdb, 0xd2, 0xb6, 0xa2, 0xf7,
+	0x31, 0xdc, 0x73, 0xc8, 0x24, 0xcb, 0x90, 0x33, 0x93, 0x0e, 0x3b, 0x46, 0xbd, 0x59, 0x88, 0xd6,
+	0xcf, 0x44, 0xc2, 0x62, 0xf1, 0xbb, 0xcc, 0xfa, 0x6d, 0x6b, 0xbd, 0x8c, 0x92, 0x1f, 0xc2, 0xed,
+	0xca, 0x85, 0x42, 0x05, 0xbb, 0x05, 0xc7, 0x72, 0x94, 0xd6, 0xd4, 0xc8, 0x8f, 0x81, 0x64, 0x47,
+	0xf3, 0x1e, 0x26, 0x4d, 0x0f, 0x37, 0x28, 0x62, 0x3a, 0x9a, 0x50, 0xe6, 0xd4, 0xbe, 0x63, 0x86,
+	0xd2, 0x32, 0x68, 0x9b, 0x08, 0x6e, 0x9c, 0xd7, 0x23, 0xae, 0x82, 0xbb, 0x46, 0xb3, 0x2e, 0x20,
+	0x14, 0x82, 0xd1, 0x32, 0xc5, 0xf9, 0xcb, 0x9c, 0xae, 0x34, 0x0e, 0xdd, 0x3b, 0x5c, 0xcb, 0xbf,
+	0x16, 0xd4, 0xaa, 0x19, 0x5d, 0xf9, 0x1c, 0xb9, 0x84, 0x07, 0x99, 0xf7, 0x75, 0xa3, 0x7b, 0x37,
+	0x1a, 0x5d, 0xfd, 0x20, 0x9e, 0x6b, 0xc8, 0xf4, 0x64, 0xc6, 0x6d, 0xa1, 0xa5, 0x52, 0x6a, 0x15,
+	0xdc, 0xb7, 0xe7, 0xaa, 0x09, 0xc8, 0x31, 0x6c, 0x66, 0x9f, 0x28, 0x54, 0x10, 0x78, 0xe3, 0xcd,
+	0x44, 0x8b, 0xb7, 0x3c, 0x13, 0xd1, 0x42, 0x89, 0x7c, 0x02, 0x7d, 0xe7, 0x90, 0x9d, 0x89, 0x1e,
+	0xac, 0x9c, 0x89, 0x4a, 0x7a, 0x45, 0xbc, 0xfd, 0x01, 0x69, 0xdf, 0xde, 0x7b, 0x6a, 0x02, 0x1c,
+	0x61, 0xdd, 0xd2, 0x16, 0xc4, 0x87, 0x86, 0x63, 0x25, 0x8c, 0xfc, 0x14, 0x6e, 0x57, 0xae, 0x17,
+	0x2a, 0x38, 0x38, 0x5c, 0xcb, 0x7a, 0x46, 0x45, 0x46, 0x6b, 0xca, 0xe1, 0x5f, 0x5b, 0xf0, 0xb0,
+	0x39, 0xcf, 0xed, 0x0d, 0xe0, 0x53, 0xb8, 0x6f, 0x3d, 0x2b, 0xc9, 0xbc, 0x8b, 0xcb, 0x2a, 0xf1,
+	0x8a, 0xab, 0xc8, 0x56, 0xed, 0x2a, 0x52, 0x4c, 0x61, 0x6b, 0xa5, 0x29, 0x8c, 0x40, 0xe7, 0x2c,
+	0x66, 0x53, 0xd7, 0x6f, 0xcc, 0xef, 0xf0, 0x4b, 0x20, 0x3e, 0x05, 0xf8, 0x6f, 0x96, 0x5c, 0xe9,
+	0xd5, 0x3d, 0xb0, 0x75, 0x43, 0x0f, 0x0c, 0x53, 0xd8, 0xc8, 0xbf, 0x3c, 0x11, 0xe8, 0x5c, 0x5e,
+	0x2f, 0xb8, 0xfb, 0x10, 0x67, 0x7e, 0x9b, 0x79, 0x0e, 0xb3, 0x25, 0xfb, 0x60, 0xe4, 0x56, 0xd8,
+	0xa5, 0x90, 0x19, 0x32, 0xf1, 0xbe, 0xf8, 0x79, 0x08, 0xce, 0x9f, 0x59, 0xd3, 0x33, 0xbe, 0x6f,
+	0xd1, 0x7c, 0x8d, 0xd3, 0x54, 0x99, 0x20, 0xe4, 0xb0, 0xd4, 0xe8, 0x4d, 0xb7, 0xca, 0xbc, 0x72,
+	0x1d, 0xbe, 0x74, 0x4b, 0x69, 0x57, 0x6e, 0x29, 0xa6, 0xef, 0xfa, 0xc7, 0x73, 0x0e, 0x95, 0xc1,
+	0xf0, 0x8f, 0x2d, 0xd8, 0x2e, 0xd3, 0xf9, 0x3d, 0x36, 0xae, 0x99, 0x6e, 0x37, 0x98, 0x36, 0xd7,
+	0x38, 0xcd, 0xd2, 0xd2, 0x07, 0x21, 0x0f, 0xc1, 0x30, 0x7e, 0xb3, 0xe4, 0x4b, 0x1e, 0x99, 0x60,
+	0x6c, 0x50, 0xb7, 0x0a, 0xcf, 0x01, 0x46, 0x33, 0x3e, 0x79, 0xb3, 0x90, 0x22, 0xd1, 0xe4, 0x43,
+	0x77, 0xe3, 0x74, 0xee, 0x6c, 0xe6, 0x17, 0x48, 0x6a, 0x71, 0xf2, 0xa1, 0x1b, 0x1c, 0x82, 0x76,
+	0xa1, 0x60, 0x00, 0x6a, 0xf1, 0xf0, 0x5f, 0xf8, 0x49, 0x31, 0x61, 0x0b, 0x35, 0x93, 0xb6, 0x6a,
+	0x9d, 0xcb, 0xc8, 0x8c, 0x19, 0xe6, 0x35, 0x59, 0x26, 0x98, 0xdf, 0xe8, 0xd1, 0x73, 0x2e, 0xa6,
+	0xb3, 0xfc, 0x5a, 0x6f, 0x57, 0xf9, 0x48, 0xb2, 0xe6, 0x8d, 0x24, 0x48, 0x4e, 0x73, 0xe5, 0x74,
+	0x17, 0x48, 0xb7, 0x2a, 0x5f, 0x3e, 0xbb, 0xd5, 0xcb, 0xe7, 0x3e, 0x6c, 0x8c, 0x66, 0x22, 0x8e,
+	0x52, 0x9e, 0x98, 0xb1, 0xa0, 0x4f, 0xf3, 0x75, 0x71, 0xd2, 0x5b, 0xcd, 0x27, 0x0d, 0x63, 0x78,
+	0x90, 0x9d, 0xc3, 0xa6, 0x96, 0x3f, 0x17, 0x1e, 0x78, 0xf7, 0x17, 0xc7, 0xd6, 0x02, 0x20, 0x27,
+	0xa5, 0xef, 0x48, 0x2e, 0x54, 0xb5, 0x3b, 0xaa, 0xaf, 0x13, 0xfe, 0x65, 0x0d, 0xb6, 0xb3, 0xed,
+	0xdc, 0xc5, 0xbc, 0x32, 0x66, 0xb5, 0xea, 0x63, 0xd6, 0x01, 0x6c, 0xa2, 0xae, 0x3d, 0x87, 0xa3,
+	0x64, 0x0e, 0x20, 0x6f, 0xf2, 0x3e, 0x88, 0x68, 0x46, 0xc9, 0x12, 0x48, 0x4e, 0xbc, 0x1e, 0x6a,
+	0xdf, 0x6c, 0xa7, 0xfa, 0x66, 0x2b, 0x0a, 0x68, 0x38, 0x6f, 0xdf, 0xc6, 0xb0, 0x0d, 0x7c, 0x19,
+	0x44, 0xc3, 0x39, 0x60, 0x0d, 0xaf, 0xd7, 0x0c, 0x97, 0x15, 0xb0, 0x54, 0x19, 0xd7, 0x4d, 0x41,
+	0xf2, 0x6e, 0xad, 0x15, 0x14, 0x1d, 0x38, 0x5f, 0xce, 0x73, 0x76, 0x29, 0x37, 0x6c, 0x95, 0x41,
+	0xf2, 0x15, 0x90, 0xda, 0x8b, 0x53, 0xc1, 0xa6, 0xa9, 0xca, 0x1f, 0x18, 0x27, 0x56, 0xbd, 0x5e,
+	0xda, 0xf0, 0xe0, 0xd5, 0xba, 0xf9, 0xaf, 0xe2, 0xa3, 0xff, 0x0d, 0x00, 0x89, 0xe0, 0x0a, 0x7d,
+	0xbb, 0x18, 0x00, 0x00,
 }
@@ -24,6 +24,8 @@ type VersionMessage struct {
 	PeerInfo             []byte   `protobuf:"bytes,3,opt,name=PeerInfo,proto3" json:"PeerInfo,omitempty"`
 	GenesisHash          []byte   `protobuf:"bytes,4,opt,name=GenesisHash,proto3" json:"GenesisHash,omitempty"`
 	Height               uint64   `protobuf:"varint,5,opt,name=Height,proto3" json:"Height,omitempty"`
+	TipSlot              uint64   `protobuf:"varint,6,opt,name=TipSlot,proto3" json:"TipSlot,omitempty"`
+	ForkVersion          uint64   `protobuf:"varint,7,opt,name=ForkVersion,proto3" json:"ForkVersion,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -33,7 +35,7 @@ func (m *VersionMessage) Reset()         { *m = VersionMessage{} }
 func (m *VersionMessage) String() string { return proto.CompactTextString(m) }
 func (*VersionMessage) ProtoMessage()    {}
 func (*VersionMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{0}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{0}
 }
 func (m *VersionMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionMessage.Unmarshal(m, b)
@@ -88,6 +90,20 @@ func (m *VersionMessage) GetHeight() uint64 {
 	return 0
 }
 
+func (m *VersionMessage) GetTipSlot() uint64 {
+	if m != nil {
+		return m.TipSlot
+	}
+	return 0
+}
+
+func (m *VersionMessage) GetForkVersion() uint64 {
+	if m != nil {
+		return m.ForkVersion
+	}
+	return 0
+}
+
 type PingMessage struct {
 	Nonce                uint64   `protobuf:"varint,1,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -99,7 +115,7 @@ func (m *PingMessage) Reset()         { *m = PingMessage{} }
 func (m *PingMessage) String() string { return proto.CompactTextString(m) }
 func (*PingMessage) ProtoMessage()    {}
 func (*PingMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{1}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{1}
 }
 func (m *PingMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingMessage.Unmarshal(m, b)
@@ -137,7 +153,7 @@ func (m *PongMessage) Reset()         { *m = PongMessage{} }
 func (m *PongMessage) String() string { return proto.CompactTextString(m) }
 func (*PongMessage) ProtoMessage()    {}
 func (*PongMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{2}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{2}
 }
 func (m *PongMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PongMessage.Unmarshal(m, b)
@@ -175,7 +191,7 @@ func (m *RejectMessage) Reset()         { *m = RejectMessage{} }
 func (m *RejectMessage) String() string { return proto.CompactTextString(m) }
 func (*RejectMessage) ProtoMessage()    {}
 func (*RejectMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{3}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{3}
 }
 func (m *RejectMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RejectMessage.Unmarshal(m, b)
@@ -214,7 +230,7 @@ func (m *AttestationMempoolItem) Reset()         { *m = AttestationMempoolItem{}
 func (m *AttestationMempoolItem) String() string { return proto.CompactTextString(m) }
 func (*AttestationMempoolItem) ProtoMessage()    {}
 func (*AttestationMempoolItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{4}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{4}
 }
 func (m *AttestationMempoolItem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationMempoolItem.Unmarshal(m, b)
@@ -260,7 +276,7 @@ func (m *GetMempoolMessage) Reset()         { *m = GetMempoolMessage{} }
 func (m *GetMempoolMessage) String() string { return proto.CompactTextString(m) }
 func (*GetMempoolMessage) ProtoMessage()    {}
 func (*GetMempoolMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{5}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{5}
 }
 func (m *GetMempoolMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetMempoolMessage.Unmarshal(m, b)
@@ -290,6 +306,7 @@ func (m *GetMempoolMessage) GetAttestations() []*AttestationMempoolItem {
 type GetBlockMessage struct {
 	LocatorHashes        [][]byte `protobuf:"bytes,1,rep,name=LocatorHashes,proto3" json:"LocatorHashes,omitempty"`
 	HashStop             []byte   `protobuf:"bytes,2,opt,name=HashStop,proto3" json:"HashStop,omitempty"`
+	RequestID            uint64   `protobuf:"varint,3,opt,name=RequestID,proto3" json:"RequestID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -299,7 +316,7 @@ func (m *GetBlockMessage) Reset()         { *m = GetBlockMessage{} }
 func (m *GetBlockMessage) String() string { return proto.CompactTextString(m) }
 func (*GetBlockMessage) ProtoMessage()    {}
 func (*GetBlockMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{6}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{6}
 }
 func (m *GetBlockMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockMessage.Unmarshal(m, b)
@@ -333,6 +350,68 @@ func (m *GetBlockMessage) GetHashStop() []byte {
 	return nil
 }
 
+func (m *GetBlockMessage) GetRequestID() uint64 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+// Requests the blocks in the main chain between two slots (inclusive)
+type GetBlocksBySlotMessage struct {
+	RequestID            uint64   `protobuf:"varint,1,opt,name=RequestID,proto3" json:"RequestID,omitempty"`
+	StartSlot            uint64   `protobuf:"varint,2,opt,name=StartSlot,proto3" json:"StartSlot,omitempty"`
+	EndSlot              uint64   `protobuf:"varint,3,opt,name=EndSlot,proto3" json:"EndSlot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlocksBySlotMessage) Reset()         { *m = GetBlocksBySlotMessage{} }
+func (m *GetBlocksBySlotMessage) String() string { return proto.CompactTextString(m) }
+func (*GetBlocksBySlotMessage) ProtoMessage()    {}
+func (*GetBlocksBySlotMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{7}
+}
+func (m *GetBlocksBySlotMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlocksBySlotMessage.Unmarshal(m, b)
+}
+func (m *GetBlocksBySlotMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlocksBySlotMessage.Marshal(b, m, deterministic)
+}
+func (dst *GetBlocksBySlotMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlocksBySlotMessage.Merge(dst, src)
+}
+func (m *GetBlocksBySlotMessage) XXX_Size() int {
+	return xxx_messageInfo_GetBlocksBySlotMessage.Size(m)
+}
+func (m *GetBlocksBySlotMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlocksBySlotMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlocksBySlotMessage proto.InternalMessageInfo
+
+func (m *GetBlocksBySlotMessage) GetRequestID() uint64 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *GetBlocksBySlotMessage) GetStartSlot() uint64 {
+	if m != nil {
+		return m.StartSlot
+	}
+	return 0
+}
+
+func (m *GetBlocksBySlotMessage) GetEndSlot() uint64 {
+	if m != nil {
+		return m.EndSlot
+	}
+	return 0
+}
+
 type MempoolMessage struct {
 	Attestations         []*Attestation `protobuf:"bytes,1,rep,name=Attestations,proto3" json:"Attestations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -344,7 +423,7 @@ func (m *MempoolMessage) Reset()         { *m = MempoolMessage{} }
 func (m *MempoolMessage) String() string { return proto.CompactTextString(m) }
 func (*MempoolMessage) ProtoMessage()    {}
 func (*MempoolMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{7}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{8}
 }
 func (m *MempoolMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MempoolMessage.Unmarshal(m, b)
@@ -371,10 +450,11 @@ func (m *MempoolMessage) GetAttestations() []*Attestation {
 	return nil
 }
 
-// Response to GetBlockMessage
+// Response to GetBlockMessage or GetBlocksBySlotMessage
 type BlockMessage struct {
 	Blocks               []*Block `protobuf:"bytes,1,rep,name=Blocks,proto3" json:"Blocks,omitempty"`
 	LatestBlockHash      []byte   `protobuf:"bytes,2,opt,name=LatestBlockHash,proto3" json:"LatestBlockHash,omitempty"`
+	RequestID            uint64   `protobuf:"varint,3,opt,name=RequestID,proto3" json:"RequestID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -384,7 +464,7 @@ func (m *BlockMessage) Reset()         { *m = BlockMessage{} }
 func (m *BlockMessage) String() string { return proto.CompactTextString(m) }
 func (*BlockMessage) ProtoMessage()    {}
 func (*BlockMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{8}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{9}
 }
 func (m *BlockMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMessage.Unmarshal(m, b)
@@ -418,6 +498,13 @@ func (m *BlockMessage) GetLatestBlockHash() []byte {
 	return nil
 }
 
+func (m *BlockMessage) GetRequestID() uint64 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
 type GetAddrMessage struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -428,7 +515,7 @@ func (m *GetAddrMessage) Reset()         { *m = GetAddrMessage{} }
 func (m *GetAddrMessage) String() string { return proto.CompactTextString(m) }
 func (*GetAddrMessage) ProtoMessage()    {}
 func (*GetAddrMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{9}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{10}
 }
 func (m *GetAddrMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetAddrMessage.Unmarshal(m, b)
@@ -459,7 +546,7 @@ func (m *AddrMessage) Reset()         { *m = AddrMessage{} }
 func (m *AddrMessage) String() string { return proto.CompactTextString(m) }
 func (*AddrMessage) ProtoMessage()    {}
 func (*AddrMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_p2p_54b0f8144e4dad1c, []int{10}
+	return fileDescriptor_p2p_0158dcc8de37f571, []int{11}
 }
 func (m *AddrMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AddrMessage.Unmarshal(m, b)
@@ -494,39 +581,44 @@ func init() {
 	proto.RegisterType((*AttestationMempoolItem)(nil), "pb.AttestationMempoolItem")
 	proto.RegisterType((*GetMempoolMessage)(nil), "pb.GetMempoolMessage")
 	proto.RegisterType((*GetBlockMessage)(nil), "pb.GetBlockMessage")
+	proto.RegisterType((*GetBlocksBySlotMessage)(nil), "pb.GetBlocksBySlotMessage")
 	proto.RegisterType((*MempoolMessage)(nil), "pb.MempoolMessage")
 	proto.RegisterType((*BlockMessage)(nil), "pb.BlockMessage")
 	proto.RegisterType((*GetAddrMessage)(nil), "pb.GetAddrMessage")
 	proto.RegisterType((*AddrMessage)(nil), "pb.AddrMessage")
 }
 
-func init() { proto.RegisterFile("p2p.proto", fileDescriptor_p2p_54b0f8144e4dad1c) }
-
-var fileDescriptor_p2p_54b0f8144e4dad1c = []byte{
-	// 385 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x8e, 0xda, 0x30,
-	0x10, 0xc6, 0x15, 0xfe, 0xb5, 0x4c, 0x02, 0xb4, 0x51, 0x85, 0x22, 0x4e, 0x69, 0xe0, 0x90, 0x5e,
-	0x38, 0xc0, 0xbd, 0x12, 0x55, 0xab, 0x80, 0x44, 0x2b, 0x14, 0xa4, 0x5e, 0xf6, 0x14, 0xc2, 0x2c,
-	0x64, 0x97, 0xc4, 0x56, 0xec, 0x87, 0xd9, 0xc7, 0x5d, 0x79, 0x62, 0xa3, 0x24, 0x42, 0xda, 0xdb,
-	0x7c, 0xdf, 0x8c, 0xc7, 0xbf, 0x19, 0x27, 0x30, 0xe4, 0x2b, 0xbe, 0xe4, 0x25, 0x93, 0xcc, 0xed,
-	0xf0, 0xd3, 0xcc, 0x49, 0x59, 0x9e, 0xb3, 0xa2, 0x72, 0x82, 0x37, 0x0b, 0xc6, 0xff, 0xb1, 0x14,
-	0x19, 0x2b, 0xfe, 0xa2, 0x10, 0xc9, 0x05, 0x5d, 0x0f, 0x3e, 0x69, 0xc7, 0xb3, 0x7c, 0x2b, 0xec,
-	0xc5, 0x46, 0xba, 0x53, 0x18, 0x1c, 0x10, 0xcb, 0xdd, 0x6f, 0xaf, 0xe3, 0x5b, 0xa1, 0x13, 0x6b,
-	0xe5, 0xce, 0xe0, 0x33, 0x45, 0xc5, 0x33, 0xf3, 0xba, 0x94, 0xb9, 0x6b, 0xd7, 0x07, 0x3b, 0xc2,
-	0x02, 0x45, 0x26, 0xb6, 0x89, 0xb8, 0x7a, 0x3d, 0x4a, 0xd7, 0x2d, 0xd5, 0x75, 0x8b, 0xd9, 0xe5,
-	0x2a, 0xbd, 0x3e, 0x5d, 0xa7, 0x55, 0x30, 0x07, 0xfb, 0x90, 0x15, 0x17, 0x83, 0xf5, 0x0d, 0xfa,
-	0xff, 0x58, 0x91, 0xa2, 0x86, 0xaa, 0x04, 0x15, 0xb1, 0x8f, 0x8a, 0x7e, 0xc0, 0x28, 0xc6, 0x17,
-	0x4c, 0x65, 0x6d, 0x44, 0x1d, 0x52, 0xe1, 0x30, 0x36, 0x32, 0xb8, 0xc2, 0x74, 0x23, 0x25, 0x0a,
-	0x99, 0x48, 0x5a, 0x49, 0xce, 0x19, 0xbb, 0xed, 0x24, 0xe6, 0x6e, 0x08, 0x93, 0x5a, 0x86, 0x86,
-	0xb1, 0x68, 0x98, 0xb6, 0xed, 0x2e, 0x60, 0x74, 0x48, 0x4a, 0x99, 0xa5, 0x19, 0x27, 0x53, 0x6f,
-	0xab, 0x69, 0x06, 0x47, 0xf8, 0x1a, 0xa1, 0xd4, 0x37, 0x18, 0xb0, 0x9f, 0xe0, 0xd4, 0xba, 0x09,
-	0xcf, 0xf2, 0xbb, 0xa1, 0xbd, 0x9a, 0x2d, 0xf9, 0x69, 0xf9, 0x18, 0x2b, 0x6e, 0xd4, 0x07, 0x47,
-	0x98, 0x44, 0x28, 0x7f, 0xdd, 0x58, 0xfa, 0x6a, 0x5a, 0x2e, 0x60, 0xb4, 0x67, 0x69, 0x22, 0x59,
-	0xa9, 0xe0, 0xb0, 0xea, 0xe9, 0xc4, 0x4d, 0x53, 0x3d, 0xa1, 0x8a, 0x8e, 0x92, 0x71, 0x8d, 0x7b,
-	0xd7, 0xc1, 0x1f, 0x18, 0xb7, 0x30, 0xd7, 0x0f, 0x31, 0x27, 0x2d, 0xcc, 0x16, 0xdb, 0x13, 0x38,
-	0x0d, 0xb0, 0xef, 0x30, 0x20, 0x6d, 0x8e, 0x0f, 0xd5, 0x71, 0x72, 0x62, 0x9d, 0x50, 0x3b, 0xdf,
-	0x27, 0xaa, 0x05, 0x69, 0xda, 0x79, 0x05, 0xd7, 0xb6, 0x83, 0x2f, 0x30, 0x8e, 0x50, 0x6e, 0xce,
-	0xe7, 0xd2, 0xbc, 0xe4, 0x1c, 0xec, 0x9a, 0x54, 0x5f, 0x86, 0x92, 0x66, 0xfc, 0x4a, 0x9c, 0x06,
-	0xf4, 0x17, 0xac, 0xdf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x88, 0x46, 0x6a, 0x19, 0x24, 0x03, 0x00,
-	0x00,
+func init() { proto.RegisterFile("p2p.proto", fileDescriptor_p2p_0158dcc8de37f571) }
+
+var fileDescriptor_p2p_0158dcc8de37f571 = []byte{
+	// 463 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4d, 0x6f, 0xda, 0x40,
+	0x10, 0x95, 0x09, 0x21, 0x65, 0x30, 0xd0, 0x5a, 0x15, 0xb2, 0x50, 0x0f, 0xd4, 0xc9, 0x81, 0x5e,
+	0x38, 0x24, 0xf7, 0x4a, 0x89, 0x92, 0x12, 0xa4, 0xb4, 0x42, 0x4b, 0xd5, 0xbb, 0x31, 0x53, 0x70,
+	0x03, 0xde, 0x8d, 0x77, 0x7a, 0xc8, 0xff, 0xec, 0x0f, 0xaa, 0x76, 0x76, 0x97, 0xd8, 0x56, 0xa4,
+	0xdc, 0xfc, 0xde, 0x9b, 0xaf, 0x7d, 0x33, 0x86, 0xae, 0xba, 0x54, 0x33, 0x55, 0x4a, 0x92, 0x51,
+	0x4b, 0xad, 0xc7, 0x61, 0x26, 0x0f, 0x07, 0x59, 0x58, 0x26, 0xf9, 0x17, 0xc0, 0xe0, 0x17, 0x96,
+	0x3a, 0x97, 0xc5, 0x77, 0xd4, 0x3a, 0xdd, 0x62, 0x14, 0xc3, 0x99, 0x63, 0xe2, 0x60, 0x12, 0x4c,
+	0xdb, 0xc2, 0xc3, 0x68, 0x04, 0x9d, 0x25, 0x62, 0xb9, 0xb8, 0x8d, 0x5b, 0x93, 0x60, 0x1a, 0x0a,
+	0x87, 0xa2, 0x31, 0xbc, 0xe3, 0xaf, 0xe2, 0xb7, 0x8c, 0x4f, 0x58, 0x39, 0xe2, 0x68, 0x02, 0xbd,
+	0x39, 0x16, 0xa8, 0x73, 0x7d, 0x9f, 0xea, 0x5d, 0xdc, 0x66, 0xb9, 0x4a, 0x99, 0xaa, 0xf7, 0x98,
+	0x6f, 0x77, 0x14, 0x9f, 0x72, 0x3b, 0x87, 0xcc, 0x1c, 0x3f, 0x73, 0xb5, 0xda, 0x4b, 0x8a, 0x3b,
+	0x76, 0x0e, 0x07, 0x4d, 0xcd, 0x6f, 0xb2, 0x7c, 0xf4, 0x53, 0x9e, 0xb1, 0x5a, 0xa5, 0x92, 0x73,
+	0xe8, 0x2d, 0xf3, 0x62, 0xeb, 0x9f, 0xf4, 0x11, 0x4e, 0x7f, 0xc8, 0x22, 0x43, 0xf7, 0x20, 0x0b,
+	0x38, 0x48, 0xbe, 0x15, 0xf4, 0x05, 0xfa, 0x02, 0xff, 0x60, 0x46, 0x15, 0x7b, 0xdc, 0x27, 0x07,
+	0x76, 0x85, 0x87, 0xc9, 0x0e, 0x46, 0xd7, 0x44, 0xa8, 0x29, 0x25, 0xb6, 0xf3, 0xa0, 0xa4, 0xdc,
+	0x2f, 0x08, 0x0f, 0xd1, 0x14, 0x86, 0x15, 0x85, 0x8d, 0x08, 0xd8, 0x88, 0x26, 0x1d, 0x5d, 0x40,
+	0x7f, 0x99, 0x96, 0x94, 0x67, 0xb9, 0x62, 0xd2, 0x39, 0x5d, 0x27, 0x93, 0x15, 0x7c, 0x98, 0x23,
+	0xb9, 0x0e, 0x7e, 0xb0, 0xaf, 0x10, 0x56, 0xaa, 0xe9, 0x38, 0x98, 0x9c, 0x4c, 0x7b, 0x97, 0xe3,
+	0x99, 0x5a, 0xcf, 0x5e, 0x1f, 0x4b, 0xd4, 0xe2, 0x93, 0x27, 0x18, 0xce, 0x91, 0x6e, 0xf6, 0x32,
+	0x7b, 0xf4, 0x25, 0x2f, 0xa0, 0xff, 0x20, 0xb3, 0x94, 0x64, 0x69, 0x86, 0x43, 0x5b, 0x33, 0x14,
+	0x75, 0xd2, 0xac, 0xdf, 0x7c, 0xad, 0x48, 0x2a, 0x37, 0xee, 0x11, 0x47, 0x9f, 0xa0, 0x2b, 0xf0,
+	0xe9, 0x2f, 0x6a, 0x5a, 0xdc, 0xf2, 0x6d, 0xb4, 0xc5, 0x0b, 0x91, 0x14, 0x30, 0xf2, 0x2d, 0xf5,
+	0xcd, 0xb3, 0xd9, 0xad, 0xef, 0x5c, 0xcb, 0x0b, 0x1a, 0x79, 0x46, 0x5d, 0x51, 0x5a, 0x12, 0x1f,
+	0x47, 0xcb, 0xaa, 0x47, 0xc2, 0x6c, 0xe8, 0xae, 0xd8, 0xb0, 0x66, 0x3b, 0x7a, 0x98, 0xdc, 0xc1,
+	0xa0, 0x61, 0xda, 0xd5, 0xab, 0xa6, 0x0d, 0x1b, 0xa6, 0x35, 0x9c, 0x7a, 0x86, 0xb0, 0x66, 0xd3,
+	0x67, 0xe8, 0xd8, 0x37, 0xb8, 0xf4, 0xae, 0x49, 0x67, 0x46, 0x38, 0xc1, 0x5c, 0xc0, 0x43, 0x6a,
+	0x4a, 0x30, 0xe6, 0x0b, 0xb0, 0x56, 0x35, 0xe9, 0x37, 0x1c, 0x7b, 0x0f, 0x83, 0x39, 0xd2, 0xf5,
+	0x66, 0x53, 0xfa, 0xab, 0x3b, 0x87, 0x5e, 0x05, 0x9a, 0x2b, 0x36, 0xd0, 0xaf, 0xca, 0x82, 0x75,
+	0x87, 0xff, 0xf6, 0xab, 0xff, 0x03, 0x00, 0xcf, 0x0c, 0x12, 0x9b, 0x0c, 0x04, 0x00, 0x00,
 }
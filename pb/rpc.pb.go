@@ -24,6 +24,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ChainEventType int32
+
+const (
+	ChainEventType_BLOCK     ChainEventType = 0
+	ChainEventType_HEAD      ChainEventType = 1
+	ChainEventType_JUSTIFIED ChainEventType = 2
+	ChainEventType_FINALIZED ChainEventType = 3
+)
+
+var ChainEventType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEAD",
+	2: "JUSTIFIED",
+	3: "FINALIZED",
+}
+var ChainEventType_value = map[string]int32{
+	"BLOCK":     0,
+	"HEAD":      1,
+	"JUSTIFIED": 2,
+	"FINALIZED": 3,
+}
+
+func (x ChainEventType) String() string {
+	return proto.EnumName(ChainEventType_name, int32(x))
+}
+func (ChainEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{0}
+}
+
 type Role int32
 
 const (
@@ -44,7 +73,177 @@ func (x Role) String() string {
 	return proto.EnumName(Role_name, int32(x))
 }
 func (Role) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{0}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{1}
+}
+
+type SubscribeChainEventsRequest struct {
+	Blocks               bool     `protobuf:"varint,1,opt,name=Blocks,proto3" json:"Blocks,omitempty"`
+	Heads                bool     `protobuf:"varint,2,opt,name=Heads,proto3" json:"Heads,omitempty"`
+	Justifications       bool     `protobuf:"varint,3,opt,name=Justifications,proto3" json:"Justifications,omitempty"`
+	Finalizations        bool     `protobuf:"varint,4,opt,name=Finalizations,proto3" json:"Finalizations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeChainEventsRequest) Reset()         { *m = SubscribeChainEventsRequest{} }
+func (m *SubscribeChainEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeChainEventsRequest) ProtoMessage()    {}
+func (*SubscribeChainEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{0}
+}
+func (m *SubscribeChainEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeChainEventsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeChainEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeChainEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubscribeChainEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeChainEventsRequest.Merge(dst, src)
+}
+func (m *SubscribeChainEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeChainEventsRequest.Size(m)
+}
+func (m *SubscribeChainEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeChainEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeChainEventsRequest proto.InternalMessageInfo
+
+func (m *SubscribeChainEventsRequest) GetBlocks() bool {
+	if m != nil {
+		return m.Blocks
+	}
+	return false
+}
+
+func (m *SubscribeChainEventsRequest) GetHeads() bool {
+	if m != nil {
+		return m.Heads
+	}
+	return false
+}
+
+func (m *SubscribeChainEventsRequest) GetJustifications() bool {
+	if m != nil {
+		return m.Justifications
+	}
+	return false
+}
+
+func (m *SubscribeChainEventsRequest) GetFinalizations() bool {
+	if m != nil {
+		return m.Finalizations
+	}
+	return false
+}
+
+type ChainEvent struct {
+	Type                 ChainEventType `protobuf:"varint,1,opt,name=Type,proto3,enum=pb.ChainEventType" json:"Type,omitempty"`
+	BlockHash            []byte         `protobuf:"bytes,2,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	Slot                 uint64         `protobuf:"varint,3,opt,name=Slot,proto3" json:"Slot,omitempty"`
+	Epoch                uint64         `protobuf:"varint,4,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Block                *Block         `protobuf:"bytes,5,opt,name=Block,proto3" json:"Block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChainEvent) Reset()         { *m = ChainEvent{} }
+func (m *ChainEvent) String() string { return proto.CompactTextString(m) }
+func (*ChainEvent) ProtoMessage()    {}
+func (*ChainEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{1}
+}
+func (m *ChainEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainEvent.Unmarshal(m, b)
+}
+func (m *ChainEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainEvent.Marshal(b, m, deterministic)
+}
+func (dst *ChainEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainEvent.Merge(dst, src)
+}
+func (m *ChainEvent) XXX_Size() int {
+	return xxx_messageInfo_ChainEvent.Size(m)
+}
+func (m *ChainEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainEvent proto.InternalMessageInfo
+
+func (m *ChainEvent) GetType() ChainEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ChainEventType_BLOCK
+}
+
+func (m *ChainEvent) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ChainEvent) GetSlot() uint64 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *ChainEvent) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *ChainEvent) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type SetDepositRootRequest struct {
+	DepositRoot          []byte   `protobuf:"bytes,1,opt,name=DepositRoot,proto3" json:"DepositRoot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDepositRootRequest) Reset()         { *m = SetDepositRootRequest{} }
+func (m *SetDepositRootRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDepositRootRequest) ProtoMessage()    {}
+func (*SetDepositRootRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{2}
+}
+func (m *SetDepositRootRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDepositRootRequest.Unmarshal(m, b)
+}
+func (m *SetDepositRootRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDepositRootRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetDepositRootRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDepositRootRequest.Merge(dst, src)
+}
+func (m *SetDepositRootRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDepositRootRequest.Size(m)
+}
+func (m *SetDepositRootRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDepositRootRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDepositRootRequest proto.InternalMessageInfo
+
+func (m *SetDepositRootRequest) GetDepositRoot() []byte {
+	if m != nil {
+		return m.DepositRoot
+	}
+	return nil
 }
 
 type MempoolRequest struct {
@@ -58,7 +257,7 @@ func (m *MempoolRequest) Reset()         { *m = MempoolRequest{} }
 func (m *MempoolRequest) String() string { return proto.CompactTextString(m) }
 func (*MempoolRequest) ProtoMessage()    {}
 func (*MempoolRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{0}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{3}
 }
 func (m *MempoolRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MempoolRequest.Unmarshal(m, b)
@@ -96,7 +295,7 @@ func (m *GetValidatorRequest) Reset()         { *m = GetValidatorRequest{} }
 func (m *GetValidatorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidatorRequest) ProtoMessage()    {}
 func (*GetValidatorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{1}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{4}
 }
 func (m *GetValidatorRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetValidatorRequest.Unmarshal(m, b)
@@ -134,7 +333,7 @@ func (m *GetBlockRequest) Reset()         { *m = GetBlockRequest{} }
 func (m *GetBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockRequest) ProtoMessage()    {}
 func (*GetBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{2}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{5}
 }
 func (m *GetBlockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockRequest.Unmarshal(m, b)
@@ -172,7 +371,7 @@ func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
 func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockResponse) ProtoMessage()    {}
 func (*GetBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{3}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{6}
 }
 func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockResponse.Unmarshal(m, b)
@@ -210,7 +409,7 @@ func (m *GetProposerForSlotRequest) Reset()         { *m = GetProposerForSlotReq
 func (m *GetProposerForSlotRequest) String() string { return proto.CompactTextString(m) }
 func (*GetProposerForSlotRequest) ProtoMessage()    {}
 func (*GetProposerForSlotRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{4}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{7}
 }
 func (m *GetProposerForSlotRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetProposerForSlotRequest.Unmarshal(m, b)
@@ -248,7 +447,7 @@ func (m *GetProposerForSlotResponse) Reset()         { *m = GetProposerForSlotRe
 func (m *GetProposerForSlotResponse) String() string { return proto.CompactTextString(m) }
 func (*GetProposerForSlotResponse) ProtoMessage()    {}
 func (*GetProposerForSlotResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{5}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{8}
 }
 func (m *GetProposerForSlotResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetProposerForSlotResponse.Unmarshal(m, b)
@@ -277,6 +476,7 @@ func (m *GetProposerForSlotResponse) GetProposer() uint32 {
 
 type EpochInformationRequest struct {
 	EpochIndex           uint64   `protobuf:"varint,1,opt,name=EpochIndex,proto3" json:"EpochIndex,omitempty"`
+	ExcludeCommittees    bool     `protobuf:"varint,2,opt,name=ExcludeCommittees,proto3" json:"ExcludeCommittees,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -286,7 +486,7 @@ func (m *EpochInformationRequest) Reset()         { *m = EpochInformationRequest
 func (m *EpochInformationRequest) String() string { return proto.CompactTextString(m) }
 func (*EpochInformationRequest) ProtoMessage()    {}
 func (*EpochInformationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{6}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{9}
 }
 func (m *EpochInformationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EpochInformationRequest.Unmarshal(m, b)
@@ -313,6 +513,13 @@ func (m *EpochInformationRequest) GetEpochIndex() uint64 {
 	return 0
 }
 
+func (m *EpochInformationRequest) GetExcludeCommittees() bool {
+	if m != nil {
+		return m.ExcludeCommittees
+	}
+	return false
+}
+
 type EpochInformationResponse struct {
 	HasEpochInformation  bool              `protobuf:"varint,1,opt,name=HasEpochInformation,proto3" json:"HasEpochInformation,omitempty"`
 	Information          *EpochInformation `protobuf:"bytes,2,opt,name=Information,proto3" json:"Information,omitempty"`
@@ -325,7 +532,7 @@ func (m *EpochInformationResponse) Reset()         { *m = EpochInformationRespon
 func (m *EpochInformationResponse) String() string { return proto.CompactTextString(m) }
 func (*EpochInformationResponse) ProtoMessage()    {}
 func (*EpochInformationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{7}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{10}
 }
 func (m *EpochInformationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EpochInformationResponse.Unmarshal(m, b)
@@ -379,7 +586,7 @@ func (m *EpochInformation) Reset()         { *m = EpochInformation{} }
 func (m *EpochInformation) String() string { return proto.CompactTextString(m) }
 func (*EpochInformation) ProtoMessage()    {}
 func (*EpochInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{8}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{11}
 }
 func (m *EpochInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EpochInformation.Unmarshal(m, b)
@@ -469,6 +676,216 @@ func (m *EpochInformation) GetPreviousCrosslinks() []*Crosslink {
 	return nil
 }
 
+type GetValidatorDutiesRequest struct {
+	Epoch                uint64   `protobuf:"varint,1,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Validators           []uint32 `protobuf:"varint,2,rep,packed,name=Validators,proto3" json:"Validators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetValidatorDutiesRequest) Reset()         { *m = GetValidatorDutiesRequest{} }
+func (m *GetValidatorDutiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetValidatorDutiesRequest) ProtoMessage()    {}
+func (*GetValidatorDutiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{12}
+}
+func (m *GetValidatorDutiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetValidatorDutiesRequest.Unmarshal(m, b)
+}
+func (m *GetValidatorDutiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetValidatorDutiesRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetValidatorDutiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetValidatorDutiesRequest.Merge(dst, src)
+}
+func (m *GetValidatorDutiesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetValidatorDutiesRequest.Size(m)
+}
+func (m *GetValidatorDutiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetValidatorDutiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetValidatorDutiesRequest proto.InternalMessageInfo
+
+func (m *GetValidatorDutiesRequest) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *GetValidatorDutiesRequest) GetValidators() []uint32 {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+// Duties are assigned by the committees of the slots in the requested epoch and are
+// performed one slot later, so Slot is the slot the validator should propose or attest in.
+type AttesterDuty struct {
+	Slot                 uint64   `protobuf:"varint,1,opt,name=Slot,proto3" json:"Slot,omitempty"`
+	Shard                uint64   `protobuf:"varint,2,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	CommitteeIndex       uint64   `protobuf:"varint,3,opt,name=CommitteeIndex,proto3" json:"CommitteeIndex,omitempty"`
+	CommitteeSize        uint64   `protobuf:"varint,4,opt,name=CommitteeSize,proto3" json:"CommitteeSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttesterDuty) Reset()         { *m = AttesterDuty{} }
+func (m *AttesterDuty) String() string { return proto.CompactTextString(m) }
+func (*AttesterDuty) ProtoMessage()    {}
+func (*AttesterDuty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{13}
+}
+func (m *AttesterDuty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttesterDuty.Unmarshal(m, b)
+}
+func (m *AttesterDuty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttesterDuty.Marshal(b, m, deterministic)
+}
+func (dst *AttesterDuty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttesterDuty.Merge(dst, src)
+}
+func (m *AttesterDuty) XXX_Size() int {
+	return xxx_messageInfo_AttesterDuty.Size(m)
+}
+func (m *AttesterDuty) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttesterDuty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttesterDuty proto.InternalMessageInfo
+
+func (m *AttesterDuty) GetSlot() uint64 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *AttesterDuty) GetShard() uint64 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *AttesterDuty) GetCommitteeIndex() uint64 {
+	if m != nil {
+		return m.CommitteeIndex
+	}
+	return 0
+}
+
+func (m *AttesterDuty) GetCommitteeSize() uint64 {
+	if m != nil {
+		return m.CommitteeSize
+	}
+	return 0
+}
+
+type ValidatorDuties struct {
+	Validator            uint32          `protobuf:"varint,1,opt,name=Validator,proto3" json:"Validator,omitempty"`
+	ProposerSlots        []uint64        `protobuf:"varint,2,rep,packed,name=ProposerSlots,proto3" json:"ProposerSlots,omitempty"`
+	AttesterDuties       []*AttesterDuty `protobuf:"bytes,3,rep,name=AttesterDuties,proto3" json:"AttesterDuties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ValidatorDuties) Reset()         { *m = ValidatorDuties{} }
+func (m *ValidatorDuties) String() string { return proto.CompactTextString(m) }
+func (*ValidatorDuties) ProtoMessage()    {}
+func (*ValidatorDuties) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{14}
+}
+func (m *ValidatorDuties) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatorDuties.Unmarshal(m, b)
+}
+func (m *ValidatorDuties) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatorDuties.Marshal(b, m, deterministic)
+}
+func (dst *ValidatorDuties) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorDuties.Merge(dst, src)
+}
+func (m *ValidatorDuties) XXX_Size() int {
+	return xxx_messageInfo_ValidatorDuties.Size(m)
+}
+func (m *ValidatorDuties) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorDuties.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorDuties proto.InternalMessageInfo
+
+func (m *ValidatorDuties) GetValidator() uint32 {
+	if m != nil {
+		return m.Validator
+	}
+	return 0
+}
+
+func (m *ValidatorDuties) GetProposerSlots() []uint64 {
+	if m != nil {
+		return m.ProposerSlots
+	}
+	return nil
+}
+
+func (m *ValidatorDuties) GetAttesterDuties() []*AttesterDuty {
+	if m != nil {
+		return m.AttesterDuties
+	}
+	return nil
+}
+
+type GetValidatorDutiesResponse struct {
+	Epoch                uint64             `protobuf:"varint,1,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Duties               []*ValidatorDuties `protobuf:"bytes,2,rep,name=Duties,proto3" json:"Duties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetValidatorDutiesResponse) Reset()         { *m = GetValidatorDutiesResponse{} }
+func (m *GetValidatorDutiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetValidatorDutiesResponse) ProtoMessage()    {}
+func (*GetValidatorDutiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{15}
+}
+func (m *GetValidatorDutiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetValidatorDutiesResponse.Unmarshal(m, b)
+}
+func (m *GetValidatorDutiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetValidatorDutiesResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetValidatorDutiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetValidatorDutiesResponse.Merge(dst, src)
+}
+func (m *GetValidatorDutiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetValidatorDutiesResponse.Size(m)
+}
+func (m *GetValidatorDutiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetValidatorDutiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetValidatorDutiesResponse proto.InternalMessageInfo
+
+func (m *GetValidatorDutiesResponse) GetEpoch() uint64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *GetValidatorDutiesResponse) GetDuties() []*ValidatorDuties {
+	if m != nil {
+		return m.Duties
+	}
+	return nil
+}
+
 type DisconnectResponse struct {
 	Success              bool     `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -480,7 +897,7 @@ func (m *DisconnectResponse) Reset()         { *m = DisconnectResponse{} }
 func (m *DisconnectResponse) String() string { return proto.CompactTextString(m) }
 func (*DisconnectResponse) ProtoMessage()    {}
 func (*DisconnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{9}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{16}
 }
 func (m *DisconnectResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DisconnectResponse.Unmarshal(m, b)
@@ -518,7 +935,7 @@ func (m *GetCommitteesForSlotRequest) Reset()         { *m = GetCommitteesForSlo
 func (m *GetCommitteesForSlotRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCommitteesForSlotRequest) ProtoMessage()    {}
 func (*GetCommitteesForSlotRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{10}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{17}
 }
 func (m *GetCommitteesForSlotRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCommitteesForSlotRequest.Unmarshal(m, b)
@@ -556,7 +973,7 @@ func (m *GetSlotAndShardAssignmentRequest) Reset()         { *m = GetSlotAndShar
 func (m *GetSlotAndShardAssignmentRequest) String() string { return proto.CompactTextString(m) }
 func (*GetSlotAndShardAssignmentRequest) ProtoMessage()    {}
 func (*GetSlotAndShardAssignmentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{11}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{18}
 }
 func (m *GetSlotAndShardAssignmentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetSlotAndShardAssignmentRequest.Unmarshal(m, b)
@@ -596,7 +1013,7 @@ func (m *SlotAndShardAssignment) Reset()         { *m = SlotAndShardAssignment{}
 func (m *SlotAndShardAssignment) String() string { return proto.CompactTextString(m) }
 func (*SlotAndShardAssignment) ProtoMessage()    {}
 func (*SlotAndShardAssignment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{12}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{19}
 }
 func (m *SlotAndShardAssignment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SlotAndShardAssignment.Unmarshal(m, b)
@@ -648,7 +1065,7 @@ func (m *SubmitBlockRequest) Reset()         { *m = SubmitBlockRequest{} }
 func (m *SubmitBlockRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitBlockRequest) ProtoMessage()    {}
 func (*SubmitBlockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{13}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{20}
 }
 func (m *SubmitBlockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitBlockRequest.Unmarshal(m, b)
@@ -686,7 +1103,7 @@ func (m *SubmitBlockResponse) Reset()         { *m = SubmitBlockResponse{} }
 func (m *SubmitBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitBlockResponse) ProtoMessage()    {}
 func (*SubmitBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{14}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{21}
 }
 func (m *SubmitBlockResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitBlockResponse.Unmarshal(m, b)
@@ -725,7 +1142,7 @@ func (m *SlotNumberResponse) Reset()         { *m = SlotNumberResponse{} }
 func (m *SlotNumberResponse) String() string { return proto.CompactTextString(m) }
 func (*SlotNumberResponse) ProtoMessage()    {}
 func (*SlotNumberResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{15}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{22}
 }
 func (m *SlotNumberResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SlotNumberResponse.Unmarshal(m, b)
@@ -770,7 +1187,7 @@ func (m *GetBlockHashRequest) Reset()         { *m = GetBlockHashRequest{} }
 func (m *GetBlockHashRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBlockHashRequest) ProtoMessage()    {}
 func (*GetBlockHashRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{16}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{23}
 }
 func (m *GetBlockHashRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockHashRequest.Unmarshal(m, b)
@@ -808,7 +1225,7 @@ func (m *GetBlockHashResponse) Reset()         { *m = GetBlockHashResponse{} }
 func (m *GetBlockHashResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBlockHashResponse) ProtoMessage()    {}
 func (*GetBlockHashResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{17}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{24}
 }
 func (m *GetBlockHashResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBlockHashResponse.Unmarshal(m, b)
@@ -846,7 +1263,7 @@ func (m *GetValidatorAtIndexRequest) Reset()         { *m = GetValidatorAtIndexR
 func (m *GetValidatorAtIndexRequest) String() string { return proto.CompactTextString(m) }
 func (*GetValidatorAtIndexRequest) ProtoMessage()    {}
 func (*GetValidatorAtIndexRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{18}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{25}
 }
 func (m *GetValidatorAtIndexRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetValidatorAtIndexRequest.Unmarshal(m, b)
@@ -884,7 +1301,7 @@ func (m *GetValidatorAtIndexResponse) Reset()         { *m = GetValidatorAtIndex
 func (m *GetValidatorAtIndexResponse) String() string { return proto.CompactTextString(m) }
 func (*GetValidatorAtIndexResponse) ProtoMessage()    {}
 func (*GetValidatorAtIndexResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{19}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{26}
 }
 func (m *GetValidatorAtIndexResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetValidatorAtIndexResponse.Unmarshal(m, b)
@@ -923,7 +1340,7 @@ func (m *GetCommitteeValidatorsRequest) Reset()         { *m = GetCommitteeValid
 func (m *GetCommitteeValidatorsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetCommitteeValidatorsRequest) ProtoMessage()    {}
 func (*GetCommitteeValidatorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{20}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{27}
 }
 func (m *GetCommitteeValidatorsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCommitteeValidatorsRequest.Unmarshal(m, b)
@@ -968,7 +1385,7 @@ func (m *GetStateResponse) Reset()         { *m = GetStateResponse{} }
 func (m *GetStateResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStateResponse) ProtoMessage()    {}
 func (*GetStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{21}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{28}
 }
 func (m *GetStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateResponse.Unmarshal(m, b)
@@ -995,6 +1412,82 @@ func (m *GetStateResponse) GetState() *State {
 	return nil
 }
 
+type GetStateAtSlotRequest struct {
+	Slot                 uint64   `protobuf:"varint,1,opt,name=Slot,proto3" json:"Slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateAtSlotRequest) Reset()         { *m = GetStateAtSlotRequest{} }
+func (m *GetStateAtSlotRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateAtSlotRequest) ProtoMessage()    {}
+func (*GetStateAtSlotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{29}
+}
+func (m *GetStateAtSlotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateAtSlotRequest.Unmarshal(m, b)
+}
+func (m *GetStateAtSlotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateAtSlotRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateAtSlotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateAtSlotRequest.Merge(dst, src)
+}
+func (m *GetStateAtSlotRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateAtSlotRequest.Size(m)
+}
+func (m *GetStateAtSlotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateAtSlotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateAtSlotRequest proto.InternalMessageInfo
+
+func (m *GetStateAtSlotRequest) GetSlot() uint64 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+type GetStateForBlockRequest struct {
+	BlockHash            []byte   `protobuf:"bytes,1,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateForBlockRequest) Reset()         { *m = GetStateForBlockRequest{} }
+func (m *GetStateForBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateForBlockRequest) ProtoMessage()    {}
+func (*GetStateForBlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{30}
+}
+func (m *GetStateForBlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateForBlockRequest.Unmarshal(m, b)
+}
+func (m *GetStateForBlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateForBlockRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetStateForBlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateForBlockRequest.Merge(dst, src)
+}
+func (m *GetStateForBlockRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateForBlockRequest.Size(m)
+}
+func (m *GetStateForBlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateForBlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateForBlockRequest proto.InternalMessageInfo
+
+func (m *GetStateForBlockRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type GetStateRootResponse struct {
 	StateRoot            []byte   `protobuf:"bytes,1,opt,name=StateRoot,proto3" json:"StateRoot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1006,7 +1499,7 @@ func (m *GetStateRootResponse) Reset()         { *m = GetStateRootResponse{} }
 func (m *GetStateRootResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStateRootResponse) ProtoMessage()    {}
 func (*GetStateRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{22}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{31}
 }
 func (m *GetStateRootResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateRootResponse.Unmarshal(m, b)
@@ -1044,7 +1537,7 @@ func (m *GetCommitteeValidatorsResponse) Reset()         { *m = GetCommitteeVali
 func (m *GetCommitteeValidatorsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetCommitteeValidatorsResponse) ProtoMessage()    {}
 func (*GetCommitteeValidatorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{23}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{32}
 }
 func (m *GetCommitteeValidatorsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCommitteeValidatorsResponse.Unmarshal(m, b)
@@ -1082,7 +1575,7 @@ func (m *GetCommitteeValidatorIndicesResponse) Reset()         { *m = GetCommitt
 func (m *GetCommitteeValidatorIndicesResponse) String() string { return proto.CompactTextString(m) }
 func (*GetCommitteeValidatorIndicesResponse) ProtoMessage()    {}
 func (*GetCommitteeValidatorIndicesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_rpc_4533f8b5074d69a3, []int{24}
+	return fileDescriptor_rpc_88fafde8a15a785d, []int{33}
 }
 func (m *GetCommitteeValidatorIndicesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetCommitteeValidatorIndicesResponse.Unmarshal(m, b)
@@ -1110,6 +1603,9 @@ func (m *GetCommitteeValidatorIndicesResponse) GetValidators() []uint32 {
 }
 
 func init() {
+	proto.RegisterType((*SubscribeChainEventsRequest)(nil), "pb.SubscribeChainEventsRequest")
+	proto.RegisterType((*ChainEvent)(nil), "pb.ChainEvent")
+	proto.RegisterType((*SetDepositRootRequest)(nil), "pb.SetDepositRootRequest")
 	proto.RegisterType((*MempoolRequest)(nil), "pb.MempoolRequest")
 	proto.RegisterType((*GetValidatorRequest)(nil), "pb.GetValidatorRequest")
 	proto.RegisterType((*GetBlockRequest)(nil), "pb.GetBlockRequest")
@@ -1119,6 +1615,10 @@ func init() {
 	proto.RegisterType((*EpochInformationRequest)(nil), "pb.EpochInformationRequest")
 	proto.RegisterType((*EpochInformationResponse)(nil), "pb.EpochInformationResponse")
 	proto.RegisterType((*EpochInformation)(nil), "pb.EpochInformation")
+	proto.RegisterType((*GetValidatorDutiesRequest)(nil), "pb.GetValidatorDutiesRequest")
+	proto.RegisterType((*AttesterDuty)(nil), "pb.AttesterDuty")
+	proto.RegisterType((*ValidatorDuties)(nil), "pb.ValidatorDuties")
+	proto.RegisterType((*GetValidatorDutiesResponse)(nil), "pb.GetValidatorDutiesResponse")
 	proto.RegisterType((*DisconnectResponse)(nil), "pb.DisconnectResponse")
 	proto.RegisterType((*GetCommitteesForSlotRequest)(nil), "pb.GetCommitteesForSlotRequest")
 	proto.RegisterType((*GetSlotAndShardAssignmentRequest)(nil), "pb.GetSlotAndShardAssignmentRequest")
@@ -1132,9 +1632,12 @@ func init() {
 	proto.RegisterType((*GetValidatorAtIndexResponse)(nil), "pb.GetValidatorAtIndexResponse")
 	proto.RegisterType((*GetCommitteeValidatorsRequest)(nil), "pb.GetCommitteeValidatorsRequest")
 	proto.RegisterType((*GetStateResponse)(nil), "pb.GetStateResponse")
+	proto.RegisterType((*GetStateAtSlotRequest)(nil), "pb.GetStateAtSlotRequest")
+	proto.RegisterType((*GetStateForBlockRequest)(nil), "pb.GetStateForBlockRequest")
 	proto.RegisterType((*GetStateRootResponse)(nil), "pb.GetStateRootResponse")
 	proto.RegisterType((*GetCommitteeValidatorsResponse)(nil), "pb.GetCommitteeValidatorsResponse")
 	proto.RegisterType((*GetCommitteeValidatorIndicesResponse)(nil), "pb.GetCommitteeValidatorIndicesResponse")
+	proto.RegisterEnum("pb.ChainEventType", ChainEventType_name, ChainEventType_value)
 	proto.RegisterEnum("pb.Role", Role_name, Role_value)
 }
 
@@ -1155,14 +1658,24 @@ type BlockchainRPCClient interface {
 	GetBlockHash(ctx context.Context, in *GetBlockHashRequest, opts ...grpc.CallOption) (*GetBlockHashResponse, error)
 	GetLastBlockHash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetBlockHashResponse, error)
 	GetState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetStateResponse, error)
+	GetStateAtSlot(ctx context.Context, in *GetStateAtSlotRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
+	GetStateForBlock(ctx context.Context, in *GetStateForBlockRequest, opts ...grpc.CallOption) (*GetStateResponse, error)
 	GetStateRoot(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetStateRootResponse, error)
+	GetFinalizedCheckpoint(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Checkpoint, error)
 	GetEpochInformation(ctx context.Context, in *EpochInformationRequest, opts ...grpc.CallOption) (*EpochInformationResponse, error)
+	GetValidatorDuties(ctx context.Context, in *GetValidatorDutiesRequest, opts ...grpc.CallOption) (*GetValidatorDutiesResponse, error)
 	GetForkData(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForkData, error)
 	GetProposerForSlot(ctx context.Context, in *GetProposerForSlotRequest, opts ...grpc.CallOption) (*GetProposerForSlotResponse, error)
 	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
 	SubmitAttestation(ctx context.Context, in *Attestation, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubmitProposerSlashing(ctx context.Context, in *ProposerSlashing, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubmitCasperSlashing(ctx context.Context, in *CasperSlashing, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubmitDeposit(ctx context.Context, in *Deposit, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetDepositRoot(ctx context.Context, in *SetDepositRootRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SubmitExit(ctx context.Context, in *Exit, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetMempool(ctx context.Context, in *MempoolRequest, opts ...grpc.CallOption) (*BlockBody, error)
 	GetValidatorInformation(ctx context.Context, in *GetValidatorRequest, opts ...grpc.CallOption) (*Validator, error)
+	SubscribeChainEvents(ctx context.Context, in *SubscribeChainEventsRequest, opts ...grpc.CallOption) (BlockchainRPC_SubscribeChainEventsClient, error)
 }
 
 type blockchainRPCClient struct {
@@ -1218,6 +1731,24 @@ func (c *blockchainRPCClient) GetState(ctx context.Context, in *empty.Empty, opt
 	return out, nil
 }
 
+func (c *blockchainRPCClient) GetStateAtSlot(ctx context.Context, in *GetStateAtSlotRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetStateAtSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainRPCClient) GetStateForBlock(ctx context.Context, in *GetStateForBlockRequest, opts ...grpc.CallOption) (*GetStateResponse, error) {
+	out := new(GetStateResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetStateForBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainRPCClient) GetStateRoot(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetStateRootResponse, error) {
 	out := new(GetStateRootResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetStateRoot", in, out, opts...)
@@ -1227,6 +1758,15 @@ func (c *blockchainRPCClient) GetStateRoot(ctx context.Context, in *empty.Empty,
 	return out, nil
 }
 
+func (c *blockchainRPCClient) GetFinalizedCheckpoint(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Checkpoint, error) {
+	out := new(Checkpoint)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetFinalizedCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainRPCClient) GetEpochInformation(ctx context.Context, in *EpochInformationRequest, opts ...grpc.CallOption) (*EpochInformationResponse, error) {
 	out := new(EpochInformationResponse)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetEpochInformation", in, out, opts...)
@@ -1236,6 +1776,15 @@ func (c *blockchainRPCClient) GetEpochInformation(ctx context.Context, in *Epoch
 	return out, nil
 }
 
+func (c *blockchainRPCClient) GetValidatorDuties(ctx context.Context, in *GetValidatorDutiesRequest, opts ...grpc.CallOption) (*GetValidatorDutiesResponse, error) {
+	out := new(GetValidatorDutiesResponse)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetValidatorDuties", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainRPCClient) GetForkData(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ForkData, error) {
 	out := new(ForkData)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetForkData", in, out, opts...)
@@ -1272,6 +1821,51 @@ func (c *blockchainRPCClient) SubmitAttestation(ctx context.Context, in *Attesta
 	return out, nil
 }
 
+func (c *blockchainRPCClient) SubmitProposerSlashing(ctx context.Context, in *ProposerSlashing, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/SubmitProposerSlashing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainRPCClient) SubmitCasperSlashing(ctx context.Context, in *CasperSlashing, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/SubmitCasperSlashing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainRPCClient) SubmitDeposit(ctx context.Context, in *Deposit, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/SubmitDeposit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainRPCClient) SetDepositRoot(ctx context.Context, in *SetDepositRootRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/SetDepositRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainRPCClient) SubmitExit(ctx context.Context, in *Exit, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/SubmitExit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainRPCClient) GetMempool(ctx context.Context, in *MempoolRequest, opts ...grpc.CallOption) (*BlockBody, error) {
 	out := new(BlockBody)
 	err := c.cc.Invoke(ctx, "/pb.BlockchainRPC/GetMempool", in, out, opts...)
@@ -1290,6 +1884,38 @@ func (c *blockchainRPCClient) GetValidatorInformation(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *blockchainRPCClient) SubscribeChainEvents(ctx context.Context, in *SubscribeChainEventsRequest, opts ...grpc.CallOption) (BlockchainRPC_SubscribeChainEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockchainRPC_serviceDesc.Streams[0], "/pb.BlockchainRPC/SubscribeChainEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockchainRPCSubscribeChainEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockchainRPC_SubscribeChainEventsClient interface {
+	Recv() (*ChainEvent, error)
+	grpc.ClientStream
+}
+
+type blockchainRPCSubscribeChainEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockchainRPCSubscribeChainEventsClient) Recv() (*ChainEvent, error) {
+	m := new(ChainEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BlockchainRPCServer is the server API for BlockchainRPC service.
 type BlockchainRPCServer interface {
 	SubmitBlock(context.Context, *SubmitBlockRequest) (*SubmitBlockResponse, error)
@@ -1297,14 +1923,24 @@ type BlockchainRPCServer interface {
 	GetBlockHash(context.Context, *GetBlockHashRequest) (*GetBlockHashResponse, error)
 	GetLastBlockHash(context.Context, *empty.Empty) (*GetBlockHashResponse, error)
 	GetState(context.Context, *empty.Empty) (*GetStateResponse, error)
+	GetStateAtSlot(context.Context, *GetStateAtSlotRequest) (*GetStateResponse, error)
+	GetStateForBlock(context.Context, *GetStateForBlockRequest) (*GetStateResponse, error)
 	GetStateRoot(context.Context, *empty.Empty) (*GetStateRootResponse, error)
+	GetFinalizedCheckpoint(context.Context, *empty.Empty) (*Checkpoint, error)
 	GetEpochInformation(context.Context, *EpochInformationRequest) (*EpochInformationResponse, error)
+	GetValidatorDuties(context.Context, *GetValidatorDutiesRequest) (*GetValidatorDutiesResponse, error)
 	GetForkData(context.Context, *empty.Empty) (*ForkData, error)
 	GetProposerForSlot(context.Context, *GetProposerForSlotRequest) (*GetProposerForSlotResponse, error)
 	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
 	SubmitAttestation(context.Context, *Attestation) (*empty.Empty, error)
+	SubmitProposerSlashing(context.Context, *ProposerSlashing) (*empty.Empty, error)
+	SubmitCasperSlashing(context.Context, *CasperSlashing) (*empty.Empty, error)
+	SubmitDeposit(context.Context, *Deposit) (*empty.Empty, error)
+	SetDepositRoot(context.Context, *SetDepositRootRequest) (*empty.Empty, error)
+	SubmitExit(context.Context, *Exit) (*empty.Empty, error)
 	GetMempool(context.Context, *MempoolRequest) (*BlockBody, error)
 	GetValidatorInformation(context.Context, *GetValidatorRequest) (*Validator, error)
+	SubscribeChainEvents(*SubscribeChainEventsRequest, BlockchainRPC_SubscribeChainEventsServer) error
 }
 
 func RegisterBlockchainRPCServer(s *grpc.Server, srv BlockchainRPCServer) {
@@ -1401,6 +2037,42 @@ func _BlockchainRPC_GetState_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainRPC_GetStateAtSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateAtSlotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).GetStateAtSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/GetStateAtSlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).GetStateAtSlot(ctx, req.(*GetStateAtSlotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainRPC_GetStateForBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateForBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).GetStateForBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/GetStateForBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).GetStateForBlock(ctx, req.(*GetStateForBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainRPC_GetStateRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -1419,6 +2091,24 @@ func _BlockchainRPC_GetStateRoot_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainRPC_GetFinalizedCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).GetFinalizedCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/GetFinalizedCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).GetFinalizedCheckpoint(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainRPC_GetEpochInformation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EpochInformationRequest)
 	if err := dec(in); err != nil {
@@ -1437,6 +2127,24 @@ func _BlockchainRPC_GetEpochInformation_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainRPC_GetValidatorDuties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorDutiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).GetValidatorDuties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/GetValidatorDuties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).GetValidatorDuties(ctx, req.(*GetValidatorDutiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainRPC_GetForkData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -1509,6 +2217,96 @@ func _BlockchainRPC_SubmitAttestation_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainRPC_SubmitProposerSlashing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposerSlashing)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).SubmitProposerSlashing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/SubmitProposerSlashing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).SubmitProposerSlashing(ctx, req.(*ProposerSlashing))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainRPC_SubmitCasperSlashing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CasperSlashing)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).SubmitCasperSlashing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/SubmitCasperSlashing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).SubmitCasperSlashing(ctx, req.(*CasperSlashing))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainRPC_SubmitDeposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Deposit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).SubmitDeposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/SubmitDeposit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).SubmitDeposit(ctx, req.(*Deposit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainRPC_SetDepositRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDepositRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).SetDepositRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/SetDepositRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).SetDepositRoot(ctx, req.(*SetDepositRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainRPC_SubmitExit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Exit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainRPCServer).SubmitExit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BlockchainRPC/SubmitExit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainRPCServer).SubmitExit(ctx, req.(*Exit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainRPC_GetMempool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MempoolRequest)
 	if err := dec(in); err != nil {
@@ -1545,6 +2343,27 @@ func _BlockchainRPC_GetValidatorInformation_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainRPC_SubscribeChainEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeChainEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockchainRPCServer).SubscribeChainEvents(m, &blockchainRPCSubscribeChainEventsServer{stream})
+}
+
+type BlockchainRPC_SubscribeChainEventsServer interface {
+	Send(*ChainEvent) error
+	grpc.ServerStream
+}
+
+type blockchainRPCSubscribeChainEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockchainRPCSubscribeChainEventsServer) Send(m *ChainEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _BlockchainRPC_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.BlockchainRPC",
 	HandlerType: (*BlockchainRPCServer)(nil),
@@ -1569,14 +2388,30 @@ var _BlockchainRPC_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetState",
 			Handler:    _BlockchainRPC_GetState_Handler,
 		},
+		{
+			MethodName: "GetStateAtSlot",
+			Handler:    _BlockchainRPC_GetStateAtSlot_Handler,
+		},
+		{
+			MethodName: "GetStateForBlock",
+			Handler:    _BlockchainRPC_GetStateForBlock_Handler,
+		},
 		{
 			MethodName: "GetStateRoot",
 			Handler:    _BlockchainRPC_GetStateRoot_Handler,
 		},
+		{
+			MethodName: "GetFinalizedCheckpoint",
+			Handler:    _BlockchainRPC_GetFinalizedCheckpoint_Handler,
+		},
 		{
 			MethodName: "GetEpochInformation",
 			Handler:    _BlockchainRPC_GetEpochInformation_Handler,
 		},
+		{
+			MethodName: "GetValidatorDuties",
+			Handler:    _BlockchainRPC_GetValidatorDuties_Handler,
+		},
 		{
 			MethodName: "GetForkData",
 			Handler:    _BlockchainRPC_GetForkData_Handler,
@@ -1593,6 +2428,26 @@ var _BlockchainRPC_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitAttestation",
 			Handler:    _BlockchainRPC_SubmitAttestation_Handler,
 		},
+		{
+			MethodName: "SubmitProposerSlashing",
+			Handler:    _BlockchainRPC_SubmitProposerSlashing_Handler,
+		},
+		{
+			MethodName: "SubmitCasperSlashing",
+			Handler:    _BlockchainRPC_SubmitCasperSlashing_Handler,
+		},
+		{
+			MethodName: "SubmitDeposit",
+			Handler:    _BlockchainRPC_SubmitDeposit_Handler,
+		},
+		{
+			MethodName: "SetDepositRoot",
+			Handler:    _BlockchainRPC_SetDepositRoot_Handler,
+		},
+		{
+			MethodName: "SubmitExit",
+			Handler:    _BlockchainRPC_SubmitExit_Handler,
+		},
 		{
 			MethodName: "GetMempool",
 			Handler:    _BlockchainRPC_GetMempool_Handler,
@@ -1602,77 +2457,115 @@ var _BlockchainRPC_serviceDesc = grpc.ServiceDesc{
 			Handler:    _BlockchainRPC_GetValidatorInformation_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeChainEvents",
+			Handler:       _BlockchainRPC_SubscribeChainEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "rpc.proto",
 }
 
-func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_4533f8b5074d69a3) }
-
-var fileDescriptor_rpc_4533f8b5074d69a3 = []byte{
-	// 1026 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x6d, 0x73, 0xda, 0x46,
-	0x10, 0x2e, 0x7e, 0x0b, 0x2c, 0xe0, 0xd0, 0x33, 0xb5, 0xa9, 0xec, 0xb8, 0xcc, 0x4d, 0xd2, 0xf1,
-	0xa4, 0x53, 0xdc, 0xe2, 0xd8, 0x93, 0xcc, 0xf4, 0x8d, 0x18, 0x4c, 0x9c, 0x49, 0x6b, 0x46, 0xd0,
-	0x7e, 0x17, 0xe2, 0x6c, 0x6b, 0x8c, 0x74, 0x54, 0x77, 0x74, 0x9a, 0xaf, 0x9d, 0xfe, 0xca, 0xfe,
-	0x9a, 0xce, 0xad, 0x4e, 0xd2, 0x09, 0x89, 0x24, 0xdf, 0xb4, 0xcf, 0xbe, 0x6a, 0xef, 0xb9, 0xbd,
-	0x85, 0x4a, 0xb8, 0x70, 0x3b, 0x8b, 0x90, 0x4b, 0x4e, 0x36, 0x16, 0x53, 0xeb, 0xf0, 0x8e, 0xf3,
-	0xbb, 0x39, 0x3b, 0x45, 0x64, 0xba, 0xbc, 0x3d, 0x65, 0xfe, 0x42, 0xbe, 0x8f, 0x0c, 0xac, 0x9a,
-	0xcb, 0x7d, 0x9f, 0x07, 0x91, 0x44, 0x2f, 0x60, 0xf7, 0x57, 0xe6, 0x2f, 0x38, 0x9f, 0xdb, 0xec,
-	0xcf, 0x25, 0x13, 0x92, 0x3c, 0x85, 0xfa, 0x3b, 0x47, 0xc8, 0xd7, 0x73, 0xee, 0x3e, 0xbc, 0x71,
-	0xc4, 0x7d, 0xab, 0xd4, 0x2e, 0x9d, 0xd4, 0xec, 0x2c, 0x48, 0x9f, 0xc1, 0xde, 0x90, 0xc9, 0x3f,
-	0x9c, 0xb9, 0x37, 0x73, 0x24, 0x0f, 0x63, 0xe7, 0x5d, 0xd8, 0xb8, 0xee, 0xa3, 0x47, 0xdd, 0xde,
-	0xb8, 0xee, 0xd3, 0x67, 0xf0, 0x78, 0xc8, 0x22, 0xb7, 0xd8, 0x84, 0xc0, 0x96, 0x11, 0x16, 0xbf,
-	0xe9, 0x19, 0x34, 0x52, 0x33, 0xb1, 0xe0, 0x81, 0x60, 0xe4, 0x2b, 0xd8, 0x46, 0x00, 0x0d, 0xab,
-	0xdd, 0x4a, 0x67, 0x31, 0xed, 0x44, 0x16, 0x11, 0x4e, 0x4f, 0xe1, 0xcb, 0x21, 0x93, 0xa3, 0x90,
-	0x2f, 0xb8, 0x60, 0xe1, 0x15, 0x0f, 0xc7, 0x73, 0x2e, 0x8d, 0x2c, 0x4a, 0x44, 0xe7, 0x2d, 0x1b,
-	0xbf, 0xe9, 0x4b, 0xb0, 0x8a, 0x1c, 0x74, 0x3e, 0x0b, 0xca, 0xb1, 0x4a, 0xff, 0x40, 0x22, 0xd3,
-	0x57, 0x70, 0x30, 0x58, 0x70, 0xf7, 0xfe, 0x3a, 0xb8, 0xe5, 0xa1, 0xef, 0x48, 0x8f, 0x07, 0x71,
-	0xa2, 0x63, 0x00, 0xad, 0x9a, 0xb1, 0xbf, 0x75, 0x3a, 0x03, 0xa1, 0xff, 0x96, 0xa0, 0x95, 0xf7,
-	0xd5, 0x39, 0xbf, 0x83, 0xbd, 0x37, 0x8e, 0x58, 0x55, 0x63, 0x94, 0xb2, 0x5d, 0xa4, 0x22, 0x17,
-	0x50, 0x35, 0x2d, 0x37, 0xb0, 0x37, 0x4d, 0xd5, 0x9b, 0x5c, 0x12, 0xd3, 0x90, 0xfe, 0xb3, 0x05,
-	0x8d, 0x5c, 0xb0, 0x09, 0x1c, 0x8c, 0xef, 0x9d, 0x70, 0x76, 0xc9, 0x7d, 0xdf, 0x93, 0x92, 0x31,
-	0xa1, 0x9b, 0x22, 0x5a, 0xa5, 0xf6, 0xe6, 0x49, 0xb5, 0x6b, 0xa9, 0xc0, 0xc5, 0x26, 0xf6, 0x3a,
-	0xd7, 0xa4, 0xf5, 0xaa, 0xb6, 0xcd, 0xa8, 0xf5, 0xe4, 0x15, 0x34, 0xde, 0x39, 0x92, 0x09, 0x79,
-	0x19, 0x72, 0x21, 0xe6, 0x5e, 0xf0, 0x20, 0x5a, 0x9b, 0x98, 0xa2, 0xae, 0x52, 0x24, 0xa8, 0x9d,
-	0x33, 0x23, 0x5f, 0xc3, 0xee, 0xdb, 0xa5, 0x90, 0xde, 0xad, 0xc7, 0x66, 0xf8, 0x07, 0xad, 0x2d,
-	0x6c, 0xf2, 0x0a, 0xaa, 0x78, 0x9b, 0x20, 0x48, 0xb0, 0xed, 0x88, 0xb7, 0x19, 0x50, 0x1d, 0xd7,
-	0xc4, 0x09, 0xef, 0x98, 0x44, 0x93, 0x1d, 0x34, 0x31, 0x10, 0xd2, 0x01, 0x32, 0x0a, 0xd9, 0x5f,
-	0x1e, 0x5f, 0x0a, 0xc3, 0xee, 0x11, 0xda, 0x15, 0x68, 0xc8, 0x05, 0xec, 0xc7, 0xe8, 0x4a, 0x95,
-	0x65, 0xac, 0x72, 0x8d, 0x96, 0xbc, 0x80, 0x2f, 0x72, 0x1a, 0x4c, 0x55, 0xc1, 0x54, 0xc5, 0x4a,
-	0xf2, 0x63, 0x5a, 0x9d, 0xd1, 0x48, 0x28, 0x6a, 0x64, 0x81, 0x21, 0xed, 0x00, 0xe9, 0x7b, 0xc2,
-	0xe5, 0x41, 0xc0, 0xdc, 0x94, 0xf8, 0x2d, 0x78, 0x34, 0x5e, 0xba, 0x2e, 0x13, 0x42, 0x13, 0x2f,
-	0x16, 0xe9, 0xf7, 0x70, 0x38, 0x64, 0x32, 0x7f, 0xf4, 0x1f, 0xb8, 0x63, 0x7d, 0x68, 0x0f, 0x99,
-	0x54, 0x9f, 0xbd, 0x60, 0x86, 0x0c, 0xe9, 0x09, 0xe1, 0xdd, 0x05, 0x3e, 0x0b, 0x12, 0xbf, 0x36,
-	0x54, 0x93, 0xc1, 0x91, 0x4c, 0x0b, 0x13, 0xa2, 0x33, 0xd8, 0x2f, 0x0e, 0x81, 0xc5, 0x2a, 0x28,
-	0xf1, 0x8b, 0xc5, 0x0c, 0xed, 0x74, 0x35, 0xe4, 0x08, 0xb6, 0x6c, 0x3e, 0x67, 0xad, 0xcd, 0x76,
-	0xe9, 0x64, 0xb7, 0x5b, 0x56, 0x1d, 0x52, 0xb2, 0x8d, 0x28, 0x3d, 0x07, 0x32, 0x5e, 0x4e, 0x7d,
-	0x2f, 0x3b, 0x9f, 0x3e, 0x3a, 0x77, 0xce, 0x60, 0x2f, 0xe3, 0xa6, 0xdb, 0x78, 0x04, 0x95, 0xd5,
-	0x99, 0x99, 0x02, 0xd4, 0x06, 0xa2, 0x2a, 0xfa, 0x6d, 0xe9, 0x4f, 0x59, 0x98, 0xf8, 0x1c, 0x03,
-	0xa4, 0x68, 0x3c, 0x3c, 0x52, 0x24, 0x1b, 0x73, 0x63, 0x35, 0xe6, 0x39, 0xce, 0xe0, 0x44, 0x36,
-	0x26, 0xd2, 0x87, 0x82, 0xd2, 0xe7, 0xd0, 0xcc, 0xba, 0xe9, 0x62, 0x8a, 0x06, 0x73, 0x17, 0x47,
-	0x66, 0x72, 0x34, 0x3d, 0x89, 0x43, 0x2d, 0xce, 0xd4, 0x84, 0xed, 0x74, 0xec, 0xd5, 0xed, 0x48,
-	0xa0, 0x6f, 0x91, 0x35, 0x79, 0x1f, 0x9d, 0xe6, 0x1b, 0xa8, 0x24, 0x3a, 0xdd, 0x63, 0xa4, 0x6e,
-	0xfa, 0x96, 0xa4, 0x7a, 0xfa, 0x3b, 0x3c, 0x31, 0x19, 0x98, 0x28, 0xc4, 0x27, 0xfe, 0xac, 0x2a,
-	0x11, 0x09, 0x82, 0xdd, 0xab, 0xdb, 0x91, 0xa0, 0xdf, 0x9b, 0xb1, 0x74, 0x24, 0x33, 0xdf, 0x1b,
-	0xa1, 0x00, 0xf3, 0xdc, 0x23, 0x8b, 0x08, 0xa7, 0x2f, 0xb0, 0x6f, 0x11, 0xc4, 0x8d, 0x87, 0xe3,
-	0x08, 0x2a, 0x09, 0x18, 0x1f, 0x7c, 0x02, 0xd0, 0x1b, 0x38, 0x5e, 0xf7, 0x07, 0xda, 0xff, 0x5b,
-	0x80, 0x14, 0xd5, 0x83, 0x77, 0xa5, 0x23, 0x86, 0x01, 0xbd, 0x82, 0xa7, 0x85, 0x01, 0xaf, 0x83,
-	0x99, 0xe7, 0x32, 0x61, 0x72, 0x6b, 0x25, 0x6c, 0xdd, 0x8c, 0xf3, 0x9c, 0x46, 0x77, 0x83, 0xd4,
-	0xa0, 0xdc, 0x9b, 0x4c, 0x06, 0xe3, 0xc9, 0xc0, 0x6e, 0x7c, 0xa6, 0xa4, 0x91, 0x7d, 0x33, 0xba,
-	0x19, 0x0f, 0xec, 0x46, 0xa9, 0xfb, 0xdf, 0x0e, 0xd4, 0x91, 0x28, 0xee, 0xbd, 0xe3, 0x05, 0xf6,
-	0xe8, 0x92, 0xfc, 0x04, 0x55, 0x83, 0xfc, 0x64, 0x1f, 0xbb, 0x94, 0xbb, 0x44, 0xd6, 0x41, 0x0e,
-	0xd7, 0x55, 0xfd, 0x0c, 0x75, 0x3d, 0x1f, 0xf4, 0x01, 0xed, 0x77, 0xa2, 0x65, 0xa5, 0x13, 0x2f,
-	0x2b, 0x9d, 0x81, 0x5a, 0x56, 0xac, 0x28, 0x72, 0xfe, 0xca, 0xf4, 0xa0, 0x66, 0xb2, 0x97, 0x60,
-	0xa6, 0x82, 0x6b, 0x60, 0xb5, 0xf2, 0x0a, 0x1d, 0xa2, 0x8f, 0xa7, 0x9f, 0xd9, 0x67, 0xd6, 0x96,
-	0xb1, 0x3e, 0xca, 0x4b, 0x28, 0xc7, 0x74, 0x58, 0xeb, 0xdd, 0xd4, 0xde, 0x59, 0xa6, 0xfd, 0x82,
-	0xbf, 0x90, 0x50, 0xe4, 0xa3, 0xb9, 0xf3, 0x94, 0x1b, 0xe1, 0xcd, 0xcf, 0xbd, 0xe7, 0x87, 0x85,
-	0x7b, 0x80, 0xee, 0xc7, 0x51, 0xb1, 0x52, 0x47, 0x3c, 0x83, 0xea, 0x90, 0xc9, 0x2b, 0x1e, 0x3e,
-	0xf4, 0x1d, 0xe9, 0xac, 0x2d, 0xa9, 0xa6, 0x82, 0x24, 0x56, 0x63, 0x20, 0xf9, 0x85, 0x8a, 0x3c,
-	0xd1, 0x65, 0x17, 0x6f, 0x66, 0xd6, 0xf1, 0x3a, 0xb5, 0xae, 0xe4, 0x1c, 0xfb, 0x1a, 0xd1, 0x6b,
-	0xcf, 0xec, 0x7e, 0x1c, 0xa0, 0x99, 0x05, 0xb5, 0xdb, 0x0f, 0xf0, 0x79, 0xc4, 0xb7, 0x9e, 0x54,
-	0x0b, 0x44, 0xd4, 0x90, 0xc7, 0xca, 0xd4, 0x00, 0xac, 0x35, 0xff, 0x45, 0x4e, 0x01, 0x86, 0x4c,
-	0xea, 0x4d, 0x98, 0x10, 0xe5, 0x96, 0x5d, 0x8b, 0xad, 0x7a, 0xf2, 0x0e, 0xbc, 0xe6, 0xb3, 0xf7,
-	0xa4, 0x07, 0x07, 0xe6, 0x90, 0x33, 0x4f, 0x21, 0x66, 0xe4, 0xea, 0x72, 0x6c, 0x65, 0x2f, 0xf5,
-	0x74, 0x07, 0x6b, 0x38, 0xfb, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x67, 0xf6, 0xde, 0xc8, 0xbd, 0x0b,
-	0x00, 0x00,
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_rpc_88fafde8a15a785d) }
+
+var fileDescriptor_rpc_88fafde8a15a785d = []byte{
+	// 1539 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0xdb, 0x73, 0xd3, 0x46,
+	0x17, 0x47, 0x8e, 0x13, 0x9c, 0xe3, 0x0b, 0x66, 0x63, 0x1c, 0xe3, 0x80, 0xc9, 0x68, 0x80, 0xc9,
+	0xc0, 0xf7, 0x39, 0x7c, 0x09, 0xf0, 0xc1, 0x4c, 0x2f, 0x38, 0xbe, 0xc5, 0x34, 0x25, 0xa9, 0x6c,
+	0xfa, 0xd0, 0x97, 0x8e, 0x2c, 0x2f, 0x89, 0x26, 0xb6, 0xd6, 0x95, 0xd6, 0x0c, 0xe1, 0xb1, 0xd3,
+	0xe9, 0x6b, 0x9f, 0xfb, 0xd0, 0x3f, 0xb5, 0x33, 0x9d, 0xbd, 0x48, 0x5a, 0xdd, 0x42, 0xdf, 0xb4,
+	0xbf, 0x73, 0x3f, 0x7b, 0xce, 0xd9, 0x5d, 0xc1, 0xa6, 0xbb, 0xb4, 0xda, 0x4b, 0x97, 0x50, 0x82,
+	0x72, 0xcb, 0x69, 0x73, 0xe7, 0x9c, 0x90, 0xf3, 0x39, 0xde, 0xe7, 0xc8, 0x74, 0xf5, 0x61, 0x1f,
+	0x2f, 0x96, 0xf4, 0x4a, 0x30, 0x34, 0x4b, 0x16, 0x59, 0x2c, 0x88, 0x23, 0x56, 0xfa, 0x9f, 0x1a,
+	0xec, 0x8c, 0x57, 0x53, 0xcf, 0x72, 0xed, 0x29, 0xee, 0x5e, 0x98, 0xb6, 0xd3, 0xff, 0x88, 0x1d,
+	0xea, 0x19, 0xf8, 0x97, 0x15, 0xf6, 0x28, 0xaa, 0xc3, 0xc6, 0xd1, 0x9c, 0x58, 0x97, 0x5e, 0x43,
+	0xdb, 0xd5, 0xf6, 0x0a, 0x86, 0x5c, 0xa1, 0x1a, 0xac, 0x1f, 0x63, 0x73, 0xe6, 0x35, 0x72, 0x1c,
+	0x16, 0x0b, 0xf4, 0x18, 0x2a, 0x6f, 0x57, 0x1e, 0xb5, 0x3f, 0xd8, 0x96, 0x49, 0x6d, 0xe2, 0x78,
+	0x8d, 0x35, 0x4e, 0x8e, 0xa1, 0xe8, 0x21, 0x94, 0x07, 0xb6, 0x63, 0xce, 0xed, 0xcf, 0x92, 0x2d,
+	0xcf, 0xd9, 0xa2, 0xa0, 0xfe, 0x97, 0x06, 0x10, 0xba, 0x84, 0x1e, 0x43, 0x7e, 0x72, 0xb5, 0xc4,
+	0xdc, 0x91, 0xca, 0x01, 0x6a, 0x2f, 0xa7, 0xed, 0x90, 0xca, 0x28, 0x06, 0xa7, 0xa3, 0x7b, 0xb0,
+	0xc9, 0x9d, 0x3c, 0x36, 0xbd, 0x0b, 0xee, 0x5e, 0xc9, 0x08, 0x01, 0x84, 0x20, 0x3f, 0x9e, 0x13,
+	0xca, 0x1d, 0xcb, 0x1b, 0xfc, 0x9b, 0x05, 0xd3, 0x5f, 0x12, 0xeb, 0x82, 0xbb, 0x91, 0x37, 0xc4,
+	0x02, 0x3d, 0x80, 0x75, 0x2e, 0xd6, 0x58, 0xdf, 0xd5, 0xf6, 0x8a, 0x07, 0x9b, 0xcc, 0x20, 0x07,
+	0x0c, 0x81, 0xeb, 0xaf, 0xe1, 0xce, 0x18, 0xd3, 0x1e, 0x5e, 0x12, 0xcf, 0xa6, 0x06, 0x21, 0xd4,
+	0x4f, 0xda, 0x2e, 0x14, 0x15, 0x94, 0x3b, 0x5c, 0x32, 0x54, 0x48, 0x7f, 0x09, 0x95, 0xef, 0xf1,
+	0x62, 0x49, 0xc8, 0xdc, 0x97, 0x79, 0x08, 0xe5, 0x13, 0xd3, 0xa3, 0xa1, 0xe7, 0x42, 0x2a, 0x0a,
+	0xea, 0x8f, 0x60, 0x6b, 0x88, 0xe9, 0x8f, 0xe6, 0xdc, 0x9e, 0x99, 0x94, 0xb8, 0xbe, 0x70, 0x05,
+	0x72, 0xa3, 0x1e, 0x97, 0x28, 0x1b, 0xb9, 0x51, 0x4f, 0x7f, 0x04, 0xb7, 0x86, 0x58, 0x88, 0xf9,
+	0x2c, 0x08, 0xf2, 0x8a, 0x5a, 0xfe, 0xad, 0x1f, 0x42, 0x35, 0x64, 0xf3, 0x96, 0xc4, 0xf1, 0x70,
+	0x18, 0xb5, 0x96, 0x11, 0xf5, 0x3e, 0xdc, 0x1d, 0x62, 0x7a, 0xe6, 0x92, 0x25, 0xf1, 0xb0, 0x3b,
+	0x20, 0x2e, 0x4b, 0xa1, 0x62, 0x85, 0x67, 0x57, 0x0b, 0xb3, 0xab, 0xbf, 0x82, 0x66, 0x9a, 0x80,
+	0xb4, 0xd7, 0x84, 0x82, 0x4f, 0x92, 0x01, 0x04, 0x6b, 0xfd, 0x1c, 0xb6, 0xf9, 0x56, 0x8c, 0x9c,
+	0x0f, 0xc4, 0x5d, 0xf0, 0xaa, 0xf0, 0x0d, 0xb5, 0x00, 0x24, 0x69, 0x86, 0x3f, 0x49, 0x73, 0x0a,
+	0x82, 0xfe, 0x03, 0xb7, 0xfb, 0x9f, 0xac, 0xf9, 0x6a, 0x86, 0xbb, 0x64, 0xb1, 0xb0, 0x29, 0xc5,
+	0xd8, 0xaf, 0xd5, 0x24, 0x41, 0xff, 0x4d, 0x83, 0x46, 0xd2, 0x92, 0xf4, 0xf0, 0x19, 0x6c, 0x1d,
+	0x9b, 0x5e, 0x9c, 0x2c, 0xfb, 0x21, 0x8d, 0x84, 0x5e, 0x42, 0x51, 0xe5, 0xcc, 0xf1, 0x4c, 0xd6,
+	0x58, 0x26, 0x13, 0x46, 0x54, 0x46, 0xfd, 0xd7, 0x3c, 0x54, 0x13, 0xca, 0x26, 0xb0, 0x3d, 0xbe,
+	0x30, 0xdd, 0x59, 0xe8, 0xae, 0x4c, 0x21, 0x6b, 0xc9, 0xb5, 0xbd, 0xe2, 0x41, 0x93, 0x29, 0x4e,
+	0x67, 0x31, 0xb2, 0x44, 0x83, 0x8d, 0x62, 0xbe, 0xad, 0xc9, 0x36, 0x78, 0x0d, 0xd5, 0x13, 0x93,
+	0x62, 0x8f, 0x76, 0x5d, 0xe2, 0x79, 0x73, 0xdb, 0xb9, 0x64, 0xfd, 0xcb, 0x4c, 0x94, 0x79, 0xb3,
+	0xf9, 0xa8, 0x91, 0x60, 0x53, 0x1a, 0x1f, 0xcf, 0xd4, 0x56, 0x8a, 0xa1, 0xac, 0xca, 0x03, 0x84,
+	0x97, 0xe3, 0xba, 0xa8, 0xf2, 0x08, 0xc8, 0x36, 0x77, 0x62, 0xba, 0xe7, 0x98, 0x72, 0x96, 0x0d,
+	0xce, 0xa2, 0x20, 0xa8, 0x0d, 0xe8, 0xcc, 0xc5, 0x1f, 0x6d, 0xb2, 0xf2, 0x14, 0xbe, 0x9b, 0x9c,
+	0x2f, 0x85, 0x82, 0x5e, 0x42, 0xdd, 0x47, 0x63, 0x5e, 0x16, 0xb8, 0x97, 0x19, 0x54, 0xf4, 0x1c,
+	0xee, 0x24, 0x28, 0xdc, 0xd4, 0x26, 0x37, 0x95, 0x4e, 0x44, 0x5f, 0x87, 0xde, 0x29, 0x89, 0x84,
+	0xb4, 0x44, 0xa6, 0x30, 0xea, 0x3f, 0xc0, 0x5d, 0xb5, 0xc5, 0x7b, 0x2b, 0x6a, 0xe3, 0x60, 0x1c,
+	0x07, 0x93, 0x4a, 0x53, 0x27, 0x55, 0x0b, 0x20, 0xe0, 0x67, 0x55, 0xbe, 0xb6, 0x57, 0x36, 0x14,
+	0x44, 0xff, 0x5d, 0x83, 0x52, 0x87, 0xb2, 0x2d, 0xc3, 0x4c, 0xdf, 0x55, 0x5a, 0x9b, 0x32, 0xd5,
+	0xbc, 0x58, 0x78, 0x49, 0xe4, 0x0d, 0xb1, 0x60, 0x1b, 0x1b, 0x54, 0x8f, 0xe8, 0x35, 0x31, 0x38,
+	0x63, 0x28, 0xdb, 0xd8, 0x00, 0x19, 0xdb, 0x9f, 0xb1, 0xdc, 0xff, 0x28, 0xa8, 0xff, 0xa1, 0xc1,
+	0xad, 0x58, 0x64, 0x6c, 0x5c, 0x07, 0x90, 0x9c, 0x00, 0x21, 0xc0, 0xf4, 0xfa, 0xe3, 0x40, 0xd4,
+	0x3c, 0x8b, 0x2e, 0x6f, 0x44, 0x41, 0xf4, 0x0a, 0x2a, 0x4a, 0x7c, 0x36, 0xf6, 0xeb, 0xb6, 0xca,
+	0xd2, 0xad, 0x46, 0x6e, 0xc4, 0xf8, 0xf4, 0x9f, 0xf9, 0x70, 0x4a, 0x64, 0x5b, 0xb6, 0x7e, 0x7a,
+	0xba, 0x9f, 0xc2, 0x86, 0xb4, 0x92, 0xe3, 0x56, 0xb6, 0x98, 0x95, 0xb8, 0x0a, 0xc9, 0xa2, 0xb7,
+	0x01, 0xf5, 0x6c, 0xcf, 0x22, 0x8e, 0x83, 0xad, 0x70, 0xea, 0x35, 0xe0, 0xe6, 0x78, 0x65, 0x59,
+	0xd8, 0xf3, 0xcf, 0x55, 0x7f, 0xa9, 0xff, 0x0f, 0x76, 0x86, 0x98, 0x26, 0x3b, 0xf9, 0x9a, 0x01,
+	0xdb, 0x83, 0xdd, 0x21, 0xa6, 0xec, 0xb3, 0xe3, 0xcc, 0xf8, 0xb6, 0x75, 0x3c, 0xcf, 0x3e, 0x77,
+	0x16, 0xd8, 0x51, 0x8f, 0xa4, 0xc0, 0xc3, 0xe0, 0xa8, 0x50, 0x21, 0x7d, 0x06, 0xf5, 0x74, 0x15,
+	0xdc, 0x59, 0x06, 0x05, 0x72, 0xfe, 0x32, 0x32, 0x45, 0xfc, 0x3a, 0xba, 0x07, 0x79, 0x83, 0xcc,
+	0x31, 0xaf, 0x93, 0xca, 0x41, 0x81, 0xe5, 0x86, 0xad, 0x0d, 0x8e, 0xea, 0x2f, 0x00, 0x8d, 0x57,
+	0xd3, 0x85, 0x1d, 0x3d, 0x9c, 0xbe, 0x78, 0xe8, 0x1c, 0xc2, 0x56, 0x44, 0x4c, 0xa6, 0x31, 0x72,
+	0xd4, 0x6b, 0xb1, 0xa3, 0x5e, 0x37, 0x00, 0x31, 0x8f, 0xde, 0xad, 0x16, 0x53, 0xec, 0x06, 0x32,
+	0x2d, 0x80, 0x10, 0xf5, 0x4f, 0x8e, 0x10, 0xb9, 0xfe, 0xfa, 0xa0, 0xbf, 0xe0, 0x07, 0x70, 0xb0,
+	0x56, 0x8e, 0xa3, 0xeb, 0x94, 0xea, 0x4f, 0xa0, 0x16, 0x15, 0x93, 0xce, 0xa4, 0x9d, 0xca, 0x07,
+	0xd1, 0x92, 0xec, 0x50, 0xde, 0x61, 0xca, 0x04, 0x08, 0xcf, 0xbc, 0xb2, 0x21, 0x16, 0xfa, 0x5b,
+	0xd8, 0x49, 0x95, 0x91, 0x66, 0x9e, 0xc6, 0x7b, 0x4c, 0x4e, 0xa2, 0x00, 0x54, 0x5a, 0x4e, 0x7f,
+	0x0f, 0xf7, 0xd5, 0x0a, 0x0c, 0x08, 0xde, 0xbf, 0x0c, 0x36, 0x3a, 0x49, 0xca, 0x72, 0x92, 0xc8,
+	0xcb, 0xc6, 0x98, 0x9a, 0x14, 0xab, 0x97, 0x0d, 0x8f, 0x01, 0xea, 0xbe, 0x0b, 0x0e, 0x81, 0xeb,
+	0x4f, 0xe1, 0x8e, 0x2f, 0xd4, 0xa1, 0x5f, 0xea, 0x83, 0xff, 0xc3, 0xb6, 0xcf, 0x3c, 0x20, 0x6e,
+	0xa4, 0xc0, 0xae, 0x2f, 0x94, 0xe7, 0x50, 0xf3, 0x05, 0xc5, 0x35, 0x2e, 0x2c, 0xaf, 0x00, 0xf4,
+	0xa5, 0x02, 0x40, 0x3f, 0x85, 0x56, 0x56, 0x9e, 0xa4, 0xfc, 0x7f, 0x23, 0x73, 0x59, 0x0b, 0x4f,
+	0x80, 0x30, 0xef, 0xea, 0x98, 0x1e, 0xc0, 0xc3, 0x54, 0x85, 0x23, 0x67, 0x66, 0x5b, 0xca, 0x54,
+	0x6a, 0x25, 0xd4, 0x46, 0xc6, 0xfd, 0x93, 0x2e, 0x54, 0xa2, 0x17, 0x63, 0xb4, 0x09, 0xeb, 0x47,
+	0x27, 0xa7, 0xdd, 0xef, 0xaa, 0x37, 0x50, 0x01, 0xf2, 0xc7, 0xfd, 0x4e, 0xaf, 0xaa, 0xa1, 0x32,
+	0x6c, 0xbe, 0x7d, 0x3f, 0x9e, 0x8c, 0x06, 0xa3, 0x7e, 0xaf, 0x9a, 0x63, 0xcb, 0xc1, 0xe8, 0x5d,
+	0xe7, 0x64, 0xf4, 0x53, 0xbf, 0x57, 0x5d, 0x7b, 0xa2, 0x8b, 0x36, 0x46, 0x25, 0x28, 0x74, 0x26,
+	0x93, 0xfe, 0x78, 0xd2, 0x37, 0xaa, 0x37, 0xd8, 0xea, 0xcc, 0x38, 0x3d, 0x3b, 0x1d, 0xf7, 0x8d,
+	0xaa, 0x76, 0xf0, 0x77, 0x11, 0xca, 0x3c, 0x8b, 0x16, 0x33, 0x67, 0x9c, 0x75, 0xd1, 0x37, 0x50,
+	0x54, 0xfa, 0x14, 0xd5, 0xf9, 0x86, 0x26, 0xfa, 0xbd, 0xb9, 0x9d, 0xc0, 0x65, 0x68, 0xdf, 0x42,
+	0x59, 0x8e, 0x32, 0x59, 0x4b, 0xf5, 0xb6, 0x78, 0xcb, 0xb4, 0xfd, 0xb7, 0x4c, 0xbb, 0xcf, 0xde,
+	0x32, 0x4d, 0xa1, 0x39, 0xd9, 0xdd, 0x1d, 0x28, 0xa9, 0x8d, 0x86, 0xb8, 0xa5, 0x94, 0x8e, 0x6d,
+	0x36, 0x92, 0x04, 0xa9, 0xa2, 0xc7, 0x0b, 0x35, 0x72, 0xef, 0xce, 0x74, 0x23, 0x5b, 0xcb, 0x2b,
+	0x28, 0xf8, 0x35, 0x95, 0x29, 0x5d, 0x93, 0xd2, 0xd1, 0xa6, 0xe8, 0x40, 0x25, 0x5a, 0xf3, 0xe8,
+	0xae, 0xca, 0x17, 0xe9, 0x83, 0x0c, 0x15, 0x7d, 0xa8, 0xc6, 0x3b, 0x01, 0xed, 0xa8, 0x9c, 0xb1,
+	0xfe, 0xc8, 0x50, 0xf3, 0x06, 0x4a, 0x01, 0x46, 0x08, 0xfd, 0x62, 0x16, 0x92, 0x1d, 0xf4, 0x06,
+	0xea, 0x43, 0x4c, 0xe5, 0xb3, 0x0e, 0xcf, 0xba, 0x17, 0xd8, 0xba, 0x5c, 0x12, 0xdb, 0xc9, 0xd6,
+	0x55, 0x11, 0xef, 0xba, 0x80, 0xef, 0x8c, 0x0f, 0xdc, 0xc4, 0xad, 0x78, 0x27, 0xf5, 0x36, 0x2d,
+	0xa3, 0xb9, 0x97, 0x4e, 0x94, 0x3e, 0x8d, 0x01, 0x25, 0x8f, 0x7c, 0x74, 0x5f, 0xc6, 0x90, 0x7e,
+	0xf1, 0x6a, 0xb6, 0xb2, 0xc8, 0x52, 0xe9, 0x21, 0x14, 0x59, 0xa0, 0xc4, 0xbd, 0xec, 0x99, 0xd4,
+	0xcc, 0x8c, 0xae, 0xc4, 0xd4, 0x04, 0x5c, 0xc2, 0x93, 0xd8, 0xcb, 0x28, 0xf0, 0x24, 0xfd, 0x89,
+	0xd5, 0x6c, 0x65, 0x91, 0xa5, 0x27, 0x2f, 0x78, 0xe1, 0x89, 0x3d, 0xdf, 0x52, 0xcb, 0x33, 0xbe,
+	0xd7, 0xd1, 0xce, 0xfb, 0x0a, 0x6e, 0x8b, 0x86, 0x14, 0x17, 0x24, 0x91, 0xe5, 0x5b, 0xe1, 0xfd,
+	0x89, 0x03, 0xcd, 0x8c, 0xb8, 0xd0, 0x00, 0xea, 0x42, 0x3a, 0xbc, 0x97, 0x99, 0xde, 0x85, 0xed,
+	0x9c, 0x23, 0x6e, 0x2d, 0x8e, 0x66, 0xea, 0x39, 0x82, 0x9a, 0xd0, 0xd3, 0x35, 0xbd, 0xa5, 0xa2,
+	0x45, 0xbc, 0xf6, 0x23, 0x58, 0xa6, 0x8e, 0xe7, 0x50, 0x16, 0x3a, 0xe4, 0x83, 0x1b, 0x15, 0x99,
+	0xb0, 0x5c, 0x64, 0x4a, 0x75, 0xa1, 0x12, 0x7d, 0xcc, 0x8b, 0xae, 0x4b, 0x7d, 0xe0, 0x67, 0x2a,
+	0x69, 0x03, 0x08, 0xd3, 0xfd, 0x4f, 0x36, 0x45, 0xfc, 0xee, 0xc3, 0xbe, 0x32, 0xf9, 0xf7, 0x01,
+	0x86, 0x98, 0xca, 0x3f, 0x01, 0x22, 0xc8, 0xe8, 0x6f, 0x81, 0x66, 0x39, 0xb8, 0x0a, 0x1d, 0x91,
+	0xd9, 0x15, 0xea, 0xc0, 0xb6, 0x5a, 0x84, 0x6a, 0x47, 0x6c, 0xc7, 0x2b, 0x34, 0xa2, 0x22, 0x40,
+	0xd1, 0x08, 0x6a, 0x69, 0x3f, 0x7c, 0xd0, 0x03, 0x39, 0x93, 0xb3, 0x7e, 0x05, 0xf9, 0x9d, 0xe9,
+	0xe3, 0xcf, 0xb4, 0xe9, 0x06, 0x0f, 0xe7, 0xf0, 0x9f, 0x01, 0x00, 0x98, 0x68, 0xfe, 0xec, 0x7f,
+	0x12, 0x00, 0x00,
 }
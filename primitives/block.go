@@ -117,6 +117,10 @@ type BlockBody struct {
 	Deposits          []Deposit
 	Exits             []Exit
 	Votes             []AggregatedVote
+
+	// DepositRoot is the deposit tree root the proposer votes for. A zero hash doesn't vote
+	// for any root.
+	DepositRoot chainhash.Hash
 }
 
 // Copy returns a copy of the block body.
@@ -159,6 +163,7 @@ func (bb *BlockBody) Copy() BlockBody {
 		Deposits:          newDeposits,
 		Exits:             newExits,
 		Votes:             newVotes,
+		DepositRoot:       bb.DepositRoot,
 	}
 }
 
@@ -195,6 +200,7 @@ func (bb *BlockBody) ToProto() *pb.BlockBody {
 		Deposits:          ds,
 		Exits:             ex,
 		Votes:             vs,
+		DepositRoot:       bb.DepositRoot[:],
 	}
 }
 
@@ -259,12 +265,22 @@ func BlockBodyFromProto(body *pb.BlockBody) (*BlockBody, error) {
 		votes[i] = *v
 	}
 
-	return &BlockBody{
+	newBody := &BlockBody{
 		Attestations:      atts,
 		CasperSlashings:   casperSlashings,
 		ProposerSlashings: proposerSlashings,
 		Deposits:          deposits,
 		Exits:             exits,
 		Votes:             votes,
-	}, nil
+	}
+
+	// blocks stored before deposit root votes were added don't have a deposit root
+	if len(body.DepositRoot) > 0 {
+		err := newBody.DepositRoot.SetBytes(body.DepositRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newBody, nil
 }
@@ -12,6 +12,45 @@ import (
 	ssz "github.com/prysmaticlabs/go-ssz"
 )
 
+// VerifyAttestationSignature checks that the aggregate signature of an attestation was
+// signed by the participants in its participation bitfield.
+func (s *State) VerifyAttestationSignature(att Attestation, c *config.Config) error {
+	participants, err := s.GetAttestationParticipants(att.Data, att.ParticipationBitfield, c)
+	if err != nil {
+		return err
+	}
+
+	dataRoot, err := ssz.HashTreeRoot(AttestationDataAndCustodyBit{Data: att.Data, PoCBit: false})
+	if err != nil {
+		return err
+	}
+
+	groupPublicKey := bls.NewAggregatePublicKey()
+	for _, p := range participants {
+		pub, err := s.ValidatorRegistry[p].GetPublicKey()
+		if err != nil {
+			return err
+		}
+		groupPublicKey.AggregatePubKey(pub)
+	}
+
+	aggSig, err := bls.DeserializeSignature(att.AggregateSig)
+	if err != nil {
+		return err
+	}
+
+	valid, err := bls.VerifySig(groupPublicKey, dataRoot[:], aggSig, GetDomain(s.ForkData, att.Data.Slot, bls.DomainAttestation))
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return fmt.Errorf("attestation signature is invalid. expected committee with members: %v for slot %d shard %d", participants, att.Data.Slot, att.Data.Shard)
+	}
+
+	return nil
+}
+
 // ValidateAttestation checks if the attestation is valid.
 func (s *State) ValidateAttestation(att Attestation, verifySignature bool, c *config.Config) error {
 	if att.Data.TargetEpoch == s.EpochIndex {
@@ -67,38 +106,10 @@ func (s *State) ValidateAttestation(att Attestation, verifySignature bool, c *co
 	}
 
 	if verifySignature {
-		participants, err := s.GetAttestationParticipants(att.Data, att.ParticipationBitfield, c)
-		if err != nil {
-			return err
-		}
-
-		dataRoot, err := ssz.HashTreeRoot(AttestationDataAndCustodyBit{Data: att.Data, PoCBit: false})
-		if err != nil {
-			return err
-		}
-
-		groupPublicKey := bls.NewAggregatePublicKey()
-		for _, p := range participants {
-			pub, err := s.ValidatorRegistry[p].GetPublicKey()
-			if err != nil {
-				return err
-			}
-			groupPublicKey.AggregatePubKey(pub)
-		}
-
-		aggSig, err := bls.DeserializeSignature(att.AggregateSig)
-		if err != nil {
-			return err
-		}
-
-		valid, err := bls.VerifySig(groupPublicKey, dataRoot[:], aggSig, GetDomain(s.ForkData, att.Data.Slot, bls.DomainAttestation))
+		err := s.VerifyAttestationSignature(att, c)
 		if err != nil {
 			return err
 		}
-
-		if !valid {
-			return fmt.Errorf("attestation signature is invalid. expected committee with members: %v for slot %d shard %d", participants, att.Data.Slot, att.Data.Shard)
-		}
 	}
 
 	node, err := s.GetRecentBlockHash(att.Data.Slot, c)
@@ -185,7 +196,7 @@ func (s *State) validateParticipationSignature(voteHash chainhash.Hash, particip
 		return err
 	}
 
-	valid, err := bls.VerifySig(aggregatedPublicKey, voteHash[:], sig, bls.DomainVote)
+	valid, err := bls.VerifySig(aggregatedPublicKey, voteHash[:], sig, GetDomain(s.ForkData, s.Slot, bls.DomainVote))
 
 	if err != nil {
 		return err
@@ -279,8 +290,104 @@ func (s *State) applyVote(vote AggregatedVote, config *config.Config) error {
 	return nil
 }
 
+// GetProposerSignatureSet gets the proposer and randao signatures of a block. The state
+// should be at the slot of the block before the block is processed.
+func (s *State) GetProposerSignatureSet(block *Block, con *config.Config) (*bls.SignatureSet, error) {
+	if block.BlockHeader.SlotNumber != s.Slot {
+		return nil, fmt.Errorf("block has incorrect slot number (expecting: %d, got: %d)", s.Slot, block.BlockHeader.SlotNumber)
+	}
+
+	proposerIndex, err := s.GetBeaconProposerIndex(block.BlockHeader.SlotNumber-1, con)
+	if err != nil {
+		return nil, err
+	}
+
+	proposerPub, err := s.ValidatorRegistry[proposerIndex].GetPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	blockWithoutSignature := block.Copy()
+	blockWithoutSignature.BlockHeader.Signature = bls.EmptySignature.Serialize()
+	blockWithoutSignatureRoot, err := ssz.HashTreeRoot(blockWithoutSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	proposalRoot, err := ssz.HashTreeRoot(ProposalSignedData{
+		Slot:      s.Slot,
+		Shard:     con.BeaconShardNumber,
+		BlockHash: blockWithoutSignatureRoot,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	proposerSig, err := bls.DeserializeSignature(block.BlockHeader.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var slotBytes [8]byte
+	binary.BigEndian.PutUint64(slotBytes[:], block.BlockHeader.SlotNumber)
+	slotBytesHash := chainhash.HashH(slotBytes[:])
+
+	randaoSig, err := bls.DeserializeSignature(block.BlockHeader.RandaoReveal)
+	if err != nil {
+		return nil, err
+	}
+
+	set := bls.NewSignatureSet()
+	set.Add(proposerPub, proposalRoot[:], proposerSig, GetDomain(s.ForkData, s.Slot, bls.DomainProposal))
+	set.Add(proposerPub, slotBytesHash[:], randaoSig, GetDomain(s.ForkData, s.Slot, bls.DomainRandao))
+
+	return set, nil
+}
+
+// GetBlockSignatureSet gets the proposer, randao and attestation signatures in a block so
+// that they can be verified in a batch. The state should be at the slot of the block before
+// the block is processed.
+func (s *State) GetBlockSignatureSet(block *Block, con *config.Config) (*bls.SignatureSet, error) {
+	set, err := s.GetProposerSignatureSet(block, con)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, att := range block.BlockBody.Attestations {
+		participants, err := s.GetAttestationParticipants(att.Data, att.ParticipationBitfield, con)
+		if err != nil {
+			return nil, err
+		}
+
+		dataRoot, err := ssz.HashTreeRoot(AttestationDataAndCustodyBit{Data: att.Data, PoCBit: false})
+		if err != nil {
+			return nil, err
+		}
+
+		groupPublicKey := bls.NewAggregatePublicKey()
+		for _, p := range participants {
+			pub, err := s.ValidatorRegistry[p].GetPublicKey()
+			if err != nil {
+				return nil, err
+			}
+			groupPublicKey.AggregatePubKey(pub)
+		}
+
+		aggSig, err := bls.DeserializeSignature(att.AggregateSig)
+		if err != nil {
+			return nil, err
+		}
+
+		set.Add(groupPublicKey, dataRoot[:], aggSig, GetDomain(s.ForkData, att.Data.Slot, bls.DomainAttestation))
+	}
+
+	return set, nil
+}
+
 // ProcessBlock tries to apply a block to the state.
 func (s *State) ProcessBlock(block *Block, con *config.Config, view BlockView, verifySignature bool) error {
+	con = con.ForEpoch(s.EpochIndex)
+
 	proposerIndex, err := s.GetBeaconProposerIndex(block.BlockHeader.SlotNumber-1, con)
 	if err != nil {
 		return err
@@ -324,13 +431,15 @@ func (s *State) ProcessBlock(block *Block, con *config.Config, view BlockView, v
 		verificationResult := make(chan error)
 
 		go func() {
-			valid, err := bls.VerifySig(proposerPub, proposalRoot[:], proposerSig, bls.DomainProposal)
+			valid, err := bls.VerifySig(proposerPub, proposalRoot[:], proposerSig, GetDomain(s.ForkData, s.Slot, bls.DomainProposal))
 			if err != nil {
 				verificationResult <- err
+				return
 			}
 
 			if !valid {
 				verificationResult <- fmt.Errorf("block had invalid signature (expected signature from validator %d)", proposerIndex)
+				return
 			}
 
 			verificationResult <- nil
@@ -346,12 +455,14 @@ func (s *State) ProcessBlock(block *Block, con *config.Config, view BlockView, v
 		}
 
 		go func() {
-			valid, err := bls.VerifySig(proposerPub, slotBytesHash[:], randaoSig, bls.DomainRandao)
+			valid, err := bls.VerifySig(proposerPub, slotBytesHash[:], randaoSig, GetDomain(s.ForkData, s.Slot, bls.DomainRandao))
 			if err != nil {
 				verificationResult <- err
+				return
 			}
 			if !valid {
 				verificationResult <- errors.New("block has invalid randao signature")
+				return
 			}
 
 			verificationResult <- nil
@@ -403,14 +514,14 @@ func (s *State) ProcessBlock(block *Block, con *config.Config, view BlockView, v
 	}
 
 	for _, slashing := range block.BlockBody.ProposerSlashings {
-		err := s.applyProposerSlashing(slashing, con)
+		err := s.ApplyProposerSlashing(slashing, con)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, c := range block.BlockBody.CasperSlashings {
-		err := s.applyCasperSlashing(c, con)
+		err := s.ApplyCasperSlashing(c, con)
 		if err != nil {
 			return err
 		}
@@ -423,7 +534,14 @@ func (s *State) ProcessBlock(block *Block, con *config.Config, view BlockView, v
 		}
 	}
 
-	// process deposits here
+	s.voteForDepositRoot(block.BlockBody.DepositRoot)
+
+	for _, d := range block.BlockBody.Deposits {
+		err := s.ApplyDeposit(d, con)
+		if err != nil {
+			return err
+		}
+	}
 
 	for _, e := range block.BlockBody.Exits {
 		err := s.ApplyExit(e, con)
@@ -2,6 +2,7 @@ package primitives_test
 
 import (
 	"encoding/binary"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -194,6 +195,60 @@ func TestProposerIndex(t *testing.T) {
 	}
 }
 
+func TestProcessBlockInvalidSignaturesDontLeakGoroutines(t *testing.T) {
+	c := &config.RegtestConfig
+
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	state, keystore, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = state.ProcessSlot(chainhash.Hash{}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposerIndex, err := state.GetBeaconProposerIndex(state.Slot-1, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockTest := &primitives.Block{
+		BlockHeader: primitives.BlockHeader{
+			SlotNumber: state.Slot,
+		},
+	}
+
+	// both the proposal and randao signatures are from the wrong validator
+	err = SignBlock(blockTest, keystore.GetKeyForValidator(proposerIndex+1), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goroutinesBefore := runtime.NumGoroutine()
+
+	const blocksToProcess = 10
+
+	for i := 0; i < blocksToProcess; i++ {
+		stateCopy := state.Copy()
+		err = stateCopy.ProcessBlock(blockTest, c, FakeBlockView{}, true)
+		if err == nil {
+			t.Fatal("expected block with wrong validator to fail")
+		}
+	}
+
+	// verification goroutines that are still running exit shortly after the block fails
+	for i := 0; i < 100 && runtime.NumGoroutine() >= goroutinesBefore+blocksToProcess; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if goroutines := runtime.NumGoroutine(); goroutines >= goroutinesBefore+blocksToProcess {
+		t.Fatalf("expected verification goroutines to exit, but %d goroutines are running (%d before)", goroutines, goroutinesBefore)
+	}
+}
+
 func TestBlockMaximums(t *testing.T) {
 	c := &config.RegtestConfig
 
@@ -1440,3 +1495,174 @@ func TestVoteValidatorLeave(t *testing.T) {
 		t.Fatal("expected vote to be reset on validator exit")
 	}
 }
+
+// buildDepositTree gets the root of a deposit tree containing the given leaves along with
+// the Merkle proof for each leaf.
+func buildDepositTree(leaves []chainhash.Hash, depth uint64) (chainhash.Hash, [][]chainhash.Hash) {
+	proofs := make([][]chainhash.Hash, len(leaves))
+	layer := append([]chainhash.Hash{}, leaves...)
+	zeroHash := chainhash.Hash{}
+
+	for i := uint64(0); i < depth; i++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHash)
+		}
+
+		for j := range leaves {
+			proofs[j] = append(proofs[j], layer[(j>>i)^1])
+		}
+
+		nextLayer := make([]chainhash.Hash, len(layer)/2)
+		for j := range nextLayer {
+			nextLayer[j] = chainhash.HashH(append(layer[2*j][:], layer[2*j+1][:]...))
+		}
+		layer = nextLayer
+		zeroHash = chainhash.HashH(append(zeroHash[:], zeroHash[:]...))
+	}
+
+	return layer[0], proofs
+}
+
+func makeDeposit(key *bls.SecretKey, amount uint64) (*primitives.Deposit, error) {
+	pub := key.DerivePublicKey()
+	hashPub, err := ssz.HashTreeRoot(pub.Serialize())
+	if err != nil {
+		return nil, err
+	}
+	proofOfPossession, err := bls.Sign(key, hashPub[:], bls.DomainDeposit)
+	if err != nil {
+		return nil, err
+	}
+	return &primitives.Deposit{
+		Parameters: primitives.DepositParameters{
+			PubKey:            pub.Serialize(),
+			ProofOfPossession: proofOfPossession.Serialize(),
+		},
+		Amount: amount,
+	}, nil
+}
+
+func TestDepositProcessing(t *testing.T) {
+	c := &config.RegtestConfig
+
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	numValidators := c.ShardCount*c.TargetCommitteeSize*2 + 5
+
+	state, keystore, err := SetupState(numValidators, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newDeposit, err := makeDeposit(keystore.GetKeyForValidator(uint32(numValidators)), c.MaxDeposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topUpDeposit, err := makeDeposit(keystore.GetKeyForValidator(0), c.MinDeposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deposits := []*primitives.Deposit{newDeposit, topUpDeposit}
+	leaves := make([]chainhash.Hash, len(deposits))
+	for i, d := range deposits {
+		d.Index = uint64(i)
+		leaves[i], err = d.Leaf()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root, proofs := buildDepositTree(leaves, c.DepositTreeDepth)
+	for i, d := range deposits {
+		d.Proof = proofs[i]
+	}
+	state.LatestDepositRoot = root
+
+	processDeposit := func(s *primitives.State, d primitives.Deposit) error {
+		slotToPropose := s.Slot + 1
+		proposerIndex, err := s.GetBeaconProposerIndex(slotToPropose-1, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		block := &primitives.Block{
+			BlockHeader: primitives.BlockHeader{
+				SlotNumber: slotToPropose,
+			},
+			BlockBody: primitives.BlockBody{
+				Attestations:      []primitives.Attestation{},
+				Deposits:          []primitives.Deposit{d},
+				Exits:             []primitives.Exit{},
+				ProposerSlashings: []primitives.ProposerSlashing{},
+				CasperSlashings:   []primitives.CasperSlashing{},
+				Votes:             []primitives.AggregatedVote{},
+			},
+		}
+
+		err = SignBlock(block, keystore.GetKeyForValidator(proposerIndex), c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = s.ProcessSlot(chainhash.Hash{}, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return s.ProcessBlock(block, c, FakeBlockView{}, true)
+	}
+
+	invalidProof := newDeposit.Copy()
+	invalidProof.Proof[0][0] ^= 1
+	stateCopy := state.Copy()
+	if err := processDeposit(&stateCopy, invalidProof); err == nil {
+		t.Fatal("expected deposit with invalid proof to fail")
+	}
+
+	outOfOrder := state.Copy()
+	if err := processDeposit(&outOfOrder, *topUpDeposit); err == nil {
+		t.Fatal("expected deposit processed out of order to fail")
+	}
+
+	err = processDeposit(state, *newDeposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.ValidatorRegistry) != numValidators+1 {
+		t.Fatalf("expected deposit to add a validator (expected: %d, got: %d)", numValidators+1, len(state.ValidatorRegistry))
+	}
+
+	if state.ValidatorRegistry[numValidators].Status != primitives.PendingActivation {
+		t.Fatal("expected new validator to be pending activation")
+	}
+
+	if state.ValidatorBalances[numValidators] != c.MaxDeposit {
+		t.Fatalf("expected new validator to have balance %d, got %d", c.MaxDeposit, state.ValidatorBalances[numValidators])
+	}
+
+	balanceBefore := state.ValidatorBalances[0]
+
+	err = processDeposit(state, *topUpDeposit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.ValidatorRegistry) != numValidators+1 {
+		t.Fatal("expected top-up not to add a validator")
+	}
+
+	if state.ValidatorBalances[0] != balanceBefore+c.MinDeposit {
+		t.Fatalf("expected top-up to increase balance to %d, got %d", balanceBefore+c.MinDeposit, state.ValidatorBalances[0])
+	}
+
+	if state.DepositIndex != 2 {
+		t.Fatalf("expected deposit index to be 2, got %d", state.DepositIndex)
+	}
+
+	if err := processDeposit(state, *topUpDeposit); err == nil {
+		t.Fatal("expected replayed deposit to fail")
+	}
+}
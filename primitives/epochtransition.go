@@ -230,6 +230,9 @@ func (s *State) GetRecentBlockHash(slotToGet uint64, c *config.Config) (*chainha
 // be used as ProcessSlots is generally a better way to update state, but sometimes it's required to
 // validate/generate attestations for the next epoch.
 func (s *State) ProcessEpochTransition(c *config.Config) ([]Receipt, error) {
+	// the epoch that's ending is processed with the parameters it was run with
+	c = c.ForEpoch(s.EpochIndex)
+
 	activeValidatorIndices := GetActiveValidatorIndices(s.ValidatorRegistry)
 	totalBalance := s.GetTotalBalance(activeValidatorIndices, c)
 
@@ -805,6 +808,10 @@ func (s *State) ProcessEpochTransition(c *config.Config) ([]Receipt, error) {
 		s.Proposals = s.Proposals[:out]
 	}
 
+	if (s.EpochIndex+1)%c.EpochsPerDepositRootVotingPeriod == 0 {
+		s.updateDepositRoot(c)
+	}
+
 	if err := s.exitValidatorsUnderMinimum(c); err != nil {
 		return nil, err
 	}
@@ -845,5 +852,14 @@ func (s *State) ProcessEpochTransition(c *config.Config) ([]Receipt, error) {
 
 	s.CurrentEpochAttestations = make([]PendingAttestation, 0)
 
+	// the new fork version is used starting with the first slot processed after the transition
+	if fork := c.ForkAtEpoch(s.EpochIndex); fork != nil {
+		s.ForkData = ForkData{
+			PreForkVersion:  s.ForkData.PostForkVersion,
+			PostForkVersion: fork.Version,
+			ForkSlotNumber:  s.Slot + 1,
+		}
+	}
+
 	return receipts, nil
 }
@@ -63,6 +63,11 @@ func InitializeState(c *config.Config, initialValidators []InitialValidatorEntry
 		BatchedBlockRoots:         []chainhash.Hash{},
 
 		ShardRegistry: make([]chainhash.Hash, c.ShardCount),
+
+		// initial validators aren't in the deposit tree, so the tree starts out empty
+		LatestDepositRoot: EmptyDepositTreeRoot(c.DepositTreeDepth),
+		DepositIndex:      0,
+		DepositRootVotes:  []DepositRootVote{},
 	}
 
 	for _, deposit := range initialValidators {
@@ -184,6 +184,21 @@ type State struct {
 	ShardRegistry []chainhash.Hash
 	Proposals     []ActiveProposal
 	PendingVotes  []AggregatedVote
+
+	// DEPOSITS
+	// LatestDepositRoot is the root of the deposit tree that deposits are proven against.
+	LatestDepositRoot chainhash.Hash
+
+	// DepositIndex is the index of the next deposit to be processed.
+	DepositIndex uint64
+
+	// DepositRootVotes are the deposit roots blocks voted for in the current deposit root
+	// voting period.
+	DepositRootVotes []DepositRootVote
+
+	// XXXHashCache is the cache used to calculate the hash tree root of the state. It's shared
+	// with copies of the state.
+	XXXHashCache *stateHashCache
 }
 
 // Copy deep-copies the state.
@@ -208,6 +223,8 @@ func (s *State) Copy() State {
 	for i := range s.PendingVotes {
 		newPendingVotes[i] = s.PendingVotes[i].Copy()
 	}
+	newDepositRootVotes := make([]DepositRootVote, len(s.DepositRootVotes))
+	copy(newDepositRootVotes, s.DepositRootVotes)
 	var newValidatorRegistryDeltaChainTip chainhash.Hash
 	var newRandaoMix chainhash.Hash
 	copy(newValidatorRegistryDeltaChainTip[:], s.ValidatorRegistryDeltaChainTip[:])
@@ -265,6 +282,10 @@ func (s *State) Copy() State {
 		ShardRegistry:                      newShardRegistry,
 		PendingVotes:                       newPendingVotes,
 		Proposals:                          newProposals,
+		LatestDepositRoot:                  s.LatestDepositRoot,
+		DepositIndex:                       s.DepositIndex,
+		DepositRootVotes:                   newDepositRootVotes,
+		XXXHashCache:                       s.XXXHashCache,
 	}
 
 	return newState
@@ -283,6 +304,7 @@ func (s *State) ToProto() *pb.State {
 	proposals := make([]*pb.ActiveProposal, len(s.Proposals))
 	shardRegistry := make([][]byte, len(s.ShardRegistry))
 	pendingVotes := make([]*pb.AggregatedVote, len(s.PendingVotes))
+	depositRootVotes := make([]*pb.DepositRootVote, len(s.DepositRootVotes))
 
 	for i := range validatorRegistry {
 		validatorRegistry[i] = s.ValidatorRegistry[i].ToProto()
@@ -327,6 +349,10 @@ func (s *State) ToProto() *pb.State {
 		pendingVotes[i] = s.PendingVotes[i].ToProto()
 	}
 
+	for i := range depositRootVotes {
+		depositRootVotes[i] = s.DepositRootVotes[i].ToProto()
+	}
+
 	for i := range shardRegistry {
 		shardRegistry[i] = s.ShardRegistry[i][:]
 	}
@@ -360,6 +386,9 @@ func (s *State) ToProto() *pb.State {
 		ShardRegistry:                      shardRegistry,
 		PendingVotes:                       pendingVotes,
 		Proposals:                          proposals,
+		LatestDepositRoot:                  s.LatestDepositRoot[:],
+		DepositIndex:                       s.DepositIndex,
+		DepositRootVotes:                   depositRootVotes,
 	}
 }
 
@@ -376,6 +405,7 @@ func StateFromProto(s *pb.State) (*State, error) {
 	pendingVotes := make([]AggregatedVote, len(s.PendingVotes))
 	proposals := make([]ActiveProposal, len(s.Proposals))
 	shardRegistry := make([]chainhash.Hash, len(s.ShardRegistry))
+	depositRootVotes := make([]DepositRootVote, len(s.DepositRootVotes))
 
 	fd, err := ForkDataFromProto(s.ForkData)
 	if err != nil {
@@ -448,6 +478,14 @@ func StateFromProto(s *pb.State) (*State, error) {
 		pendingVotes[i] = *a
 	}
 
+	for i := range depositRootVotes {
+		v, err := DepositRootVoteFromProto(s.DepositRootVotes[i])
+		if err != nil {
+			return nil, err
+		}
+		depositRootVotes[i] = *v
+	}
+
 	for i := range currentAttestations {
 		a, err := PendingAttestationFromProto(s.CurrentEpochAttestations[i])
 		if err != nil {
@@ -492,6 +530,16 @@ func StateFromProto(s *pb.State) (*State, error) {
 		ShardRegistry:                      shardRegistry,
 		PendingVotes:                       pendingVotes,
 		Proposals:                          proposals,
+		DepositIndex:                       s.DepositIndex,
+		DepositRootVotes:                   depositRootVotes,
+	}
+
+	// states stored before deposits were tracked don't have a deposit root
+	if len(s.LatestDepositRoot) > 0 {
+		err = newState.LatestDepositRoot.SetBytes(s.LatestDepositRoot)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	err = newState.ValidatorRegistryDeltaChainTip.SetBytes(s.ValidatorRegistryDeltaChainTip)
@@ -571,7 +619,7 @@ func (s *State) ActivateValidator(index uint32) error {
 
 // InitiateValidatorExit moves a validator from active to pending exit.
 func (s *State) InitiateValidatorExit(index uint32) error {
-	validator := s.ValidatorRegistry[index]
+	validator := &s.ValidatorRegistry[index]
 	if validator.Status != Active {
 		return errors.New("validator is not active")
 	}
@@ -594,7 +642,7 @@ func (s *State) GetBeaconProposerIndex(slot uint64, c *config.Config) (uint32, e
 
 // ExitValidator handles state changes when a validator exits.
 func (s *State) ExitValidator(index uint32, status uint64, c *config.Config) error {
-	validator := s.ValidatorRegistry[index]
+	validator := &s.ValidatorRegistry[index]
 	prevStatus := validator.Status
 
 	if prevStatus == ExitedWithPenalty {
@@ -731,23 +779,29 @@ func (s *State) GetCommitteeIndices(slot uint64, shardID uint64, con *config.Con
 	return ShardCommitteeByShardID(shardID, committees)
 }
 
-// ValidateProofOfPossession validates a proof of possession for a new validator.
-func (s *State) ValidateProofOfPossession(pubkey *bls.PublicKey, proofOfPossession bls.Signature, withdrawalCredentials chainhash.Hash) (bool, error) {
-	// fixme
-
+// VerifyProofOfPossession checks that a proof of possession is a signature of the public key
+// by the validator it belongs to.
+func VerifyProofOfPossession(pubkey *bls.PublicKey, proofOfPossession *bls.Signature) (bool, error) {
 	h, err := ssz.HashTreeRoot(pubkey.Serialize())
 	if err != nil {
 		return false, err
 	}
-	valid, err := bls.VerifySig(pubkey, h[:], &proofOfPossession, bls.DomainDeposit)
+	valid, err := bls.VerifySig(pubkey, h[:], proofOfPossession, bls.DomainDeposit)
 	if err != nil {
 		return false, err
 	}
 	return valid, nil
 }
 
-// applyProposerSlashing applies a proposer slashing if valid.
-func (s *State) applyProposerSlashing(proposerSlashing ProposerSlashing, config *config.Config) error {
+// ValidateProofOfPossession validates a proof of possession for a new validator.
+func (s *State) ValidateProofOfPossession(pubkey *bls.PublicKey, proofOfPossession bls.Signature, withdrawalCredentials chainhash.Hash) (bool, error) {
+	// fixme
+
+	return VerifyProofOfPossession(pubkey, &proofOfPossession)
+}
+
+// ApplyProposerSlashing validates and applies a proposer slashing.
+func (s *State) ApplyProposerSlashing(proposerSlashing ProposerSlashing, config *config.Config) error {
 	if proposerSlashing.ProposerIndex >= uint32(len(s.ValidatorRegistry)) {
 		return errors.New("invalid proposer index")
 	}
@@ -782,7 +836,7 @@ func (s *State) applyProposerSlashing(proposerSlashing ProposerSlashing, config
 		return err
 	}
 
-	valid, err := bls.VerifySig(pub, hashProposal2[:], sigProposal2, bls.DomainProposal)
+	valid, err := bls.VerifySig(pub, hashProposal2[:], sigProposal2, GetDomain(s.ForkData, proposerSlashing.ProposalData2.Slot, bls.DomainProposal))
 	if err != nil {
 		return err
 	}
@@ -795,7 +849,7 @@ func (s *State) applyProposerSlashing(proposerSlashing ProposerSlashing, config
 		return err
 	}
 
-	valid, err = bls.VerifySig(pub, hashProposal1[:], sigProposal1, bls.DomainProposal)
+	valid, err = bls.VerifySig(pub, hashProposal1[:], sigProposal1, GetDomain(s.ForkData, proposerSlashing.ProposalData1.Slot, bls.DomainProposal))
 	if err != nil {
 		return err
 	}
@@ -839,6 +893,9 @@ func (s *State) verifySlashableVoteData(voteData SlashableVoteData, c *config.Co
 	pubKey1 := bls.NewAggregatePublicKey()
 
 	for _, i := range voteData.AggregateSignaturePoC0Indices {
+		if i >= uint32(len(s.ValidatorRegistry)) {
+			return false
+		}
 		p, err := s.ValidatorRegistry[i].GetPublicKey()
 		if err != nil {
 			panic(err)
@@ -847,6 +904,9 @@ func (s *State) verifySlashableVoteData(voteData SlashableVoteData, c *config.Co
 	}
 
 	for _, i := range voteData.AggregateSignaturePoC1Indices {
+		if i >= uint32(len(s.ValidatorRegistry)) {
+			return false
+		}
 		p, err := s.ValidatorRegistry[i].GetPublicKey()
 		if err != nil {
 			panic(err)
@@ -878,11 +938,11 @@ func (s *State) verifySlashableVoteData(voteData SlashableVoteData, c *config.Co
 	}, [][]byte{
 		ad0Hash[:],
 		ad1Hash[:],
-	}, aggregateSignature, GetDomain(s.ForkData, s.Slot, bls.DomainAttestation))
+	}, aggregateSignature, GetDomain(s.ForkData, voteData.Data.Slot, bls.DomainAttestation))
 }
 
-// applyCasperSlashing applies a casper slashing claim to the current state.
-func (s *State) applyCasperSlashing(casperSlashing CasperSlashing, c *config.Config) error {
+// ApplyCasperSlashing validates and applies a casper slashing claim to the current state.
+func (s *State) ApplyCasperSlashing(casperSlashing CasperSlashing, c *config.Config) error {
 	var intersection []uint32
 	indices1 := indices(casperSlashing.Votes1)
 	indices2 := indices(casperSlashing.Votes2)
@@ -931,6 +991,9 @@ var zeroHash = chainhash.Hash{}
 
 // ApplyExit validates and applies an exit.
 func (s *State) ApplyExit(exit Exit, config *config.Config) error {
+	if exit.ValidatorIndex >= uint64(len(s.ValidatorRegistry)) {
+		return errors.New("invalid validator index")
+	}
 	validator := s.ValidatorRegistry[exit.ValidatorIndex]
 	if validator.Status != Active {
 		return errors.New("validator with exit is not active")
@@ -950,7 +1013,7 @@ func (s *State) ApplyExit(exit Exit, config *config.Config) error {
 		return err
 	}
 
-	valid, err := bls.VerifySig(validatorPub, zeroHash[:], exitSig, bls.DomainExit)
+	valid, err := bls.VerifySig(validatorPub, zeroHash[:], exitSig, GetDomain(s.ForkData, exit.Slot, bls.DomainExit))
 	if err != nil {
 		return err
 	}
@@ -1011,6 +1074,117 @@ func MinEmptyValidator(validators []Validator, validatorBalances []uint64, c *co
 	return -1
 }
 
+// voteForDepositRoot counts the vote of a block for a deposit root. Blocks voting for the zero
+// hash don't vote for any root.
+func (s *State) voteForDepositRoot(root chainhash.Hash) {
+	if root.IsEqual(&zeroHash) {
+		return
+	}
+
+	for i := range s.DepositRootVotes {
+		if s.DepositRootVotes[i].DepositRoot.IsEqual(&root) {
+			s.DepositRootVotes[i].VoteCount++
+			return
+		}
+	}
+
+	s.DepositRootVotes = append(s.DepositRootVotes, DepositRootVote{
+		DepositRoot: root,
+		VoteCount:   1,
+	})
+}
+
+// updateDepositRoot sets the latest deposit root to the root voted for by a majority of the
+// slots in the voting period that's ending and starts a new voting period.
+func (s *State) updateDepositRoot(c *config.Config) {
+	slotsInPeriod := c.EpochsPerDepositRootVotingPeriod * c.EpochLength
+	for _, v := range s.DepositRootVotes {
+		if v.VoteCount*2 > slotsInPeriod {
+			s.LatestDepositRoot = v.DepositRoot
+			break
+		}
+	}
+
+	s.DepositRootVotes = []DepositRootVote{}
+}
+
+func (s *State) verifyDepositProof(deposit Deposit, c *config.Config) error {
+	leaf, err := deposit.Leaf()
+	if err != nil {
+		return err
+	}
+
+	if !VerifyMerkleBranch(leaf, deposit.Proof, c.DepositTreeDepth, deposit.Index, s.LatestDepositRoot) {
+		return errors.New("deposit merkle proof is not valid")
+	}
+
+	return nil
+}
+
+// ValidateDeposit validates the Merkle proof and proof of possession of a deposit
+// without applying it.
+func (s *State) ValidateDeposit(deposit Deposit, c *config.Config) error {
+	if deposit.Amount < c.MinDeposit || deposit.Amount > c.MaxDeposit {
+		return errors.New("deposit amount is out of range")
+	}
+
+	err := s.verifyDepositProof(deposit, c)
+	if err != nil {
+		return err
+	}
+
+	pubkey, err := bls.DeserializePublicKey(deposit.Parameters.PubKey)
+	if err != nil {
+		return err
+	}
+
+	proofOfPossession, err := bls.DeserializeSignature(deposit.Parameters.ProofOfPossession)
+	if err != nil {
+		return err
+	}
+
+	valid, err := s.ValidateProofOfPossession(pubkey, *proofOfPossession, deposit.Parameters.WithdrawalCredentials)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid deposit signature")
+	}
+
+	return nil
+}
+
+// ApplyDeposit validates and applies a deposit. Deposits must be applied in the order
+// they were added to the deposit tree.
+func (s *State) ApplyDeposit(deposit Deposit, c *config.Config) error {
+	if deposit.Index != s.DepositIndex {
+		return fmt.Errorf("expected deposit with index %d, got %d", s.DepositIndex, deposit.Index)
+	}
+
+	if deposit.Amount < c.MinDeposit || deposit.Amount > c.MaxDeposit {
+		return errors.New("deposit amount is out of range")
+	}
+
+	err := s.verifyDepositProof(deposit, c)
+	if err != nil {
+		return err
+	}
+
+	pubkey, err := bls.DeserializePublicKey(deposit.Parameters.PubKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ProcessDeposit(pubkey, deposit.Amount, deposit.Parameters.ProofOfPossession, deposit.Parameters.WithdrawalCredentials, false, c)
+	if err != nil {
+		return err
+	}
+
+	s.DepositIndex++
+
+	return nil
+}
+
 // ProcessDeposit processes a deposit with the context of the current state.
 func (s *State) ProcessDeposit(pubkey *bls.PublicKey, amount uint64, proofOfPossession [48]byte, withdrawalCredentials chainhash.Hash, skipValidation bool, c *config.Config) (uint32, error) {
 	if !skipValidation {
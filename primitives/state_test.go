@@ -3,6 +3,7 @@ package primitives_test
 import (
 	"testing"
 
+	"github.com/phoreproject/synapse/beacon/config"
 	"github.com/phoreproject/synapse/chainhash"
 
 	"github.com/go-test/deep"
@@ -194,6 +195,11 @@ func TestState_Copy(t *testing.T) {
 	if len(baseState.PendingVotes) != 0 {
 		t.Fatal("mutating proposals mutates base")
 	}
+
+	copyState.DepositRootVotes = append(copyState.DepositRootVotes, primitives.DepositRootVote{})
+	if len(baseState.DepositRootVotes) != 0 {
+		t.Fatal("mutating deposit root votes mutates base")
+	}
 }
 
 func TestState_ToFromProto(t *testing.T) {
@@ -225,6 +231,9 @@ func TestState_ToFromProto(t *testing.T) {
 		ShardRegistry:                      []chainhash.Hash{{1}},
 		Proposals:                          []primitives.ActiveProposal{{StartEpoch: 1}},
 		PendingVotes:                       []primitives.AggregatedVote{{Signature: [48]byte{1}}},
+		LatestDepositRoot:                  chainhash.Hash{1},
+		DepositIndex:                       1,
+		DepositRootVotes:                   []primitives.DepositRootVote{{DepositRoot: chainhash.Hash{1}, VoteCount: 1}},
 	}
 
 	stateProto := baseState.ToProto()
@@ -368,3 +377,68 @@ func TestShardAndCommittee_ToFromProto(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestInitiateValidatorExit(t *testing.T) {
+	c := config.RegtestConfig
+	c.ShardCount = 8
+
+	s, _, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Slot = 3
+
+	err = s.InitiateValidatorExit(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.ValidatorRegistry[1].Status != primitives.ActivePendingExit {
+		t.Fatalf("expected validator to be pending exit, got status %d", s.ValidatorRegistry[1].Status)
+	}
+
+	if s.ValidatorRegistry[1].LatestStatusChangeSlot != 3 {
+		t.Fatalf("expected latest status change slot to be 3, got %d", s.ValidatorRegistry[1].LatestStatusChangeSlot)
+	}
+
+	err = s.InitiateValidatorExit(1)
+	if err == nil {
+		t.Fatal("expected validator that is already pending exit to be rejected")
+	}
+}
+
+func TestExitValidator(t *testing.T) {
+	c := config.RegtestConfig
+	c.ShardCount = 8
+
+	s, _, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Slot = 3
+	oldDeltaChainTip := s.ValidatorRegistryDeltaChainTip
+
+	err = s.ExitValidator(1, primitives.ExitedWithoutPenalty, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator := s.ValidatorRegistry[1]
+	if validator.Status != primitives.ExitedWithoutPenalty {
+		t.Fatalf("expected validator to be exited, got status %d", validator.Status)
+	}
+
+	if validator.LatestStatusChangeSlot != 3 {
+		t.Fatalf("expected latest status change slot to be 3, got %d", validator.LatestStatusChangeSlot)
+	}
+
+	if s.ValidatorRegistryExitCount != 1 || validator.ExitCount != 1 {
+		t.Fatalf("expected exit count of validator and registry to be 1, got %d and %d", validator.ExitCount, s.ValidatorRegistryExitCount)
+	}
+
+	if s.ValidatorRegistryDeltaChainTip.IsEqual(&oldDeltaChainTip) {
+		t.Fatal("expected validator registry delta chain tip to change")
+	}
+}
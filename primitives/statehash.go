@@ -0,0 +1,431 @@
+package primitives
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+// merkleTree is a Merkle tree that keeps every layer so that only the branches of leaves that
+// changed are rehashed when it's updated. Roots match the merkleization used by go-ssz.
+type merkleTree struct {
+	// layers[0] are the leaves padded with zero chunks to a power of two and the last layer is
+	// the root.
+	layers [][]chainhash.Hash
+}
+
+func hashPair(left chainhash.Hash, right chainhash.Hash) chainhash.Hash {
+	var buf [64]byte
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+	return sha256.Sum256(buf[:])
+}
+
+// mixInLength mixes the length of a list into its root.
+func mixInLength(root chainhash.Hash, length int) chainhash.Hash {
+	var lengthChunk chainhash.Hash
+	binary.LittleEndian.PutUint64(lengthChunk[:], uint64(length))
+	return hashPair(root, lengthChunk)
+}
+
+// build hashes every layer of the tree.
+func (t *merkleTree) build(leaves []chainhash.Hash, size int) {
+	layer := make([]chainhash.Hash, size)
+	copy(layer, leaves)
+	t.layers = [][]chainhash.Hash{layer}
+
+	for len(layer) > 1 {
+		parents := make([]chainhash.Hash, len(layer)/2)
+		for i := range parents {
+			parents[i] = hashPair(layer[2*i], layer[2*i+1])
+		}
+		t.layers = append(t.layers, parents)
+		layer = parents
+	}
+}
+
+// update updates the leaves of the tree and returns the new root.
+func (t *merkleTree) update(leaves []chainhash.Hash) chainhash.Hash {
+	if len(leaves) == 0 {
+		return chainhash.Hash{}
+	}
+
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+
+	size := 1
+	for size < len(leaves) {
+		size *= 2
+	}
+
+	if len(t.layers) == 0 || len(t.layers[0]) != size {
+		t.build(leaves, size)
+		return t.layers[len(t.layers)-1][0]
+	}
+
+	var dirty []int
+	for i := range t.layers[0] {
+		var leaf chainhash.Hash
+		if i < len(leaves) {
+			leaf = leaves[i]
+		}
+
+		if t.layers[0][i] != leaf {
+			t.layers[0][i] = leaf
+			dirty = append(dirty, i)
+		}
+	}
+
+	for l := 1; l < len(t.layers) && len(dirty) > 0; l++ {
+		// parents are in order, so they replace the dirty indices in place
+		parents := dirty[:0]
+		for _, i := range dirty {
+			p := i / 2
+			if len(parents) > 0 && parents[len(parents)-1] == p {
+				continue
+			}
+			parents = append(parents, p)
+
+			t.layers[l][p] = hashPair(t.layers[l-1][2*p], t.layers[l-1][2*p+1])
+		}
+		dirty = parents
+	}
+
+	return t.layers[len(t.layers)-1][0]
+}
+
+// listCache caches the roots of the elements of a list and the tree of those roots.
+type listCache struct {
+	roots []chainhash.Hash
+	tree  merkleTree
+}
+
+// root gets the root of a list with n elements. Elements are only rehashed if they changed since
+// the last root, as reported by unchanged, which is only called for elements that were hashed
+// before. Once an element is hashed, hashElement should keep a copy of it to compare against.
+func (l *listCache) root(n int, unchanged func(i int) bool, hashElement func(i int) (chainhash.Hash, error)) (chainhash.Hash, error) {
+	cached := len(l.roots)
+	if n < cached {
+		l.roots = l.roots[:n]
+	}
+
+	for i := 0; i < n; i++ {
+		if i < cached && unchanged(i) {
+			continue
+		}
+
+		root, err := hashElement(i)
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+
+		if i < len(l.roots) {
+			l.roots[i] = root
+		} else {
+			l.roots = append(l.roots, root)
+		}
+	}
+
+	return mixInLength(l.tree.update(l.roots), n), nil
+}
+
+// stateHashCache caches the Merkle trees of the large fields of a state. States copied from each
+// other share a cache, so changed leaves are found by comparing them with the last state hashed
+// rather than by tracking changes to the state.
+type stateHashCache struct {
+	lock *sync.Mutex
+
+	validators    []Validator
+	validatorList listCache
+
+	balanceChunks []chainhash.Hash
+	balanceTree   merkleTree
+
+	committees    [][]ShardAndCommittee
+	committeeList listCache
+
+	latestCrosslinks      []Crosslink
+	latestCrosslinkList   listCache
+	previousCrosslinks    []Crosslink
+	previousCrosslinkList listCache
+
+	latestBlockHashTree merkleTree
+
+	currentAttestations     []PendingAttestation
+	currentAttestationList  listCache
+	previousAttestations    []PendingAttestation
+	previousAttestationList listCache
+}
+
+func newStateHashCache() *stateHashCache {
+	return &stateHashCache{
+		lock: new(sync.Mutex),
+	}
+}
+
+// HashTreeRoot gets the hash tree root of the state. It matches ssz.HashTreeRoot, but only
+// rehashes the parts of the large fields that changed since the last root calculated by this
+// state or any state it was copied from or to.
+func (s *State) HashTreeRoot() (chainhash.Hash, error) {
+	if s.XXXHashCache == nil {
+		s.XXXHashCache = newStateHashCache()
+	}
+
+	return s.XXXHashCache.hashTreeRoot(s)
+}
+
+func uint64Root(n uint64) chainhash.Hash {
+	var root chainhash.Hash
+	binary.LittleEndian.PutUint64(root[:], n)
+	return root
+}
+
+// hashTreeRoot gets the root of a state. The fields must be in the same order as the fields of
+// State.
+func (c *stateHashCache) hashTreeRoot(s *State) (chainhash.Hash, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	forkDataRoot, err := ssz.HashTreeRoot(s.ForkData)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	validatorRegistryRoot, err := c.validatorRegistryRoot(s.ValidatorRegistry)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	committeesRoot, err := c.committeesRoot(s.ShardAndCommitteeForSlots)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	latestCrosslinksRoot, err := crosslinksRoot(s.LatestCrosslinks, &c.latestCrosslinks, &c.latestCrosslinkList)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	previousCrosslinksRoot, err := crosslinksRoot(s.PreviousCrosslinks, &c.previousCrosslinks, &c.previousCrosslinkList)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	currentAttestationsRoot, err := pendingAttestationsRoot(s.CurrentEpochAttestations, &c.currentAttestations, &c.currentAttestationList)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	previousAttestationsRoot, err := pendingAttestationsRoot(s.PreviousEpochAttestations, &c.previousAttestations, &c.previousAttestationList)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	batchedBlockRootsRoot, err := ssz.HashTreeRoot(s.BatchedBlockRoots)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	shardRegistryRoot, err := ssz.HashTreeRoot(s.ShardRegistry)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	proposalsRoot, err := ssz.HashTreeRoot(s.Proposals)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	pendingVotesRoot, err := ssz.HashTreeRoot(s.PendingVotes)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	depositRootVotesRoot, err := ssz.HashTreeRoot(s.DepositRootVotes)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	fieldRoots := []chainhash.Hash{
+		uint64Root(s.Slot),
+		uint64Root(s.EpochIndex),
+		uint64Root(s.GenesisTime),
+		forkDataRoot,
+		validatorRegistryRoot,
+		c.validatorBalancesRoot(s.ValidatorBalances),
+		uint64Root(s.ValidatorRegistryLatestChangeEpoch),
+		uint64Root(s.ValidatorRegistryExitCount),
+		s.ValidatorRegistryDeltaChainTip,
+		s.RandaoMix,
+		committeesRoot,
+		uint64Root(s.PreviousJustifiedEpoch),
+		uint64Root(s.JustifiedEpoch),
+		uint64Root(s.JustificationBitfield),
+		uint64Root(s.FinalizedEpoch),
+		latestCrosslinksRoot,
+		previousCrosslinksRoot,
+		mixInLength(c.latestBlockHashTree.update(s.LatestBlockHashes), len(s.LatestBlockHashes)),
+		currentAttestationsRoot,
+		previousAttestationsRoot,
+		batchedBlockRootsRoot,
+		shardRegistryRoot,
+		proposalsRoot,
+		pendingVotesRoot,
+		s.LatestDepositRoot,
+		uint64Root(s.DepositIndex),
+		depositRootVotesRoot,
+	}
+
+	var tree merkleTree
+	return tree.update(fieldRoots), nil
+}
+
+func (c *stateHashCache) validatorRegistryRoot(validators []Validator) (chainhash.Hash, error) {
+	if len(validators) < len(c.validators) {
+		c.validators = c.validators[:len(validators)]
+	}
+
+	return c.validatorList.root(len(validators), func(i int) bool {
+		v := validators[i]
+		v.XXXPubkeyCached = nil
+		return v == c.validators[i]
+	}, func(i int) (chainhash.Hash, error) {
+		v := validators[i]
+		v.XXXPubkeyCached = nil
+
+		root, err := ssz.HashTreeRoot(v)
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+
+		if i < len(c.validators) {
+			c.validators[i] = v
+		} else {
+			c.validators = append(c.validators, v)
+		}
+		return root, nil
+	})
+}
+
+// validatorBalancesRoot packs balances into chunks of 4 and updates the tree of the chunks.
+func (c *stateHashCache) validatorBalancesRoot(balances []uint64) chainhash.Hash {
+	numChunks := (len(balances) + 3) / 4
+	if cap(c.balanceChunks) < numChunks {
+		c.balanceChunks = make([]chainhash.Hash, numChunks)
+	}
+	c.balanceChunks = c.balanceChunks[:numChunks]
+
+	for i := range c.balanceChunks {
+		c.balanceChunks[i] = chainhash.Hash{}
+	}
+
+	for i, balance := range balances {
+		binary.LittleEndian.PutUint64(c.balanceChunks[i/4][(i%4)*8:], balance)
+	}
+
+	return mixInLength(c.balanceTree.update(c.balanceChunks), len(balances))
+}
+
+func committeesEqual(a []ShardAndCommittee, b []ShardAndCommittee) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Shard != b[i].Shard || a[i].TotalValidatorCount != b[i].TotalValidatorCount || len(a[i].Committee) != len(b[i].Committee) {
+			return false
+		}
+
+		for j := range a[i].Committee {
+			if a[i].Committee[j] != b[i].Committee[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (c *stateHashCache) committeesRoot(committees [][]ShardAndCommittee) (chainhash.Hash, error) {
+	if len(committees) < len(c.committees) {
+		c.committees = c.committees[:len(committees)]
+	}
+
+	return c.committeeList.root(len(committees), func(i int) bool {
+		return committeesEqual(committees[i], c.committees[i])
+	}, func(i int) (chainhash.Hash, error) {
+		root, err := ssz.HashTreeRoot(committees[i])
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+
+		committeesCopy := make([]ShardAndCommittee, len(committees[i]))
+		for j := range committees[i] {
+			committeesCopy[j] = committees[i][j].Copy()
+		}
+
+		if i < len(c.committees) {
+			c.committees[i] = committeesCopy
+		} else {
+			c.committees = append(c.committees, committeesCopy)
+		}
+		return root, nil
+	})
+}
+
+func crosslinksRoot(crosslinks []Crosslink, cached *[]Crosslink, list *listCache) (chainhash.Hash, error) {
+	if len(crosslinks) < len(*cached) {
+		*cached = (*cached)[:len(crosslinks)]
+	}
+
+	return list.root(len(crosslinks), func(i int) bool {
+		return crosslinks[i] == (*cached)[i]
+	}, func(i int) (chainhash.Hash, error) {
+		root, err := ssz.HashTreeRoot(crosslinks[i])
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+
+		if i < len(*cached) {
+			(*cached)[i] = crosslinks[i]
+		} else {
+			*cached = append(*cached, crosslinks[i])
+		}
+		return root, nil
+	})
+}
+
+func pendingAttestationsEqual(a *PendingAttestation, b *PendingAttestation) bool {
+	return a.Data == b.Data &&
+		bytes.Equal(a.ParticipationBitfield, b.ParticipationBitfield) &&
+		bytes.Equal(a.CustodyBitfield, b.CustodyBitfield) &&
+		a.InclusionDelay == b.InclusionDelay &&
+		a.ProposerIndex == b.ProposerIndex
+}
+
+func pendingAttestationsRoot(attestations []PendingAttestation, cached *[]PendingAttestation, list *listCache) (chainhash.Hash, error) {
+	if len(attestations) < len(*cached) {
+		*cached = (*cached)[:len(attestations)]
+	}
+
+	return list.root(len(attestations), func(i int) bool {
+		return pendingAttestationsEqual(&attestations[i], &(*cached)[i])
+	}, func(i int) (chainhash.Hash, error) {
+		root, err := ssz.HashTreeRoot(attestations[i])
+		if err != nil {
+			return chainhash.Hash{}, err
+		}
+
+		if i < len(*cached) {
+			(*cached)[i] = attestations[i].Copy()
+		} else {
+			*cached = append(*cached, attestations[i].Copy())
+		}
+		return root, nil
+	})
+}
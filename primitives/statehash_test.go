@@ -0,0 +1,136 @@
+package primitives_test
+
+import (
+	"testing"
+
+	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/chainhash"
+	"github.com/phoreproject/synapse/primitives"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+func checkStateHashTreeRoot(t *testing.T, s *primitives.State, description string) {
+	t.Helper()
+
+	expected, err := ssz.HashTreeRoot(*s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := s.HashTreeRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if root != expected {
+		t.Fatalf("expected cached state root to match go-ssz after %s (expected: %x, got: %x)", description, expected, root)
+	}
+}
+
+func TestStateHashTreeRootMatchesSSZ(t *testing.T) {
+	c := config.RegtestConfig
+	c.ShardCount = 8
+
+	s, _, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkStateHashTreeRoot(t, s, "initializing the state")
+
+	s.ValidatorBalances[3] += 7
+	checkStateHashTreeRoot(t, s, "changing a balance")
+
+	s.ValidatorRegistry[10].Status = primitives.ExitedWithPenalty
+	s.ValidatorRegistry[10].LatestStatusChangeSlot = 5
+	checkStateHashTreeRoot(t, s, "changing a validator")
+
+	s.LatestBlockHashes[1] = chainhash.HashH([]byte("block"))
+	checkStateHashTreeRoot(t, s, "changing a block hash")
+
+	s.ValidatorRegistry = append(s.ValidatorRegistry, s.ValidatorRegistry[0])
+	s.ValidatorBalances = append(s.ValidatorBalances, c.MaxDeposit)
+	checkStateHashTreeRoot(t, s, "adding a validator")
+
+	s.ValidatorRegistry = s.ValidatorRegistry[:len(s.ValidatorRegistry)-2]
+	s.ValidatorBalances = s.ValidatorBalances[:len(s.ValidatorBalances)-2]
+	checkStateHashTreeRoot(t, s, "removing validators")
+
+	s.ShardAndCommitteeForSlots[2][0].Committee[0]++
+	checkStateHashTreeRoot(t, s, "changing a committee")
+
+	s.LatestCrosslinks[4].ShardBlockHash = chainhash.HashH([]byte("shard block"))
+	s.PreviousCrosslinks = append(s.PreviousCrosslinks, s.LatestCrosslinks[4])
+	checkStateHashTreeRoot(t, s, "changing crosslinks")
+
+	attestation := primitives.PendingAttestation{
+		Data:                  primitives.AttestationData{Slot: 1, Shard: 2},
+		ParticipationBitfield: []byte{1},
+		CustodyBitfield:       []byte{0},
+		InclusionDelay:        1,
+	}
+	s.CurrentEpochAttestations = append(s.CurrentEpochAttestations, attestation, attestation.Copy())
+	checkStateHashTreeRoot(t, s, "adding attestations")
+
+	s.CurrentEpochAttestations[1].ParticipationBitfield[0] = 3
+	checkStateHashTreeRoot(t, s, "changing an attestation bitfield")
+
+	s.PreviousEpochAttestations = s.CurrentEpochAttestations
+	s.CurrentEpochAttestations = nil
+	checkStateHashTreeRoot(t, s, "moving attestations to the previous epoch")
+
+	s.DepositRootVotes = append(s.DepositRootVotes, primitives.DepositRootVote{DepositRoot: chainhash.HashH([]byte("deposits")), VoteCount: 2})
+	checkStateHashTreeRoot(t, s, "voting for a deposit root")
+
+	// copies share the cache, so hashing them in turn updates the cache back and forth
+	copied := s.Copy()
+	copied.ValidatorBalances[0]++
+	copied.LatestBlockHashes[2] = chainhash.HashH([]byte("other block"))
+	checkStateHashTreeRoot(t, &copied, "changing a copy")
+	checkStateHashTreeRoot(t, s, "hashing the original of a copy")
+	checkStateHashTreeRoot(t, &copied, "hashing the copy again")
+}
+
+func TestStateHashTreeRootMatchesSSZAfterTransitions(t *testing.T) {
+	c := config.RegtestConfig
+	c.EpochLength = 8
+	c.ShardCount = 8
+	c.LatestBlockRootsLength = 16
+
+	s, _, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// this covers epoch transitions and filling the latest block hashes
+	for s.Slot < 4*c.EpochLength {
+		_, err := s.ProcessSlots(s.Slot+1, FakeBlockView{}, &c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkStateHashTreeRoot(t, s, "processing a slot")
+	}
+}
+
+func BenchmarkStateHashTreeRoot(b *testing.B) {
+	c := &config.RegtestConfig
+
+	s, _, err := SetupState(c.ShardCount*c.TargetCommitteeSize*2+5, c)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s.Slot++
+		s.LatestBlockHashes[s.Slot%c.LatestBlockRootsLength] = chainhash.HashH(s.RandaoMix[:])
+		s.ValidatorBalances[s.Slot%uint64(len(s.ValidatorBalances))]++
+
+		_, err := s.HashTreeRoot()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
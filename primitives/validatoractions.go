@@ -5,6 +5,7 @@ import (
 
 	"github.com/phoreproject/synapse/chainhash"
 	"github.com/phoreproject/synapse/pb"
+	"github.com/prysmaticlabs/go-ssz"
 )
 
 // DepositParameters are the parameters the depositer needs
@@ -51,12 +52,37 @@ func DepositParametersFromProto(parameters *pb.DepositParameters) (*DepositParam
 // Deposit is a new deposit from a shard.
 type Deposit struct {
 	Parameters DepositParameters
+	Amount     uint64
+
+	// Index is the index of the deposit in the deposit tree.
+	Index uint64
+
+	// Proof is the Merkle branch proving the deposit is included in the deposit tree.
+	Proof []chainhash.Hash
+}
+
+// depositData is the data committed to by a leaf in the deposit tree.
+type depositData struct {
+	Parameters DepositParameters
+	Amount     uint64
+}
+
+// Leaf gets the hash of the deposit as stored in the deposit tree.
+func (d *Deposit) Leaf() (chainhash.Hash, error) {
+	return ssz.HashTreeRoot(depositData{d.Parameters, d.Amount})
 }
 
 // ToProto gets the protobuf representation of the deposit.
 func (d Deposit) ToProto() *pb.Deposit {
+	proof := make([][]byte, len(d.Proof))
+	for i := range d.Proof {
+		proof[i] = d.Proof[i][:]
+	}
 	return &pb.Deposit{
 		Parameters: d.Parameters.ToProto(),
+		Amount:     d.Amount,
+		Index:      d.Index,
+		Proof:      proof,
 	}
 }
 
@@ -66,12 +92,87 @@ func DepositFromProto(deposit *pb.Deposit) (*Deposit, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Deposit{*parameters}, nil
+	var proof []chainhash.Hash
+	for _, p := range deposit.Proof {
+		var h chainhash.Hash
+		err := h.SetBytes(p)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, h)
+	}
+	return &Deposit{
+		Parameters: *parameters,
+		Amount:     deposit.Amount,
+		Index:      deposit.Index,
+		Proof:      proof,
+	}, nil
 }
 
 // Copy returns a copy of the deposit.
 func (d Deposit) Copy() Deposit {
-	return Deposit{d.Parameters.Copy()}
+	return Deposit{
+		Parameters: d.Parameters.Copy(),
+		Amount:     d.Amount,
+		Index:      d.Index,
+		Proof:      append([]chainhash.Hash(nil), d.Proof...),
+	}
+}
+
+// VerifyMerkleBranch checks that a leaf at a certain index is included in a Merkle
+// tree of the given depth with the given root.
+func VerifyMerkleBranch(leaf chainhash.Hash, branch []chainhash.Hash, depth uint64, index uint64, root chainhash.Hash) bool {
+	if uint64(len(branch)) != depth {
+		return false
+	}
+	value := leaf
+	for i := uint64(0); i < depth; i++ {
+		if (index>>i)&1 == 1 {
+			value = chainhash.HashH(append(branch[i][:], value[:]...))
+		} else {
+			value = chainhash.HashH(append(value[:], branch[i][:]...))
+		}
+	}
+	return value.IsEqual(&root)
+}
+
+// EmptyDepositTreeRoot gets the root of a deposit tree of the given depth without any
+// deposits.
+func EmptyDepositTreeRoot(depth uint64) chainhash.Hash {
+	var root chainhash.Hash
+	for i := uint64(0); i < depth; i++ {
+		root = chainhash.HashH(append(root[:], root[:]...))
+	}
+	return root
+}
+
+// DepositRootVote is the number of blocks in the current voting period that voted for a
+// deposit root.
+type DepositRootVote struct {
+	DepositRoot chainhash.Hash
+	VoteCount   uint64
+}
+
+// ToProto gets the protobuf representation of the deposit root vote.
+func (v *DepositRootVote) ToProto() *pb.DepositRootVote {
+	return &pb.DepositRootVote{
+		DepositRoot: v.DepositRoot[:],
+		VoteCount:   v.VoteCount,
+	}
+}
+
+// DepositRootVoteFromProto gets the deposit root vote from the protobuf representation.
+func DepositRootVoteFromProto(vote *pb.DepositRootVote) (*DepositRootVote, error) {
+	v := &DepositRootVote{
+		VoteCount: vote.VoteCount,
+	}
+
+	err := v.DepositRoot.SetBytes(vote.DepositRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
 }
 
 // Exit exits the validator.
@@ -52,7 +52,7 @@ func TestDepositParameters_ToFromProto(t *testing.T) {
 }
 
 func TestDeposit_Copy(t *testing.T) {
-	baseDeposit := &primitives.Deposit{Parameters: primitives.DepositParameters{}}
+	baseDeposit := &primitives.Deposit{Parameters: primitives.DepositParameters{}, Proof: []chainhash.Hash{{}}}
 
 	copyDeposit := baseDeposit.Copy()
 
@@ -60,6 +60,11 @@ func TestDeposit_Copy(t *testing.T) {
 	if baseDeposit.Parameters.WithdrawalCredentials[0] == 1 {
 		t.Fatal("mutating depositParameters mutates base")
 	}
+
+	copyDeposit.Proof[0][0] = 1
+	if baseDeposit.Proof[0][0] == 1 {
+		t.Fatal("mutating proof mutates base")
+	}
 }
 
 func TestDeposit_ToFromProto(t *testing.T) {
@@ -67,6 +72,9 @@ func TestDeposit_ToFromProto(t *testing.T) {
 		Parameters: primitives.DepositParameters{
 			PubKey: [96]byte{},
 		},
+		Amount: 1,
+		Index:  2,
+		Proof:  []chainhash.Hash{{3}},
 	}
 
 	depositProto := baseDeposit.ToProto()
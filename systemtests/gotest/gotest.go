@@ -36,10 +36,18 @@ func (app *goTestApp) Height() uint64 {
 	return 1
 }
 
+func (app *goTestApp) TipSlot() uint64 {
+	return 0
+}
+
 func (app *goTestApp) GenesisHash() chainhash.Hash {
 	return app.genesisHash
 }
 
+func (app *goTestApp) ForkVersion() uint64 {
+	return 0
+}
+
 func (app *goTestApp) run() {
 	app.parseArgs()
 
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/beevik/ntp"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.New().WithField("module", "ntp")
+
+// Clock tells the time and waits for time to pass. Anything that depends on the current time
+// should use a clock so it can be tested without waiting.
+type Clock interface {
+	// Now gets the current time.
+	Now() time.Time
+
+	// After waits for the duration to pass and then sends the current time on the returned
+	// channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a clock using the time of the computer, adjusted by the offset from an NTP
+// server if it was checked.
+type RealClock struct {
+	lock   *sync.RWMutex
+	offset time.Duration
+}
+
+// NewRealClock creates a clock using the time of the computer.
+func NewRealClock() *RealClock {
+	return &RealClock{
+		lock: new(sync.RWMutex),
+	}
+}
+
+// CheckNTP queries an NTP server and adjusts the clock by the offset of the computer time from
+// the NTP server.
+func (c *RealClock) CheckNTP() {
+	res, err := ntp.Query("pool.ntp.org")
+	if err != nil {
+		log.Warn("could not connect to NTP server to check time offset")
+		return
+	}
+
+	log.WithField("offset", res.ClockOffset).Info("got clock offset from NTP server")
+
+	c.lock.Lock()
+	c.offset = res.ClockOffset
+	c.lock.Unlock()
+}
+
+// Now gets the true time (not relying on computer time)
+func (c *RealClock) Now() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return time.Now().Add(c.offset)
+}
+
+// After implements Clock.
+func (c *RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type manualWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// ManualClock is a clock that only moves when it's set or advanced, so tests can move through
+// time instantly.
+type ManualClock struct {
+	lock    *sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+}
+
+// NewManualClock creates a manual clock starting at a certain time.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{
+		lock: new(sync.Mutex),
+		now:  now,
+	}
+}
+
+// Now implements Clock.
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// After implements Clock. The channel receives the time once the clock is moved past the
+// deadline.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	waiter := manualWaiter{
+		deadline: c.now.Add(d),
+		c:        make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		waiter.c <- c.now
+		return waiter.c
+	}
+
+	c.waiters = append(c.waiters, waiter)
+	return waiter.c
+}
+
+// Set sets the time of the clock and wakes up anything waiting for a time up to the new time.
+func (c *ManualClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.set(now)
+}
+
+// Advance moves the clock forward by a duration.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+func (c *ManualClock) set(now time.Time) {
+	c.now = now
+
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(now) {
+			waiting = append(waiting, w)
+		} else {
+			w.c <- now
+		}
+	}
+	c.waiters = waiting
+}
+
+// Waiters gets the number of callers waiting for the clock to reach a certain time.
+func (c *ManualClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.waiters)
+}
+
+var _ Clock = &RealClock{}
+var _ Clock = &ManualClock{}
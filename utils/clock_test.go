@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func received(c <-chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestManualClock(t *testing.T) {
+	start := time.Unix(1560000000, 0)
+	clock := NewManualClock(start)
+
+	if !received(clock.After(0)) {
+		t.Fatal("expected waiting for no time to return immediately")
+	}
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+
+	if clock.Waiters() != 2 {
+		t.Fatalf("expected 2 waiters, got %d", clock.Waiters())
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if received(short) || received(long) {
+		t.Fatal("expected waiters to wait until their deadline")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if !received(short) {
+		t.Fatal("expected waiter to be woken up at its deadline")
+	}
+	if received(long) {
+		t.Fatal("expected later waiter to keep waiting")
+	}
+
+	clock.Set(start.Add(time.Hour))
+	if !received(long) {
+		t.Fatal("expected waiter to be woken up when the clock is set past its deadline")
+	}
+
+	if clock.Waiters() != 0 {
+		t.Fatalf("expected no waiters left, got %d", clock.Waiters())
+	}
+
+	if !clock.Now().Equal(start.Add(time.Hour)) {
+		t.Fatalf("expected clock to be at %s, got %s", start.Add(time.Hour), clock.Now())
+	}
+}
@@ -60,7 +60,7 @@ func (v *ValidatorApp) Run() error {
 
 	log.Info("Validators successfully verified!")
 
-	vm, err := validator.NewManager(v.ctx, blockchainRPC, v.config.ValidatorIndices, keystore, v.config.NetworkConfig)
+	vm, err := validator.NewManager(v.ctx, blockchainRPC, v.config.ValidatorIndices, keystore, v.config.NetworkConfig, v.config.Clock)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/phoreproject/synapse/beacon/config"
+	"github.com/phoreproject/synapse/utils"
 	"google.golang.org/grpc"
 )
 
@@ -14,6 +15,7 @@ type ValidatorConfig struct {
 	NetworkConfig    *config.Config
 	ValidatorIndices []uint32
 	RootKey          string
+	Clock            utils.Clock
 }
 
 // ParseValidatorIndices parses validator indices given a user-supplied list of ranges.
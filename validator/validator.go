@@ -6,8 +6,6 @@ import (
 	"github.com/phoreproject/synapse/beacon/config"
 	"github.com/sirupsen/logrus"
 
-	"github.com/phoreproject/synapse/primitives"
-
 	"github.com/phoreproject/synapse/pb"
 )
 
@@ -18,20 +16,18 @@ type Validator struct {
 	id                 uint32
 	logger             *logrus.Entry
 	config             *config.Config
-	forkData           *primitives.ForkData
 	attestationRequest chan attestationAssignment
 	proposerRequest    chan proposerAssignment
 	ctx                context.Context
 }
 
 // NewValidator gets a validator
-func NewValidator(ctx context.Context, keystore Keystore, blockchainRPC pb.BlockchainRPCClient, id uint32, c *config.Config, f *primitives.ForkData) (*Validator, error) {
+func NewValidator(ctx context.Context, keystore Keystore, blockchainRPC pb.BlockchainRPCClient, id uint32, c *config.Config) (*Validator, error) {
 	v := &Validator{
 		keystore:           keystore,
 		blockchainRPC:      blockchainRPC,
 		id:                 id,
 		config:             c,
-		forkData:           f,
 		attestationRequest: make(chan attestationAssignment),
 		proposerRequest:    make(chan proposerAssignment),
 		ctx:                ctx,
@@ -29,8 +29,8 @@ func getAttestation(information attestationAssignment) (*primitives.AttestationD
 	return &a, hashAttestation, nil
 }
 
-func (v *Validator) signAttestation(hashAttestation [32]byte, data primitives.AttestationData, committeeSize uint64, committeeIndex uint64) (*primitives.Attestation, error) {
-	signature, err := bls.Sign(v.keystore.GetKeyForValidator(v.id), hashAttestation[:], primitives.GetDomain(*v.forkData, data.Slot, bls.DomainAttestation))
+func (v *Validator) signAttestation(hashAttestation [32]byte, data primitives.AttestationData, committeeSize uint64, committeeIndex uint64, forkData primitives.ForkData) (*primitives.Attestation, error) {
+	signature, err := bls.Sign(v.keystore.GetKeyForValidator(v.id), hashAttestation[:], primitives.GetDomain(forkData, data.Slot, bls.DomainAttestation))
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (v *Validator) attestBlock(information attestationAssignment) (*primitives.
 	}
 
 	// sign attestation
-	att, err := v.signAttestation(hash, *attData, information.committeeSize, information.committeeIndex)
+	att, err := v.signAttestation(hash, *attData, information.committeeSize, information.committeeIndex, information.forkData)
 	if err != nil {
 		return nil, err
 	}
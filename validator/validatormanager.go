@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/phoreproject/synapse/utils"
@@ -27,15 +28,22 @@ type attestationAssignment struct {
 	sourceHash       chainhash.Hash
 	targetEpoch      uint64
 	targetHash       chainhash.Hash
+	forkData         primitives.ForkData
 }
 
 type proposerAssignment struct {
-	slot uint64
+	slot     uint64
+	forkData primitives.ForkData
+}
+
+type attesterDuty struct {
+	validator      uint32
+	shard          uint64
+	committeeIndex uint64
+	committeeSize  uint64
 }
 
 type epochInformation struct {
-	slots                  [][]primitives.ShardAndCommittee
-	earliestSlot           int64
 	targetHash             chainhash.Hash
 	justifiedEpoch         uint64
 	latestCrosslinks       []primitives.Crosslink
@@ -49,24 +57,10 @@ type epochInformation struct {
 // epochInformationFromProto gets the epoch information from the protobuf format
 func epochInformationFromProto(information *pb.EpochInformation) (*epochInformation, error) {
 	ei := &epochInformation{
-		slots:                  make([][]primitives.ShardAndCommittee, len(information.ShardCommitteesForSlots)),
 		justifiedEpoch:         information.JustifiedEpoch,
 		previousJustifiedEpoch: information.PreviousJustifiedEpoch,
 		latestCrosslinks:       make([]primitives.Crosslink, len(information.LatestCrosslinks)),
 		previousCrosslinks:     make([]primitives.Crosslink, len(information.PreviousCrosslinks)),
-		earliestSlot:           information.Slot,
-	}
-
-	for i := range ei.slots {
-		ei.slots[i] = make([]primitives.ShardAndCommittee, len(information.ShardCommitteesForSlots[i].Committees))
-		for j := range ei.slots[i] {
-			sc, err := primitives.ShardAndCommitteeFromProto(information.ShardCommitteesForSlots[i].Committees[j])
-			if err != nil {
-				return nil, err
-			}
-
-			ei.slots[i][j] = *sc
-		}
 	}
 
 	for i := range ei.latestCrosslinks {
@@ -111,14 +105,20 @@ type Manager struct {
 	keystore               Keystore
 	latestEpochInformation epochInformation
 	epochIndex             uint64
+	dutiesEpochs           map[uint64]struct{}
+	proposerDuties         map[uint64]uint32
+	attesterDuties         map[uint64][]attesterDuty
 	currentSlot            uint64
 	config                 *config.Config
 	synced                 bool
 	genesisTime            uint64
+	clock                  utils.Clock
+	forkData               primitives.ForkData
 }
 
-// NewManager creates a new validator manager to manage some validators.
-func NewManager(ctx context.Context, blockchainRPC pb.BlockchainRPCClient, validators []uint32, keystore Keystore, c *config.Config) (*Manager, error) {
+// NewManager creates a new validator manager to manage some validators. The manager uses the
+// clock to wait for the slots the validators are assigned to.
+func NewManager(ctx context.Context, blockchainRPC pb.BlockchainRPCClient, validators []uint32, keystore Keystore, c *config.Config, clock utils.Clock) (*Manager, error) {
 	validatorObjs := make(map[uint32]*Validator)
 
 	forkDataProto, err := blockchainRPC.GetForkData(context.Background(), &empty.Empty{})
@@ -132,7 +132,7 @@ func NewManager(ctx context.Context, blockchainRPC pb.BlockchainRPCClient, valid
 	}
 
 	for idx, id := range validators {
-		v, err := NewValidator(ctx, keystore, blockchainRPC, validators[idx], c, forkData)
+		v, err := NewValidator(ctx, keystore, blockchainRPC, validators[idx], c)
 		if err != nil {
 			return nil, err
 		}
@@ -140,21 +140,128 @@ func NewManager(ctx context.Context, blockchainRPC pb.BlockchainRPCClient, valid
 	}
 
 	vm := &Manager{
-		blockchainRPC: blockchainRPC,
-		validatorMap:  validatorObjs,
-		keystore:      keystore,
-		config:        c,
-		currentSlot:   0,
-		synced:        false,
+		blockchainRPC:  blockchainRPC,
+		validatorMap:   validatorObjs,
+		keystore:       keystore,
+		config:         c,
+		currentSlot:    0,
+		synced:         false,
+		dutiesEpochs:   make(map[uint64]struct{}),
+		proposerDuties: make(map[uint64]uint32),
+		attesterDuties: make(map[uint64][]attesterDuty),
+		clock:          clock,
+		forkData:       *forkData,
 	}
 	logrus.Debug("initializing attestation listener")
 
 	return vm, nil
 }
 
+// dutiesEpoch gets the epoch to request duties for a slot from. Committees assigned at a slot
+// propose and attest in the following slot, so the duties for the first slot of an epoch are part
+// of the previous epoch.
+func (vm *Manager) dutiesEpoch(slot uint64) uint64 {
+	if slot == 0 {
+		return 0
+	}
+	return (slot - 1) / vm.config.EpochLength
+}
+
+// updateDuties requests the duties of the managed validators for an epoch if they haven't
+// been requested yet. Duties more than an epoch old are discarded.
+func (vm *Manager) updateDuties(epoch uint64) error {
+	if _, found := vm.dutiesEpochs[epoch]; found {
+		return nil
+	}
+
+	validators := make([]uint32, 0, len(vm.validatorMap))
+	for id := range vm.validatorMap {
+		validators = append(validators, id)
+	}
+
+	// request duties in a consistent order so attestations are always submitted in the same order
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i] < validators[j]
+	})
+
+	dutiesResponse, err := vm.blockchainRPC.GetValidatorDuties(context.Background(), &pb.GetValidatorDutiesRequest{
+		Epoch:      epoch,
+		Validators: validators,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range dutiesResponse.Duties {
+		for _, slot := range d.ProposerSlots {
+			vm.proposerDuties[slot] = d.Validator
+		}
+
+		for _, a := range d.AttesterDuties {
+			vm.attesterDuties[a.Slot] = append(vm.attesterDuties[a.Slot], attesterDuty{
+				validator:      d.Validator,
+				shard:          a.Shard,
+				committeeIndex: a.CommitteeIndex,
+				committeeSize:  a.CommitteeSize,
+			})
+		}
+	}
+
+	vm.dutiesEpochs[epoch] = struct{}{}
+
+	for e := range vm.dutiesEpochs {
+		if e+1 < epoch {
+			delete(vm.dutiesEpochs, e)
+		}
+	}
+
+	for slot := range vm.proposerDuties {
+		if slot/vm.config.EpochLength+1 < epoch {
+			delete(vm.proposerDuties, slot)
+		}
+	}
+
+	for slot := range vm.attesterDuties {
+		if slot/vm.config.EpochLength+1 < epoch {
+			delete(vm.attesterDuties, slot)
+		}
+	}
+
+	return nil
+}
+
+// updateForkData requests the fork data of the head state so validators sign with the fork
+// version of the current slot. Validators are given a copy of the fork data with each
+// assignment.
+func (vm *Manager) updateForkData() error {
+	forkDataProto, err := vm.blockchainRPC.GetForkData(context.Background(), &empty.Empty{})
+	if err != nil {
+		return err
+	}
+
+	forkData, err := primitives.ForkDataFromProto(forkDataProto)
+	if err != nil {
+		return err
+	}
+
+	vm.forkData = *forkData
+
+	return nil
+}
+
 // UpdateEpochInformation updates epoch information from the beacon chain
 func (vm *Manager) UpdateEpochInformation(slotNumber uint64) error {
-	epochInformation, err := vm.blockchainRPC.GetEpochInformation(context.Background(), &pb.EpochInformationRequest{EpochIndex: slotNumber / vm.config.EpochLength})
+	epochIndex := slotNumber / vm.config.EpochLength
+
+	err := vm.updateForkData()
+	if err != nil {
+		return err
+	}
+
+	epochInformation, err := vm.blockchainRPC.GetEpochInformation(context.Background(), &pb.EpochInformationRequest{
+		EpochIndex:        epochIndex,
+		ExcludeCommittees: true,
+	})
 	if err != nil {
 		return err
 	}
@@ -168,45 +275,75 @@ func (vm *Manager) UpdateEpochInformation(slotNumber uint64) error {
 		return err
 	}
 
+	err = vm.updateDuties(epochIndex)
+	if err != nil {
+		return err
+	}
+
 	vm.latestEpochInformation = *ei
 	vm.synced = true
-	vm.epochIndex = slotNumber / vm.config.EpochLength
+	vm.epochIndex = epochIndex
 
 	return nil
 }
 
 // NewSlot is run when a new slot starts.
 func (vm *Manager) NewSlot(slotNumber uint64) error {
-	earliestSlot := vm.latestEpochInformation.earliestSlot
 	logrus.WithField("slot", slotNumber).Debug("heard new slot")
 
-	proposerSlotCommittees := vm.latestEpochInformation.slots[int64(slotNumber-1)-earliestSlot]
-
-	proposer := proposerSlotCommittees[0].Committee[(slotNumber-1)%uint64(len(proposerSlotCommittees[0].Committee))]
-	if validator, found := vm.validatorMap[proposer]; found {
-		err := validator.proposeBlock(context.Background(), proposerAssignment{
-			slot: uint64(slotNumber),
-		})
-		if err != nil {
-			fmt.Println(err)
-		}
+	err := vm.ProposeForSlot(slotNumber)
+	if err != nil {
+		fmt.Println(err)
 	}
 
 	halfSlot := time.Unix(int64(slotNumber*uint64(vm.config.SlotDuration)+vm.genesisTime+uint64((vm.config.SlotDuration+1)/2)), 5e8)
 
-	<-time.NewTimer(halfSlot.Sub(utils.Now())).C
+	<-vm.clock.After(halfSlot.Sub(vm.clock.Now()))
+
+	return vm.AttestForSlot(slotNumber)
+}
+
+// ProposeForSlot proposes a block if one of the managed validators is the proposer for the
+// slot.
+func (vm *Manager) ProposeForSlot(slotNumber uint64) error {
+	err := vm.updateDuties(vm.dutiesEpoch(slotNumber))
+	if err != nil {
+		return err
+	}
 
+	proposer, found := vm.proposerDuties[slotNumber]
+	if !found {
+		return nil
+	}
+
+	// a fork may have activated since the epoch information was last updated
+	err = vm.updateForkData()
+	if err != nil {
+		return err
+	}
+
+	validator := vm.validatorMap[proposer]
+	return validator.proposeBlock(context.Background(), proposerAssignment{
+		slot:     uint64(slotNumber),
+		forkData: vm.forkData,
+	})
+}
+
+// AttestForSlot updates the epoch information and submits attestations for any of the
+// managed validators assigned to attest in the slot.
+func (vm *Manager) AttestForSlot(slotNumber uint64) error {
 	logrus.WithField("slot", slotNumber).Debug("requesting epoch information")
 	if err := vm.UpdateEpochInformation(slotNumber); err != nil {
 		return err
 	}
 	logrus.WithField("index", vm.epochIndex).Debug("got epoch information")
 
-	earliestSlot = vm.latestEpochInformation.earliestSlot
-
 	slotToAttest := slotNumber
 
-	slotCommittees := vm.latestEpochInformation.slots[int64(slotToAttest)-earliestSlot-1] // we actually want to attest MinAttestationInclusionDistance after the slot
+	err := vm.updateDuties(vm.dutiesEpoch(slotToAttest))
+	if err != nil {
+		return err
+	}
 
 	blockHashResponse, err := vm.blockchainRPC.GetBlockHash(context.Background(), &pb.GetBlockHashRequest{
 		SlotNumber: slotToAttest,
@@ -221,47 +358,44 @@ func (vm *Manager) NewSlot(slotNumber uint64) error {
 	}
 
 	if slotToAttest > 0 {
-		for _, committee := range slotCommittees {
-			shard := committee.Shard
-
-			for committeeIndex, vIndex := range committee.Committee {
-				if validator, found := vm.validatorMap[vIndex]; found {
-					sourceEpoch := vm.latestEpochInformation.justifiedEpoch
-					sourceHash := vm.latestEpochInformation.justifiedHash
-					targetEpoch := vm.epochIndex
-					targetHash := vm.latestEpochInformation.targetHash
-					crosslinks := vm.latestEpochInformation.latestCrosslinks
-
-					if slotToAttest%vm.config.EpochLength == 0 {
-						targetEpoch--
-						targetHash = vm.latestEpochInformation.previousTargetHash
-						sourceEpoch = vm.latestEpochInformation.previousJustifiedEpoch
-						sourceHash = vm.latestEpochInformation.previousJustifiedHash
-						crosslinks = vm.latestEpochInformation.previousCrosslinks
-					}
-
-					att, err := validator.attestBlock(attestationAssignment{
-						slot:             slotToAttest,
-						shard:            shard,
-						committeeIndex:   uint64(committeeIndex),
-						committeeSize:    uint64(len(committee.Committee)),
-						beaconBlockHash:  *blockHash,
-						latestCrosslinks: crosslinks,
-						sourceEpoch:      sourceEpoch,
-						sourceHash:       sourceHash,
-						targetEpoch:      targetEpoch,
-						targetHash:       targetHash,
-					})
-					if err != nil {
-						return err
-					}
-
-					_, err = vm.blockchainRPC.SubmitAttestation(context.Background(), att.ToProto())
-					if err != nil {
-						fmt.Println(err)
-						return nil
-					}
-				}
+		for _, duty := range vm.attesterDuties[slotToAttest] {
+			validator := vm.validatorMap[duty.validator]
+
+			sourceEpoch := vm.latestEpochInformation.justifiedEpoch
+			sourceHash := vm.latestEpochInformation.justifiedHash
+			targetEpoch := vm.epochIndex
+			targetHash := vm.latestEpochInformation.targetHash
+			crosslinks := vm.latestEpochInformation.latestCrosslinks
+
+			if slotToAttest%vm.config.EpochLength == 0 {
+				targetEpoch--
+				targetHash = vm.latestEpochInformation.previousTargetHash
+				sourceEpoch = vm.latestEpochInformation.previousJustifiedEpoch
+				sourceHash = vm.latestEpochInformation.previousJustifiedHash
+				crosslinks = vm.latestEpochInformation.previousCrosslinks
+			}
+
+			att, err := validator.attestBlock(attestationAssignment{
+				slot:             slotToAttest,
+				shard:            duty.shard,
+				committeeIndex:   duty.committeeIndex,
+				committeeSize:    duty.committeeSize,
+				beaconBlockHash:  *blockHash,
+				latestCrosslinks: crosslinks,
+				sourceEpoch:      sourceEpoch,
+				sourceHash:       sourceHash,
+				targetEpoch:      targetEpoch,
+				targetHash:       targetHash,
+				forkData:         vm.forkData,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = vm.blockchainRPC.SubmitAttestation(context.Background(), att.ToProto())
+			if err != nil {
+				fmt.Println(err)
+				return nil
 			}
 		}
 	}
@@ -304,7 +438,13 @@ func (vm *Manager) ListenForBlockAndCycle() error {
 	nextSlotTime := time.Unix(int64(nextEpochSlot*uint64(vm.config.SlotDuration)+genesisTime), 5e8)
 	slotNumber := nextEpochSlot
 
-	<-time.NewTimer(nextSlotTime.Sub(utils.Now())).C
+	// request duties for the first slot now so they're ready when the slot starts
+	err = vm.updateDuties(vm.dutiesEpoch(slotNumber))
+	if err != nil {
+		return err
+	}
+
+	<-vm.clock.After(nextSlotTime.Sub(vm.clock.Now()))
 
 	logrus.WithField("slot", slotNumber).Debug("requesting epoch information")
 	if err := vm.UpdateEpochInformation(slotNumber); err != nil {
@@ -321,7 +461,7 @@ func (vm *Manager) ListenForBlockAndCycle() error {
 		slotNumber = slotNumber + 1
 		nextSlotTime = time.Unix(int64(slotNumber*uint64(vm.config.SlotDuration)+genesisTime), 5e8)
 
-		<-time.NewTimer(nextSlotTime.Sub(utils.Now())).C
+		<-vm.clock.After(nextSlotTime.Sub(vm.clock.Now()))
 	}
 
 }
@@ -34,7 +34,7 @@ func (v *Validator) proposeBlock(ctx context.Context, information proposerAssign
 
 	key := v.keystore.GetKeyForValidator(v.id)
 
-	randaoSig, err := bls.Sign(key, slotBytesHash[:], bls.DomainRandao)
+	randaoSig, err := bls.Sign(key, slotBytesHash[:], primitives.GetDomain(information.forkData, information.slot, bls.DomainRandao))
 	if err != nil {
 		return err
 	}
@@ -59,7 +59,7 @@ func (v *Validator) proposeBlock(ctx context.Context, information proposerAssign
 	}
 
 	v.logger.WithFields(logrus.Fields{
-		"mempoolSize": len(mempool.Attestations) + len(mempool.Deposits) + len(mempool.CasperSlashings) + len(mempool.ProposerSlashings),
+		"mempoolSize": len(mempool.Attestations) + len(mempool.Deposits) + len(mempool.CasperSlashings) + len(mempool.ProposerSlashings) + len(mempool.Exits),
 		"slot":        information.slot,
 	}).Debug("creating block")
 
@@ -97,7 +97,7 @@ func (v *Validator) proposeBlock(ctx context.Context, information proposerAssign
 		return err
 	}
 
-	sig, err := bls.Sign(v.keystore.GetKeyForValidator(v.id), psdHash[:], bls.DomainProposal)
+	sig, err := bls.Sign(v.keystore.GetKeyForValidator(v.id), psdHash[:], primitives.GetDomain(information.forkData, information.slot, bls.DomainProposal))
 	if err != nil {
 		return err
 	}